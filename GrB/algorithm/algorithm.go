@@ -0,0 +1,20 @@
+/*
+Package algorithm provides a number of well-known graph algorithms expressed in the language of linear algebra
+with the [GrB] package, along the lines of the corresponding algorithms in LAGraph.
+
+Unless stated otherwise, graphs are represented by their adjacency matrices, where an entry A(i, j) denotes
+an edge from vertex i to vertex j. The values of the entries are ignored, except where an algorithm
+explicitly uses edge weights. The caller is responsible for freeing all GraphBLAS objects returned
+by the functions in this package.
+
+The shortest path algorithms, such as [ShortestPathsBellmanFord], work on weighted graphs, where A(i, j)
+is the weight of the edge from vertex i to vertex j, over the min-plus semiring. In the resulting
+distance matrix, dist(r, j) is the length of a shortest path from the r-th source to vertex j.
+Vertices that are not reachable from a source have no entry in its row. If sources is nil, all
+vertices are sources in order, and dist is the n x n all-pairs distance matrix. A source that is
+not a vertex causes an error wrapping [GrB.InvalidIndex]. Negative weights are allowed, but an
+error wrapping [GrB.InvalidValue] is returned if there is a negative cycle.
+
+Package algorithm is a forGraphBLASGo extension.
+*/
+package algorithm
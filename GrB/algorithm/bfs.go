@@ -0,0 +1,87 @@
+package algorithm
+
+import "github.com/intel/forGraphBLASGo/GrB"
+
+// BFSLevels performs a breadth-first search of the graph represented by the n x n adjacency matrix A,
+// starting at the given source vertex. In the resulting vector, level(i) is the length of the
+// shortest path from the source to vertex i, so that level(source) = 0. Vertices that are not
+// reachable from the source have no entry in the result.
+func BFSLevels[D GrB.Predefined](A GrB.Matrix[D], source int) (level GrB.Vector[int], err error) {
+	defer GrB.CheckErrors(&err)
+
+	n, err := A.Nrows()
+	GrB.OK(err)
+
+	level, err = GrB.VectorNew[int](n)
+	GrB.OK(err)
+	defer func() {
+		if err != nil {
+			_ = level.Free()
+		}
+	}()
+
+	// vertices visited in the current level
+	q, err := GrB.VectorNew[bool](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(q.Free())
+	}()
+	GrB.OK(q.SetElement(true, source))
+
+	for d := 0; ; d++ {
+		nq, err := q.Nvals()
+		GrB.OK(err)
+		if nq == 0 {
+			break
+		}
+		// level<q> = d
+		GrB.OK(GrB.VectorAssignConstant(level, &q, nil, d, GrB.All(n), GrB.DescS))
+		// q<!struct(level), replace> = q any.pair A
+		GrB.OK(GrB.VxM(q, level.AsMask(), nil, GrB.AnyOneb[bool](), q, GrB.MatrixView[bool, D](A), GrB.DescRSC))
+	}
+
+	return level, nil
+}
+
+// BFSParents performs a breadth-first search of the graph represented by the n x n adjacency matrix A,
+// starting at the given source vertex. In the resulting vector, parent(i) is the predecessor of
+// vertex i on a shortest path from the source to i, and parent(source) = source. Vertices that are
+// not reachable from the source have no entry in the result. If there are several shortest paths,
+// which one is represented in the result is unspecified.
+func BFSParents[D GrB.Predefined](A GrB.Matrix[D], source int) (parent GrB.Vector[int], err error) {
+	defer GrB.CheckErrors(&err)
+
+	n, err := A.Nrows()
+	GrB.OK(err)
+
+	parent, err = GrB.VectorNew[int](n)
+	GrB.OK(err)
+	defer func() {
+		if err != nil {
+			_ = parent.Free()
+		}
+	}()
+	GrB.OK(parent.SetElement(source, source))
+
+	// vertices visited in the current level, with their parents
+	q, err := GrB.VectorNew[int](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(q.Free())
+	}()
+	GrB.OK(q.SetElement(source, source))
+
+	for {
+		// q<!struct(parent), replace> = q any.secondi A
+		GrB.OK(GrB.VxM(q, parent.AsMask(), nil, GrB.AnySecondi[int](), q, GrB.MatrixView[int, D](A), GrB.DescRSC))
+		nq, err := q.Nvals()
+		GrB.OK(err)
+		if nq == 0 {
+			break
+		}
+		// parent<struct(q)> = q
+		GrB.OK(GrB.VectorAssign(parent, q.AsMask(), nil, q, GrB.All(n), GrB.DescS))
+	}
+
+	return parent, nil
+}
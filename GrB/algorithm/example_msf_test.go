@@ -0,0 +1,64 @@
+package algorithm_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"github.com/intel/forGraphBLASGo/GrB/algorithm"
+	"testing"
+)
+
+func Example_minimumSpanningForest() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	// an undirected graph with 9 vertices: a square 0-1-2-3 of equal weights,
+	// two edges of weight 3 to vertex 5, a self-loop at vertex 2,
+	// a separate edge 6-7, and an isolated vertex 8
+	const n = 9
+	i := []int{0, 1, 2, 3, 3, 4, 0, 6}
+	j := []int{1, 2, 3, 0, 4, 5, 5, 7}
+	w := []float64{1, 1, 1, 1, 2, 3, 3, 5}
+	A, err := GrB.MatrixNew[float64](n, n)
+	OK(err)
+	defer func() {
+		OK(A.Free())
+	}()
+	OK(A.Build(append(i, j...), append(j, i...), append(w, w...), nil))
+	OK(A.SetElement(0, 2, 2))
+
+	forest, weight, err := algorithm.MinimumSpanningForest(A)
+	OK(err)
+	defer func() {
+		OK(forest.Free())
+	}()
+	var rows, cols []int
+	var values []float64
+	OK(forest.ExtractTuples(&rows, &cols, &values))
+	for k := range rows {
+		if rows[k] < cols[k] {
+			fmt.Printf("%v-%v: %v\n", rows[k], cols[k], values[k])
+		}
+	}
+	fmt.Println("weight:", weight)
+
+	// Output:
+	// 0-1: 1
+	// 0-3: 1
+	// 0-5: 3
+	// 1-2: 1
+	// 3-4: 2
+	// 6-7: 5
+	// weight: 13
+}
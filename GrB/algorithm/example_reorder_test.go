@@ -0,0 +1,88 @@
+package algorithm_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"github.com/intel/forGraphBLASGo/GrB/algorithm"
+	"testing"
+)
+
+func Example_reorder() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	A, err := graph()
+	OK(err)
+	defer func() {
+		OK(A.Free())
+	}()
+
+	bandwidth := func(A GrB.Matrix[bool]) int {
+		stats, err := A.Stats()
+		OK(err)
+		return stats.Bandwidth
+	}
+	fmt.Println(bandwidth(A))
+
+	perm, err := algorithm.ReverseCuthillMcKee(A)
+	OK(err)
+	defer func() {
+		OK(perm.Free())
+	}()
+	B, err := A.PermuteSymmetric(perm)
+	OK(err)
+	defer func() {
+		OK(B.Free())
+	}()
+	fmt.Println(bandwidth(B))
+
+	count, err := algorithm.TriangleCount(B)
+	OK(err)
+	fmt.Println(count)
+
+	perm2, err := algorithm.DegreeSort(A, false)
+	OK(err)
+	defer func() {
+		OK(perm2.Free())
+	}()
+	indices, err := perm2.Indices()
+	OK(err)
+	fmt.Println(indices[:4])
+
+	perm3, err := algorithm.RandomPermutation(15, 1)
+	OK(err)
+	defer func() {
+		OK(perm3.Free())
+	}()
+	C, err := A.PermuteSymmetric(perm3)
+	OK(err)
+	defer func() {
+		OK(C.Free())
+	}()
+	count, err = algorithm.TriangleCount(C)
+	OK(err)
+	fmt.Println(count)
+
+	_, sorted, err := algorithm.TriangleCountSort(A)
+	OK(err)
+	fmt.Println(sorted)
+	// Output:
+	// 8
+	// 6
+	// 2
+	// [0 1 2 3]
+	// 2
+	// false
+}
@@ -0,0 +1,169 @@
+package algorithm_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"github.com/intel/forGraphBLASGo/GrB/algorithm"
+	"strings"
+	"testing"
+)
+
+func Example_shortestPaths() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	// a directed graph with 7 vertices and a negative edge; vertex 6 is isolated
+	const n = 7
+	A, err := GrB.MatrixNew[int](n, n)
+	OK(err)
+	defer func() {
+		OK(A.Free())
+	}()
+	OK(A.Build(
+		[]int{0, 0, 2, 1, 2, 3, 4, 5},
+		[]int{1, 2, 1, 3, 3, 4, 5, 3},
+		[]int{4, 1, 2, 1, 5, 3, -2, 4},
+		nil,
+	))
+
+	show := func(M GrB.Matrix[int]) {
+		nrows, ncols, err := M.Size()
+		OK(err)
+		for i := range nrows {
+			var row []string
+			for j := range ncols {
+				value, ok, err := M.ExtractElement(i, j)
+				OK(err)
+				if ok {
+					row = append(row, fmt.Sprintf("%2d", value))
+				} else {
+					row = append(row, " .")
+				}
+			}
+			fmt.Println(strings.Join(row, " "))
+		}
+	}
+	same := func(X, Y GrB.Matrix[int]) bool {
+		var xi, xj, xv, yi, yj, yv []int
+		OK(X.ExtractTuples(&xi, &xj, &xv))
+		OK(Y.ExtractTuples(&yi, &yj, &yv))
+		return fmt.Sprint(xi, xj, xv) == fmt.Sprint(yi, yj, yv)
+	}
+
+	dist, err := algorithm.ShortestPathsSquaring(A, nil)
+	OK(err)
+	defer func() {
+		OK(dist.Free())
+	}()
+	fmt.Println("all pairs:")
+	show(dist)
+	blocked, err := algorithm.ShortestPathsFloydWarshall(A, nil, 3)
+	OK(err)
+	defer func() {
+		OK(blocked.Free())
+	}()
+	bellmanFord, err := algorithm.ShortestPathsBellmanFord(A, nil)
+	OK(err)
+	defer func() {
+		OK(bellmanFord.Free())
+	}()
+	fmt.Println("Floyd-Warshall agrees:", same(dist, blocked), "Bellman-Ford agrees:", same(dist, bellmanFord))
+
+	sources := []int{0, 4}
+	dist2, err := algorithm.ShortestPathsBellmanFord(A, sources)
+	OK(err)
+	defer func() {
+		OK(dist2.Free())
+	}()
+	fmt.Println("from 0 and 4:")
+	show(dist2)
+	parent, err := algorithm.ShortestPathParents(A, dist2, sources)
+	OK(err)
+	defer func() {
+		OK(parent.Free())
+	}()
+	fmt.Println("parents:")
+	show(parent)
+
+	// a cycle 1 -> 2 -> 1 of weight zero, where both 1 and 2 are reached through 3
+	Z, err := GrB.MatrixNew[int](4, 4)
+	OK(err)
+	defer func() {
+		OK(Z.Free())
+	}()
+	OK(Z.Build([]int{0, 3, 3, 1, 2}, []int{3, 1, 2, 2, 1}, []int{1, 0, 0, 0, 0}, nil))
+	zeroDist, err := algorithm.ShortestPathsBellmanFord(Z, []int{0})
+	OK(err)
+	defer func() {
+		OK(zeroDist.Free())
+	}()
+	zeroParent, err := algorithm.ShortestPathParents(Z, zeroDist, []int{0})
+	OK(err)
+	defer func() {
+		OK(zeroParent.Free())
+	}()
+	show(zeroParent)
+
+	// a negative cycle 3 -> 4 -> 5 -> 3
+	OK(A.SetElement(-2, 5, 3))
+	_, err = algorithm.ShortestPathsSquaring(A, nil)
+	fmt.Println(errors.Is(err, GrB.InvalidValue), err)
+	_, err = algorithm.ShortestPathsFloydWarshall(A, []int{6}, 2)
+	fmt.Println(errors.Is(err, GrB.InvalidValue))
+	_, err = algorithm.ShortestPathsBellmanFord(A, []int{0})
+	fmt.Println(err)
+	fromIsolated, err := algorithm.ShortestPathsBellmanFord(A, []int{6})
+	OK(err)
+	defer func() {
+		OK(fromIsolated.Free())
+	}()
+	show(fromIsolated)
+
+	// invalid sources and non-square matrices
+	_, err = algorithm.ShortestPathsBellmanFord(A, []int{n})
+	fmt.Println(err)
+	R, err := GrB.MatrixNew[int](2, 3)
+	OK(err)
+	defer func() {
+		OK(R.Free())
+	}()
+	_, err = algorithm.ShortestPathsBellmanFord(R, nil)
+	fmt.Println(err)
+
+	// Output:
+	// all pairs:
+	//  0  3  1  4  7  5  .
+	//  .  0  .  1  4  2  .
+	//  .  2  0  3  6  4  .
+	//  .  .  .  0  3  1  .
+	//  .  .  .  2  0 -2  .
+	//  .  .  .  4  7  0  .
+	//  .  .  .  .  .  .  0
+	// Floyd-Warshall agrees: true Bellman-Ford agrees: true
+	// from 0 and 4:
+	//  0  3  1  4  7  5  .
+	//  .  .  .  2  0 -2  .
+	// parents:
+	//  0  2  0  1  3  4  .
+	//  .  .  .  5  4  4  .
+	//  0  3  3  0
+	// true algorithm: 3 vertices are on negative cycles: GraphBLAS API error: invalid value
+	// true
+	// algorithm: negative cycle reachable from a source: GraphBLAS API error: invalid value
+	//  .  .  .  .  .  .  0
+	// algorithm: source 7 is not a vertex: GraphBLAS API error: invalid index
+	// algorithm: 2 x 3 matrix is not square: GraphBLAS API error: dimension mismatch
+}
@@ -0,0 +1,87 @@
+package algorithm_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"github.com/intel/forGraphBLASGo/GrB/algorithm"
+	"testing"
+)
+
+// graph returns the undirected graph with 15 vertices used in the examples of the GrB package.
+func graph() (A GrB.Matrix[bool], err error) {
+	defer GrB.CheckErrors(&err)
+	A, err = GrB.MatrixNew[bool](15, 15)
+	GrB.OK(err)
+	edges := [][2]int{
+		{0, 1}, {0, 2}, {0, 3}, {0, 4}, {1, 3}, {1, 5}, {1, 6}, {2, 4}, {2, 7},
+		{2, 8}, {3, 9}, {3, 10}, {4, 11}, {4, 12}, {6, 13}, {7, 13}, {10, 14}, {11, 14},
+	}
+	for _, e := range edges {
+		GrB.OK(A.SetElement(true, e[0], e[1]))
+		GrB.OK(A.SetElement(true, e[1], e[0]))
+	}
+	return
+}
+
+func Example() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	A, err := graph()
+	OK(err)
+	defer func() {
+		OK(A.Free())
+	}()
+
+	level, err := algorithm.BFSLevels(A, 0)
+	OK(err)
+	defer func() {
+		OK(level.Free())
+	}()
+	var indices, values []int
+	OK(level.ExtractTuples(&indices, &values))
+	fmt.Println(values)
+
+	parent, err := algorithm.BFSParents(A, 14)
+	OK(err)
+	defer func() {
+		OK(parent.Free())
+	}()
+	p, _, err := parent.ExtractElement(0)
+	OK(err)
+	fmt.Println(p == 3 || p == 4)
+
+	count, err := algorithm.TriangleCount(A)
+	OK(err)
+	fmt.Println(count)
+
+	rank, iterations, err := algorithm.PageRank(A, 0.85, 1e-9, 100)
+	OK(err)
+	defer func() {
+		OK(rank.Free())
+	}()
+	ranks, err := rank.ToDense(0)
+	OK(err)
+	var sum float64
+	for _, r := range ranks {
+		sum += r
+	}
+	fmt.Printf("%.6f %v %.4f %.4f\n", sum, iterations < 100, ranks[0], ranks[14])
+	// Output:
+	// [0 1 1 1 1 2 2 2 2 2 2 2 2 3 3]
+	// true
+	// 2
+	// 1.000000 true 0.0998 0.0588
+}
@@ -0,0 +1,301 @@
+package algorithm
+
+import (
+	"github.com/intel/forGraphBLASGo/GrB"
+	"slices"
+)
+
+// MinimumSpanningForest computes a minimum spanning forest of the weighted undirected graph
+// represented by the symmetric n x n adjacency matrix A, with Borůvka's algorithm along the lines
+// of LAGraph_msf. The forest is returned as a symmetric n x n matrix with the weights of its edges,
+// together with its total weight, where each edge is counted once. Entries on the diagonal of A
+// are ignored.
+//
+// Each vertex is labeled with the component of the forest it belongs to, initially itself. In each
+// round, the edges within components are dropped with [GrB.MatrixSelect], and each component picks
+// its lightest outgoing edge: the lightest weight per vertex is a min-reduction of its row, the
+// lightest weight per component a min-reduction over its vertices, and ties between edges of the
+// same weight are broken by their smallest endpoint and then their largest endpoint, which ensures
+// that the picked edges do not form cycles. The picked edges are added to the forest, the picked
+// components are merged, and the vertices are relabeled by pointer jumping with [GrB.MatrixExtract].
+// The algorithm stops when no edges between components are left, after at most log₂(n) rounds.
+func MinimumSpanningForest[D GrB.Number](A GrB.Matrix[D]) (forest GrB.Matrix[D], weight D, err error) {
+	defer GrB.CheckErrors(&err)
+	n, err := A.Nrows()
+	GrB.OK(err)
+	forest, err = GrB.MatrixNew[D](n, n)
+	GrB.OK(err)
+	defer func() {
+		if err != nil {
+			_ = forest.Free()
+		}
+	}()
+
+	// S holds the remaining edges between components
+	S, err := GrB.MatrixNew[D](n, n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(S.Free())
+	}()
+	GrB.OK(GrB.MatrixSelect(S, nil, nil, GrB.Offdiag[D](), A, 0, nil))
+
+	// label(i) is the component of vertex i, which is the vertex that represents it,
+	// and L(i, label(i)) is true
+	label := make([]int, n)
+	for i := range label {
+		label[i] = i
+	}
+	L, err := membership(label)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(L.Free())
+	}()
+
+	var rows, cols []int
+	var values []D
+	for {
+		GrB.OK(dropIntraComponentEdges(&S, label))
+		nvals, err := S.Nvals()
+		GrB.OK(err)
+		if nvals == 0 {
+			break
+		}
+
+		lightest, edge, err := lightestEdges(S, L)
+		GrB.OK(err)
+
+		// the picked edges, and the component that each picked component is merged with
+		parent := slices.Clone(label)
+		picked := make(map[int]bool)
+		for c, id := range edge {
+			u, v := id/n, id%n
+			if !picked[id] {
+				picked[id] = true
+				rows = append(rows, u, v)
+				cols = append(cols, v, u)
+				values = append(values, lightest[c], lightest[c])
+				weight += lightest[c]
+			}
+			// if two components pick each other, they pick the same edge,
+			// and the smaller one represents both
+			if other := label[u] + label[v] - c; edge[other] != id || c > other {
+				parent[c] = other
+			}
+		}
+		label, err = relabel(&L, parent)
+		GrB.OK(err)
+	}
+	if len(values) > 0 {
+		GrB.OK(forest.Build(rows, cols, values, nil))
+	}
+	return forest, weight, nil
+}
+
+// membership returns the n x n matrix L with L(i, label[i]) = true.
+func membership(label []int) (L GrB.Matrix[bool], err error) {
+	n := len(label)
+	if L, err = GrB.MatrixNew[bool](n, n); err != nil || n == 0 {
+		return
+	}
+	rows := make([]int, n)
+	values := make([]bool, n)
+	for i := range rows {
+		rows[i] = i
+		values[i] = true
+	}
+	if err = L.Build(rows, label, values, nil); err != nil {
+		_ = L.Free()
+	}
+	return
+}
+
+// dropIntraComponentEdges removes all edges (i, j) from *S with label[i] == label[j].
+func dropIntraComponentEdges[D GrB.Number](S *GrB.Matrix[D], label []int) (err error) {
+	defer GrB.CheckErrors(&err)
+	n := len(label)
+	if n == 0 {
+		return
+	}
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = i
+	}
+	labels, err := GrB.VectorNew[int](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(labels.Free())
+	}()
+	GrB.OK(labels.Build(indices, label, nil))
+	Labels, err := labels.Diag(0)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(Labels.Free())
+	}()
+
+	// difference(i, j) = label[i] - label[j] for each edge (i, j)
+	pattern := GrB.MatrixView[int, D](*S)
+	from, err := GrB.MatrixNew[int](n, n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(from.Free())
+	}()
+	GrB.OK(GrB.MxM(from, nil, nil, GrB.AnyFirst[int](), Labels, pattern, nil))
+	to, err := GrB.MatrixNew[int](n, n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(to.Free())
+	}()
+	GrB.OK(GrB.MxM(to, nil, nil, GrB.AnySecond[int](), pattern, Labels, nil))
+	difference, err := GrB.MatrixNew[int](n, n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(difference.Free())
+	}()
+	GrB.OK(GrB.MatrixEWiseMultBinaryOp(difference, nil, nil, GrB.Minus[int](), from, to, nil))
+
+	// keep = the edges between different components, S<struct(keep)> = S
+	keep, err := GrB.MatrixNew[int](n, n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(keep.Free())
+	}()
+	GrB.OK(GrB.MatrixSelect(keep, nil, nil, GrB.Valuene[int](), difference, 0, nil))
+	next, err := GrB.MatrixNew[D](n, n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(next.Free())
+	}()
+	GrB.OK(GrB.MatrixApply(next, keep.AsMask(), nil, GrB.Identity[D](), *S, GrB.DescS))
+	*S, next = next, *S
+	return
+}
+
+// lightestEdges returns the weight of the lightest outgoing edge of each component c that has one,
+// and its identifier min(i, j) * n + max(i, j), which is the smallest among the lightest edges.
+func lightestEdges[D GrB.Number](S GrB.Matrix[D], L GrB.Matrix[bool]) (lightest map[int]D, edge map[int]int, err error) {
+	defer GrB.CheckErrors(&err)
+	n, err := S.Nrows()
+	GrB.OK(err)
+
+	// vertexWeight(i) = min_j S(i, j), componentWeight(c) = min_{i in c} vertexWeight(i),
+	// and weight(i) = componentWeight(label(i))
+	vertexWeight, err := GrB.VectorNew[D](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(vertexWeight.Free())
+	}()
+	GrB.OK(GrB.MatrixReduceMonoid(vertexWeight, nil, nil, GrB.MinMonoid[D](), S, nil))
+	componentWeight, err := GrB.VectorNew[D](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(componentWeight.Free())
+	}()
+	GrB.OK(GrB.MxV(componentWeight, nil, nil, GrB.MinSecondSemiring[D](), GrB.MatrixView[D, bool](L), vertexWeight, GrB.DescT0))
+	weight, err := GrB.VectorNew[D](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(weight.Free())
+	}()
+	GrB.OK(GrB.MxV(weight, nil, nil, GrB.AnySecond[D](), GrB.MatrixView[D, bool](L), componentWeight, nil))
+	Weight, err := weight.Diag(0)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(Weight.Free())
+	}()
+
+	// tight = the edges (i, j) with S(i, j) - weight(i) == 0
+	difference, err := GrB.MatrixNew[D](n, n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(difference.Free())
+	}()
+	GrB.OK(GrB.MxM(difference, nil, nil, GrB.MinRminus[D](), Weight, S, nil))
+	tight, err := GrB.MatrixNew[D](n, n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(tight.Free())
+	}()
+	GrB.OK(GrB.MatrixSelect(tight, nil, nil, GrB.Valueeq[D](), difference, 0, nil))
+
+	// id(i, j) = min(i, j) * n + max(i, j), vertexID(i) = min_j id(i, j),
+	// and componentID(c) = min_{i in c} vertexID(i)
+	i, err := GrB.MatrixNew[int](n, n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(i.Free())
+	}()
+	GrB.OK(GrB.MatrixApplyIndexOp(i, nil, nil, GrB.RowIndex[int, D](), tight, 0, nil))
+	j, err := GrB.MatrixNew[int](n, n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(j.Free())
+	}()
+	GrB.OK(GrB.MatrixApplyIndexOp(j, nil, nil, GrB.ColIndex[int, D](), tight, 0, nil))
+	low, err := GrB.MatrixNew[int](n, n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(low.Free())
+	}()
+	GrB.OK(GrB.MatrixEWiseMultBinaryOp(low, nil, nil, GrB.Min[int](), i, j, nil))
+	high, err := GrB.MatrixNew[int](n, n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(high.Free())
+	}()
+	GrB.OK(GrB.MatrixEWiseMultBinaryOp(high, nil, nil, GrB.Max[int](), i, j, nil))
+	plus := GrB.Plus[int]()
+	GrB.OK(GrB.MatrixApplyBinaryOp2nd(high, nil, &plus, GrB.Times[int](), low, n, nil))
+	vertexID, err := GrB.VectorNew[int](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(vertexID.Free())
+	}()
+	GrB.OK(GrB.MatrixReduceMonoid(vertexID, nil, nil, GrB.MinMonoid[int](), high, nil))
+	componentID, err := GrB.VectorNew[int](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(componentID.Free())
+	}()
+	GrB.OK(GrB.MxV(componentID, nil, nil, GrB.MinSecondSemiring[int](), GrB.MatrixView[int, bool](L), vertexID, GrB.DescT0))
+
+	var components, ids []int
+	GrB.OK(componentID.ExtractTuples(&components, &ids))
+	edge = make(map[int]int, len(components))
+	for k, c := range components {
+		edge[c] = ids[k]
+	}
+	var weights []D
+	components = components[:0]
+	GrB.OK(componentWeight.ExtractTuples(&components, &weights))
+	lightest = make(map[int]D, len(components))
+	for k, c := range components {
+		lightest[c] = weights[k]
+	}
+	return
+}
+
+// relabel merges each component c into parent[c], and relabels all vertices with the components
+// that represent them, by pointer jumping: L(i, :) = L(label(i), :) until nothing changes. It
+// returns the new labels.
+func relabel(L *GrB.Matrix[bool], parent []int) (label []int, err error) {
+	defer GrB.CheckErrors(&err)
+	n := len(parent)
+	next, err := membership(parent)
+	GrB.OK(err)
+	GrB.OK(L.Free())
+	*L = next
+	label = parent
+	for {
+		next, err := GrB.MatrixNew[bool](n, n)
+		GrB.OK(err)
+		GrB.OK(GrB.MatrixExtract(next, nil, nil, *L, label, GrB.All(n), nil))
+		GrB.OK(L.Free())
+		*L = next
+		var rows, cols []int
+		GrB.OK(next.ExtractTuples(&rows, &cols, nil))
+		if slices.Equal(cols, label) {
+			return label, nil
+		}
+		label = cols
+	}
+}
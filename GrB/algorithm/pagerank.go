@@ -0,0 +1,101 @@
+package algorithm
+
+import "github.com/intel/forGraphBLASGo/GrB"
+
+// PageRank computes the PageRank of all vertices of the directed graph represented by the
+// n x n adjacency matrix A, using the given damping factor (typically 0.85). The iteration stops
+// when the 1-norm of the difference between two successive rank vectors drops below tol, or
+// after maxIter iterations, whichever comes first. The ranks of all vertices sum up to 1.
+//
+// Vertices without outgoing edges ("dangling" vertices) are handled as if they had edges
+// to all vertices, so that their rank is evenly distributed across the graph.
+//
+// The result is a full vector, together with the number of iterations performed.
+func PageRank[D GrB.Predefined](A GrB.Matrix[D], damping, tol float64, maxIter int) (rank GrB.Vector[float64], iterations int, err error) {
+	defer GrB.CheckErrors(&err)
+
+	n, err := A.Nrows()
+	GrB.OK(err)
+	if n == 0 {
+		rank, err = GrB.VectorNew[float64](0)
+		return
+	}
+	all := GrB.All(n)
+	Af := GrB.MatrixView[float64, D](A)
+	plus := GrB.Plus[float64]()
+
+	rank, err = GrB.VectorNew[float64](n)
+	GrB.OK(err)
+	defer func() {
+		if err != nil {
+			_ = rank.Free()
+		}
+	}()
+	GrB.OK(GrB.VectorAssignConstant(rank, nil, nil, 1/float64(n), all, nil))
+
+	// d = out-degree of each vertex, no entries for dangling vertices
+	d, err := GrB.VectorNew[float64](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(d.Free())
+	}()
+	t, err := GrB.VectorNew[float64](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(t.Free())
+	}()
+	GrB.OK(GrB.VectorAssignConstant(t, nil, nil, 1, all, nil))
+	GrB.OK(GrB.MxV(d, nil, nil, GrB.PlusOneb[float64](), Af, t, nil))
+	// d = d / damping, so that w = t ./ d below already includes the damping factor
+	GrB.OK(GrB.VectorApplyBinaryOp2nd(d, nil, nil, GrB.Div[float64](), d, damping, nil))
+
+	w, err := GrB.VectorNew[float64](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(w.Free())
+	}()
+	product, err := GrB.VectorNew[float64](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(product.Free())
+	}()
+
+	teleport := (1 - damping) / float64(n)
+
+	for iterations < maxIter {
+		iterations++
+
+		// swap t and rank: t is now the rank of the previous iteration
+		t, rank = rank, t
+
+		// sum of the ranks of the dangling vertices
+		total, err := GrB.VectorReduce(GrB.PlusMonoid[float64](), t, nil)
+		GrB.OK(err)
+		GrB.OK(GrB.VectorAssign(w, d.AsMask(), nil, t, all, GrB.DescRS))
+		nonDangling, err := GrB.VectorReduce(GrB.PlusMonoid[float64](), w, nil)
+		GrB.OK(err)
+		sink := total - nonDangling
+
+		// rank = teleport + damping * sink / n
+		GrB.OK(GrB.VectorAssignConstant(rank, nil, nil, teleport+damping*sink/float64(n), all, nil))
+
+		// w = t ./ d
+		GrB.OK(GrB.VectorEWiseMultBinaryOp(w, nil, nil, GrB.Div[float64](), t, d, nil))
+
+		// rank += A' plus.second w, computed into a separate vector, since rank is full and
+		// iso-valued here (see "Known issues" in [GrB])
+		GrB.OK(GrB.MxV(product, nil, nil, GrB.PlusSecond[float64](), Af, w, GrB.DescT0))
+		GrB.OK(GrB.VectorEWiseAddBinaryOp(rank, nil, nil, plus, rank, product, nil))
+
+		// t = |t - rank|
+		GrB.OK(GrB.VectorEWiseAddBinaryOp(t, nil, nil, GrB.Minus[float64](), t, rank, nil))
+		GrB.OK(GrB.VectorApply(t, nil, nil, GrB.Abs[float64](), t, nil))
+		diff, err := GrB.VectorReduce(GrB.PlusMonoid[float64](), t, nil)
+		GrB.OK(err)
+		if diff < tol {
+			break
+		}
+	}
+
+	return rank, iterations, nil
+}
@@ -0,0 +1,223 @@
+package algorithm
+
+import (
+	"cmp"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"math/rand"
+	"slices"
+)
+
+// pattern returns a new boolean matrix with the same structure as A, with all values set to true.
+func pattern[D GrB.Predefined](A GrB.Matrix[D]) (P GrB.Matrix[bool], err error) {
+	defer GrB.CheckErrors(&err)
+	nrows, ncols, err := A.Size()
+	GrB.OK(err)
+	P, err = GrB.MatrixNew[bool](nrows, ncols)
+	GrB.OK(err)
+	GrB.OK(GrB.MatrixAssignConstant(P, A.AsMask(), nil, true, GrB.All(nrows), GrB.All(ncols), GrB.DescS))
+	return
+}
+
+// rowDegrees returns a full vector with the number of entries in each row of A.
+func rowDegrees[D GrB.Predefined](A GrB.Matrix[D]) (degree GrB.Vector[int], err error) {
+	defer GrB.CheckErrors(&err)
+
+	P, err := pattern(A)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(P.Free())
+	}()
+	n, err := P.Nrows()
+	GrB.OK(err)
+
+	degree, err = GrB.VectorNew[int](n)
+	GrB.OK(err)
+	GrB.OK(GrB.VectorAssignConstant(degree, nil, nil, 0, GrB.All(n), nil))
+	plus := GrB.Plus[int]()
+	GrB.OK(GrB.MatrixReduceMonoid(degree, nil, &plus, GrB.PlusMonoid[int](), GrB.MatrixView[int, bool](P), nil))
+	return
+}
+
+// DegreeSort returns the permutation that sorts the vertices of the graph represented by
+// the adjacency matrix A by their out-degree, in ascending or descending order. Vertices
+// with the same degree remain in the order of their indices.
+func DegreeSort[D GrB.Predefined](A GrB.Matrix[D], ascending bool) (perm GrB.Permutation, err error) {
+	defer GrB.CheckErrors(&err)
+
+	degree, err := rowDegrees(A)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(degree.Free())
+	}()
+	n, err := degree.Size()
+	GrB.OK(err)
+
+	perm.Vector, err = GrB.VectorNew[int](n)
+	GrB.OK(err)
+	order := GrB.Gt[int]()
+	if ascending {
+		order = GrB.Lt[int]()
+	}
+	if err = degree.Sort(nil, &perm.Vector, order, nil); err != nil {
+		_ = perm.Free()
+	}
+	return
+}
+
+// RandomPermutation returns a random permutation of n vertices, generated from the given seed.
+// Randomly relabeling the vertices of a graph is useful as a baseline for other orderings,
+// and for breaking up adversarial orderings of the input.
+func RandomPermutation(n int, seed int64) (GrB.Permutation, error) {
+	return GrB.PermutationFromSlice(rand.New(rand.NewSource(seed)).Perm(n))
+}
+
+// ReverseCuthillMcKee returns the reverse Cuthill–McKee ordering of the vertices of the graph
+// represented by the square adjacency matrix A, which reduces the bandwidth of the matrix, and
+// thus typically improves the locality of memory accesses. The structure of A is symmetrized,
+// so the direction of edges is ignored.
+//
+// Each connected component is ordered by a breadth-first search from a pseudo-peripheral vertex,
+// found with the method of George and Liu, where the neighbors of each vertex are visited in
+// ascending order of their degree. The resulting order is reversed. Since the algorithm is
+// inherently sequential, it operates on the adjacency lists extracted from A.
+func ReverseCuthillMcKee[D GrB.Predefined](A GrB.Matrix[D]) (perm GrB.Permutation, err error) {
+	defer GrB.CheckErrors(&err)
+
+	n, err := A.Nrows()
+	GrB.OK(err)
+
+	S, err := pattern(A)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(S.Free())
+	}()
+	// S = S | S', without self-edges
+	GrB.OK(GrB.MatrixEWiseAddBinaryOp(S, nil, nil, GrB.LorBool, S, S, GrB.DescT1))
+	GrB.OK(GrB.MatrixSelect(S, nil, nil, GrB.Offdiag[bool](), S, 0, nil))
+
+	var rows, cols []int
+	GrB.OK(S.ExtractTuples(&rows, &cols, nil))
+	adj := make([][]int, n)
+	for k, i := range rows {
+		adj[i] = append(adj[i], cols[k])
+	}
+	byDegree := func(u, v int) int {
+		if c := cmp.Compare(len(adj[u]), len(adj[v])); c != 0 {
+			return c
+		}
+		return cmp.Compare(u, v)
+	}
+	for i := range adj {
+		slices.SortFunc(adj[i], byDegree)
+	}
+
+	// bfs appends the vertices reachable from root to order, level by level, and returns
+	// the extended order, the index at which the last level starts, and the number of levels.
+	visited := make([]bool, n)
+	bfs := func(root int, order []int) (_ []int, last, levels int) {
+		last = len(order)
+		order = append(order, root)
+		visited[root] = true
+		levels = 1
+		for head, levelEnd := last, last+1; head < len(order); {
+			for _, v := range adj[order[head]] {
+				if !visited[v] {
+					visited[v] = true
+					order = append(order, v)
+				}
+			}
+			head++
+			if head == levelEnd && head < len(order) {
+				last, levelEnd = head, len(order)
+				levels++
+			}
+		}
+		return order, last, levels
+	}
+	unvisit := func(vertices []int) {
+		for _, v := range vertices {
+			visited[v] = false
+		}
+	}
+
+	// the vertices in ascending order of their degree, to choose the start of each component
+	candidates := make([]int, n)
+	for i := range candidates {
+		candidates[i] = i
+	}
+	slices.SortStableFunc(candidates, byDegree)
+
+	order := make([]int, 0, n)
+	for _, root := range candidates {
+		if visited[root] {
+			continue
+		}
+		// find a pseudo-peripheral vertex: repeatedly restart from a vertex of minimum
+		// degree in the last level, as long as the number of levels increases
+		start := len(order)
+		component, last, levels := bfs(root, order)
+		for {
+			candidate := slices.MinFunc(component[last:], byDegree)
+			unvisit(component[start:])
+			next, nextLast, nextLevels := bfs(candidate, order)
+			if nextLevels <= levels {
+				unvisit(next[start:])
+				break
+			}
+			root, component, last, levels = candidate, next, nextLast, nextLevels
+		}
+		order, _, _ = bfs(root, order)
+	}
+	slices.Reverse(order)
+	return GrB.PermutationFromSlice(order)
+}
+
+// TriangleCountSort implements the heuristic of LAGraph for deciding whether to relabel the
+// vertices of a graph before counting its triangles. If the mean degree is more than four times
+// the median degree, which is typical for graphs with a power-law degree distribution, it returns
+// the permutation that sorts the vertices by ascending degree, and true. Otherwise, sorting
+// is not expected to pay off, and TriangleCountSort returns false and an invalid permutation.
+//
+// If sorted is true, the triangles of A can be counted with:
+//
+//	B, err := A.PermuteSymmetric(perm)
+//	count, err := TriangleCount(B)
+//
+// A must be the symmetric adjacency matrix of an undirected graph.
+func TriangleCountSort[D GrB.Predefined](A GrB.Matrix[D]) (perm GrB.Permutation, sorted bool, err error) {
+	defer GrB.CheckErrors(&err)
+
+	degree, err := rowDegrees(A)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(degree.Free())
+	}()
+	n, err := degree.Size()
+	GrB.OK(err)
+	if n == 0 {
+		return
+	}
+	nvals, err := A.Nvals()
+	GrB.OK(err)
+	mean := float64(nvals) / float64(n)
+
+	sortedDegree, err := GrB.VectorNew[int](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(sortedDegree.Free())
+	}()
+	GrB.OK(degree.Sort(&sortedDegree, nil, GrB.Lt[int](), nil))
+	median, _, err := sortedDegree.ExtractElement(n / 2)
+	GrB.OK(err)
+	if mean <= 4*float64(median) {
+		return
+	}
+
+	perm.Vector, err = GrB.VectorNew[int](n)
+	GrB.OK(err)
+	if err = degree.Sort(nil, &perm.Vector, GrB.Lt[int](), nil); err != nil {
+		_ = perm.Free()
+		return
+	}
+	return perm, true, nil
+}
@@ -0,0 +1,395 @@
+package algorithm
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+)
+
+// ShortestPathsSquaring computes shortest paths from the given sources by repeatedly squaring the
+// distance matrix over the min-plus semiring, starting with A and zeros on the diagonal, and
+// stopping early as soon as the distances do not change anymore. This takes at most log₂(n) + 1
+// squarings, each of which is a full matrix multiplication, so this is best suited for small
+// graphs with short shortest paths. Any negative cycle in the graph causes an error, even if it is
+// not reachable from the sources.
+func ShortestPathsSquaring[D GrB.Number](A GrB.Matrix[D], sources []int) (dist GrB.Matrix[D], err error) {
+	defer GrB.CheckErrors(&err)
+	all, err := withZeroDiagonal(A)
+	GrB.OK(err)
+	defer func() {
+		if all != dist {
+			GrB.OK(all.Free())
+		}
+	}()
+	GrB.OK(closure(&all))
+	return sourceRows(all, sources)
+}
+
+// ShortestPathsFloydWarshall computes shortest paths from the given sources with the blocked
+// Floyd-Warshall algorithm. The distance matrix, starting with A and zeros on the diagonal, is split
+// into tiles of blockSize x blockSize with [GrB.Matrix.Split]. For each diagonal tile in turn, its
+// closure is computed by repeated squaring, and then used to relax the tiles in the same block row
+// and column, which in turn are used to relax all other tiles. The tiles are finally put together
+// again with [GrB.Matrix.Concat]. Any negative cycle in the graph causes an error, even if it is not
+// reachable from the sources.
+func ShortestPathsFloydWarshall[D GrB.Number](A GrB.Matrix[D], sources []int, blockSize int) (dist GrB.Matrix[D], err error) {
+	defer GrB.CheckErrors(&err)
+	if blockSize <= 0 {
+		GrB.OK(fmt.Errorf("algorithm: block size %v is not positive: %w", blockSize, GrB.InvalidValue))
+	}
+	n, err := A.Nrows()
+	GrB.OK(err)
+	all, err := withZeroDiagonal(A)
+	GrB.OK(err)
+	defer func() {
+		if all != dist {
+			GrB.OK(all.Free())
+		}
+	}()
+	if n == 0 {
+		return sourceRows(all, sources)
+	}
+
+	var sizes []int
+	for i := 0; i < n; i += blockSize {
+		sizes = append(sizes, min(blockSize, n-i))
+	}
+	nb := len(sizes)
+	tiles, err := all.Split(sizes, sizes, nil)
+	GrB.OK(err)
+	defer func() {
+		for _, tile := range tiles {
+			GrB.OK(tile.Free())
+		}
+	}()
+	tile := func(i, j int) *GrB.Matrix[D] {
+		return &tiles[i*nb+j]
+	}
+
+	for k := range nb {
+		GrB.OK(closure(tile(k, k)))
+		for j := range nb {
+			if j != k {
+				_, err = relax(tile(k, j), *tile(k, k), *tile(k, j))
+				GrB.OK(err)
+			}
+		}
+		for i := range nb {
+			if i != k {
+				_, err = relax(tile(i, k), *tile(i, k), *tile(k, k))
+				GrB.OK(err)
+			}
+		}
+		for i := range nb {
+			for j := range nb {
+				if i != k && j != k {
+					_, err = relax(tile(i, j), *tile(i, k), *tile(k, j))
+					GrB.OK(err)
+				}
+			}
+		}
+	}
+	GrB.OK(all.Concat(tiles, nb, nb, nil))
+	GrB.OK(checkNegativeCycle(all))
+	return sourceRows(all, sources)
+}
+
+// ShortestPathsBellmanFord computes shortest paths from the given sources with the Bellman-Ford
+// algorithm, batched for all sources: dist = min(dist, dist min.plus A) is repeated until the
+// distances do not change anymore, which takes at most n - 1 iterations, plus one to detect
+// that nothing changes. Only negative cycles that are reachable from a source cause an error.
+// If A is not square, an error wrapping [GrB.DimensionMismatch] is returned.
+func ShortestPathsBellmanFord[D GrB.Number](A GrB.Matrix[D], sources []int) (dist GrB.Matrix[D], err error) {
+	defer GrB.CheckErrors(&err)
+	n, ncols, err := A.Size()
+	GrB.OK(err)
+	if n != ncols {
+		GrB.OK(fmt.Errorf("algorithm: %v x %v matrix is not square: %w", n, ncols, GrB.DimensionMismatch))
+	}
+	GrB.OK(checkSources(sources, n))
+	if sources == nil {
+		sources = make([]int, n)
+		for i := range sources {
+			sources[i] = i
+		}
+	}
+	dist, err = GrB.MatrixNew[D](len(sources), n)
+	GrB.OK(err)
+	defer func() {
+		if err != nil {
+			_ = dist.Free()
+		}
+	}()
+	if len(sources) > 0 {
+		// dist(r, sources[r]) = 0
+		rows := make([]int, len(sources))
+		for i := range rows {
+			rows[i] = i
+		}
+		GrB.OK(dist.Build(rows, sources, make([]D, len(sources)), nil))
+	}
+	for iteration := 1; ; iteration++ {
+		changed, err := relax(&dist, dist, A)
+		GrB.OK(err)
+		if !changed {
+			break
+		}
+		if iteration >= n {
+			GrB.OK(fmt.Errorf("algorithm: negative cycle reachable from a source: %w", GrB.InvalidValue))
+		}
+	}
+	return dist, nil
+}
+
+// ShortestPathParents computes the parent matrix for the distance matrix dist, as returned by the
+// shortest path algorithms for A and the given sources. In the result, parent(r, j) is the
+// predecessor of vertex j on a shortest path from the r-th source to j, and parent(r, source) = source.
+// Vertices that are not reachable from a source have no entry in its row. If there are several
+// shortest paths, one with the fewest edges is chosen, and among those the predecessor with the
+// smallest index. The parents thus always form a tree, even if there are cycles of weight zero.
+//
+// For each source, the candidates dist(r, k) + A(k, j) for each edge (k, j) are computed with
+// a min-plus multiplication, and the edges whose candidates are minimal for j lie on shortest
+// paths. A breadth-first search from the source along these edges with the min.secondi semiring
+// then finds the predecessors.
+func ShortestPathParents[D GrB.Number](A, dist GrB.Matrix[D], sources []int) (parent GrB.Matrix[int], err error) {
+	defer GrB.CheckErrors(&err)
+	n, err := A.Nrows()
+	GrB.OK(err)
+	GrB.OK(checkSources(sources, n))
+	nsources := n
+	if sources != nil {
+		nsources = len(sources)
+	}
+	parent, err = GrB.MatrixNew[int](nsources, n)
+	GrB.OK(err)
+	defer func() {
+		if err != nil {
+			_ = parent.Free()
+		}
+	}()
+	for r := range nsources {
+		source := r
+		if sources != nil {
+			source = sources[r]
+		}
+		GrB.OK(parentRow(parent, A, dist, r, source))
+	}
+	return parent, nil
+}
+
+// parentRow sets parent(r, :) for the given source.
+func parentRow[D GrB.Number](parent GrB.Matrix[int], A, dist GrB.Matrix[D], r, source int) (err error) {
+	defer GrB.CheckErrors(&err)
+	n, err := A.Nrows()
+	GrB.OK(err)
+
+	// d = dist(r, :)
+	d, err := GrB.VectorNew[D](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(d.Free())
+	}()
+	GrB.OK(GrB.MatrixColExtract(d, nil, nil, dist, GrB.All(n), r, GrB.DescT0))
+	diagonal, err := d.Diag(0)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(diagonal.Free())
+	}()
+
+	// candidates(k, j) = d(k) + A(k, j), and minimum(j) = min_k candidates(k, j)
+	candidates, err := GrB.MatrixNew[D](n, n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(candidates.Free())
+	}()
+	GrB.OK(GrB.MxM(candidates, nil, nil, GrB.MinPlusSemiring[D](), diagonal, A, nil))
+	minimum, err := GrB.VectorNew[D](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(minimum.Free())
+	}()
+	GrB.OK(GrB.MatrixReduceMonoid(minimum, nil, nil, GrB.MinMonoid[D](), candidates, GrB.DescT0))
+	minimumDiagonal, err := minimum.Diag(0)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(minimumDiagonal.Free())
+	}()
+
+	// minimal(k, j) = candidates(k, j) - minimum(j), for the candidates that are minimal
+	differences, err := GrB.MatrixNew[D](n, n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(differences.Free())
+	}()
+	GrB.OK(GrB.MxM(differences, nil, nil, GrB.MinMinus[D](), candidates, minimumDiagonal, nil))
+	minimal, err := GrB.MatrixNew[D](n, n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(minimal.Free())
+	}()
+	GrB.OK(GrB.MatrixSelect(minimal, nil, nil, GrB.Valueeq[D](), differences, 0, nil))
+
+	// breadth-first search along the minimal edges, where p(j) is the smallest k with a minimal
+	// edge (k, j) among the vertices k visited in the previous level
+	p, err := GrB.VectorNew[int](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(p.Free())
+	}()
+	GrB.OK(p.SetElement(source, source))
+	q, err := GrB.VectorNew[int](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(q.Free())
+	}()
+	GrB.OK(q.SetElement(source, source))
+	for {
+		// q<!struct(p), replace> = q min.secondi minimal
+		GrB.OK(GrB.VxM(q, p.AsMask(), nil, GrB.MinSecondi[int](), q, GrB.MatrixView[int, D](minimal), GrB.DescRSC))
+		nq, err := q.Nvals()
+		GrB.OK(err)
+		if nq == 0 {
+			break
+		}
+		// p<struct(q)> = q
+		GrB.OK(GrB.VectorAssign(p, q.AsMask(), nil, q, GrB.All(n), GrB.DescS))
+	}
+	GrB.OK(GrB.MatrixRowAssign(parent, nil, nil, p, r, GrB.All(n), nil))
+	return
+}
+
+// withZeroDiagonal returns min(A, 0) on the diagonal, and A elsewhere.
+func withZeroDiagonal[D GrB.Number](A GrB.Matrix[D]) (C GrB.Matrix[D], err error) {
+	defer GrB.CheckErrors(&err)
+	n, err := A.Nrows()
+	GrB.OK(err)
+	zeros, err := GrB.VectorNew[D](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(zeros.Free())
+	}()
+	if n > 0 {
+		GrB.OK(GrB.VectorAssignConstant(zeros, nil, nil, 0, GrB.All(n), nil))
+	}
+	Z, err := zeros.Diag(0)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(Z.Free())
+	}()
+	C, err = GrB.MatrixNew[D](n, n)
+	GrB.OK(err)
+	defer func() {
+		if err != nil {
+			_ = C.Free()
+		}
+	}()
+	GrB.OK(GrB.MatrixEWiseAddBinaryOp(C, nil, nil, GrB.Min[D](), A, Z, nil))
+	return
+}
+
+// relax sets *C = min(*C, X min.plus Y), and reports whether *C has changed. The result is a new
+// matrix, and the previous one is freed; X and Y may be *C itself.
+func relax[D GrB.Number](C *GrB.Matrix[D], X, Y GrB.Matrix[D]) (changed bool, err error) {
+	defer GrB.CheckErrors(&err)
+	nrows, ncols, err := C.Size()
+	GrB.OK(err)
+	T, err := GrB.MatrixNew[D](nrows, ncols)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(T.Free())
+	}()
+	GrB.OK(GrB.MxM(T, nil, nil, GrB.MinPlusSemiring[D](), X, Y, nil))
+	next, err := GrB.MatrixNew[D](nrows, ncols)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(next.Free())
+	}()
+	GrB.OK(GrB.MatrixEWiseAddBinaryOp(next, nil, nil, GrB.Min[D](), *C, T, nil))
+
+	// next has changed if it has more entries, or smaller values
+	nvals, err := C.Nvals()
+	GrB.OK(err)
+	nextNvals, err := next.Nvals()
+	GrB.OK(err)
+	changed = nextNvals > nvals
+	if !changed {
+		less, err := GrB.MatrixNew[bool](nrows, ncols)
+		GrB.OK(err)
+		defer func() {
+			GrB.OK(less.Free())
+		}()
+		GrB.OK(GrB.MatrixEWiseMultBinaryOp(less, nil, nil, GrB.Lt[D](), next, *C, nil))
+		changed, err = GrB.MatrixReduce(GrB.LorMonoidBool, less, nil)
+		GrB.OK(err)
+	}
+	*C, next = next, *C
+	return
+}
+
+// closure replaces the square matrix *C with zeros on the diagonal by its closure
+// over the min-plus semiring, by repeated squaring.
+func closure[D GrB.Number](C *GrB.Matrix[D]) (err error) {
+	defer GrB.CheckErrors(&err)
+	for {
+		changed, err := relax(C, *C, *C)
+		GrB.OK(err)
+		GrB.OK(checkNegativeCycle(*C))
+		if !changed {
+			return nil
+		}
+	}
+}
+
+// checkNegativeCycle returns an error if the square matrix C has a negative entry on the diagonal.
+func checkNegativeCycle[D GrB.Number](C GrB.Matrix[D]) (err error) {
+	defer GrB.CheckErrors(&err)
+	n, err := C.Nrows()
+	GrB.OK(err)
+	d, err := GrB.VectorNew[D](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(d.Free())
+	}()
+	GrB.OK(d.ExtractDiag(C, 0, nil))
+	negative, err := GrB.VectorNew[D](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(negative.Free())
+	}()
+	GrB.OK(GrB.VectorSelect(negative, nil, nil, GrB.Valuelt[D](), d, 0, nil))
+	nvals, err := negative.Nvals()
+	GrB.OK(err)
+	if nvals > 0 {
+		GrB.OK(fmt.Errorf("algorithm: %v vertices are on negative cycles: %w", nvals, GrB.InvalidValue))
+	}
+	return
+}
+
+// sourceRows returns the rows of the n x n matrix C for the given sources, or C itself if sources is nil.
+func sourceRows[D GrB.Number](C GrB.Matrix[D], sources []int) (rows GrB.Matrix[D], err error) {
+	if sources == nil {
+		return C, nil
+	}
+	defer GrB.CheckErrors(&err)
+	n, err := C.Ncols()
+	GrB.OK(err)
+	GrB.OK(checkSources(sources, n))
+	rows, err = GrB.MatrixNew[D](len(sources), n)
+	GrB.OK(err)
+	if len(sources) > 0 && n > 0 {
+		if err = GrB.MatrixExtract(rows, nil, nil, C, sources, GrB.All(n), nil); err != nil {
+			_ = rows.Free()
+		}
+	}
+	return
+}
+
+// checkSources returns an error if a source is not a vertex of a graph with n vertices.
+func checkSources(sources []int, n int) error {
+	for _, source := range sources {
+		if source < 0 || source >= n {
+			return fmt.Errorf("algorithm: source %v is not a vertex: %w", source, GrB.InvalidIndex)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package algorithm
+
+import "github.com/intel/forGraphBLASGo/GrB"
+
+// TriangleCount returns the number of triangles in the undirected graph represented by the
+// symmetric n x n adjacency matrix A. Self-edges are ignored.
+//
+// The triangles are counted with the "Sandia" method: with L the strictly lower triangular
+// part of A, C<L> = L plus.pair Lᵀ, and the result is the sum of the entries of C.
+func TriangleCount[D GrB.Predefined](A GrB.Matrix[D]) (count int, err error) {
+	defer GrB.CheckErrors(&err)
+
+	n, err := A.Nrows()
+	GrB.OK(err)
+
+	L, err := GrB.MatrixNew[bool](n, n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(L.Free())
+	}()
+	GrB.OK(GrB.MatrixSelect(L, nil, nil, GrB.Tril[bool](), GrB.MatrixView[bool, D](A), -1, nil))
+	Lint := GrB.MatrixView[int, bool](L)
+
+	C, err := GrB.MatrixNew[int](n, n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(C.Free())
+	}()
+
+	// C<struct(L)> = L plus.pair L'
+	GrB.OK(GrB.MxM(C, L.AsMask(), nil, GrB.PlusOneb[int](), Lint, Lint, GrB.DescST1))
+
+	return GrB.MatrixReduce(GrB.PlusMonoid[int](), C, nil)
+}
@@ -0,0 +1,455 @@
+package GrB_test
+
+import (
+	"flag"
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// The benchmarks in this file measure the main GraphBLAS operations on generated graphs
+// of several shapes, for each sparsity format of the inputs, and for multiplications also
+// for each choice of the AxBMethod descriptor field. The sub-benchmarks are named
+// graph=<shape>/format=<sparsity>[/method=<method>], so that the results can be compared
+// with tools like benchstat. Apart from the usual time per operation, each benchmark reports
+// the throughput in millions of input entries processed per second (Mentries/s).
+//
+// Run them for example with:
+//
+//	go test -run '^$' -bench 'MxM/graph=rmat' ./GrB -args -grb.scale=14
+
+var benchScale = flag.Int("grb.scale", 11, "log2 of the number of vertices of the graphs generated for benchmarks")
+
+// A benchGraph generates the entries of an n x n adjacency matrix.
+type benchGraph struct {
+	name     string
+	generate func(rng *rand.Rand, n int) (rows, cols []int)
+	shift    int  // the graph has 2^(scale-shift) vertices
+	full     bool // whether all n x n entries are generated
+}
+
+const benchEdgeFactor = 16
+
+var benchGraphs = []benchGraph{
+	{name: "uniform", generate: generateUniform},
+	{name: "rmat", generate: generateRMAT},
+	{name: "banded", generate: generateBanded},
+	{name: "dense", generate: generateDense, shift: 3, full: true},
+}
+
+// generateUniform generates an Erdős–Rényi graph with an average degree of benchEdgeFactor.
+func generateUniform(rng *rand.Rand, n int) (rows, cols []int) {
+	for k := 0; k < n*benchEdgeFactor; k++ {
+		rows = append(rows, rng.Intn(n))
+		cols = append(cols, rng.Intn(n))
+	}
+	return
+}
+
+// generateRMAT generates a power-law graph with the recursive R-MAT method, using the
+// parameters of the Graph500 benchmark, and an average degree of benchEdgeFactor.
+func generateRMAT(rng *rand.Rand, n int) (rows, cols []int) {
+	const a, b, c = 0.57, 0.19, 0.19
+	for k := 0; k < n*benchEdgeFactor; k++ {
+		i, j := 0, 0
+		for size := n / 2; size > 0; size /= 2 {
+			switch p := rng.Float64(); {
+			case p < a:
+			case p < a+b:
+				j += size
+			case p < a+b+c:
+				i += size
+			default:
+				i += size
+				j += size
+			}
+		}
+		rows = append(rows, i)
+		cols = append(cols, j)
+	}
+	return
+}
+
+// generateBanded generates a band matrix with benchEdgeFactor/2 diagonals on each side
+// of the main diagonal, like the matrices that stem from discretized partial differential equations.
+func generateBanded(_ *rand.Rand, n int) (rows, cols []int) {
+	for i := 0; i < n; i++ {
+		for j := max(0, i-benchEdgeFactor/2); j <= min(n-1, i+benchEdgeFactor/2); j++ {
+			rows = append(rows, i)
+			cols = append(cols, j)
+		}
+	}
+	return
+}
+
+// generateDense generates all entries of a matrix. To keep the number of entries
+// in the same order of magnitude as for the other graphs, dense graphs have fewer vertices.
+func generateDense(_ *rand.Rand, n int) (rows, cols []int) {
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			rows = append(rows, i)
+			cols = append(cols, j)
+		}
+	}
+	return
+}
+
+type benchTuples struct {
+	n          int
+	rows, cols []int
+	values     []float64
+}
+
+var (
+	benchTuplesOnce  sync.Once
+	benchTuplesCache map[string]benchTuples
+)
+
+func benchGraphTuples(g benchGraph) benchTuples {
+	benchTuplesOnce.Do(func() {
+		benchTuplesCache = make(map[string]benchTuples)
+		for _, g := range benchGraphs {
+			n := 1 << (*benchScale - g.shift)
+			rng := rand.New(rand.NewSource(42))
+			rows, cols := g.generate(rng, n)
+			values := make([]float64, len(rows))
+			for k := range values {
+				values[k] = rng.Float64()
+			}
+			benchTuplesCache[g.name] = benchTuples{n: n, rows: rows, cols: cols, values: values}
+		}
+	})
+	return benchTuplesCache[g.name]
+}
+
+var benchFormats = []GrB.Sparsity{GrB.Hypersparse, GrB.Sparse, GrB.Bitmap, GrB.Full}
+
+// benchMatrix creates the adjacency matrix of the given graph in the given format.
+func benchMatrix(b *testing.B, g benchGraph, format GrB.Sparsity) GrB.Matrix[float64] {
+	t := benchGraphTuples(g)
+	A, err := GrB.MatrixNew[float64](t.n, t.n)
+	benchOK(b, err)
+	plus := GrB.Plus[float64]()
+	benchOK(b, A.Build(t.rows, t.cols, t.values, &plus))
+	benchOK(b, A.SetSparsityControl(format))
+	benchOK(b, A.Wait(GrB.Materialize))
+	return A
+}
+
+// benchVector creates a vector with an entry for every other index.
+func benchVector(b *testing.B, n int) GrB.Vector[float64] {
+	u, err := GrB.VectorNew[float64](n)
+	benchOK(b, err)
+	for i := 0; i < n; i += 2 {
+		benchOK(b, u.SetElement(1, i))
+	}
+	benchOK(b, u.Wait(GrB.Materialize))
+	return u
+}
+
+func benchOK(b *testing.B, err error) {
+	if err != nil {
+		b.Helper()
+		b.Fatal(err)
+	}
+}
+
+// benchLoop runs op b.N times, and reports the throughput in terms of the entries of A.
+func benchLoop(b *testing.B, A GrB.Matrix[float64], op func()) {
+	nvals, err := A.Nvals()
+	benchOK(b, err)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		op()
+	}
+	b.StopTimer()
+	b.ReportMetric(float64(nvals)*float64(b.N)/b.Elapsed().Seconds()/1e6, "Mentries/s")
+}
+
+// benchMatrices runs f as a sub-benchmark for each generated graph in each sparsity format.
+// A full format is only possible for graphs where all entries are present.
+func benchMatrices(b *testing.B, f func(b *testing.B, A GrB.Matrix[float64])) {
+	for _, g := range benchGraphs {
+		for _, format := range benchFormats {
+			if format == GrB.Full && !g.full {
+				continue
+			}
+			b.Run(fmt.Sprintf("graph=%v/format=%v", g.name, format), func(b *testing.B) {
+				A := benchMatrix(b, g, format)
+				defer func() {
+					benchOK(b, A.Free())
+				}()
+				f(b, A)
+			})
+		}
+	}
+}
+
+var benchMethods = []struct {
+	name   string
+	method GrB.DescValue
+}{
+	{"auto", GrB.Default},
+	{"gustavson", GrB.AxBGustavson},
+	{"hash", GrB.AxBHash},
+	{"saxpy", GrB.AxBSaxpy},
+	{"dot", GrB.AxBDot},
+}
+
+// benchAxBMethods runs f as a sub-benchmark for each choice of the AxBMethod descriptor field.
+func benchAxBMethods(b *testing.B, A GrB.Matrix[float64], f func(b *testing.B, desc *GrB.Descriptor)) {
+	for _, m := range benchMethods {
+		b.Run("method="+m.name, func(b *testing.B) {
+			desc, err := GrB.DescriptorNew()
+			benchOK(b, err)
+			defer func() {
+				benchOK(b, desc.Free())
+			}()
+			benchOK(b, desc.Set(GrB.AxBMethod, m.method))
+			f(b, &desc)
+		})
+	}
+}
+
+func BenchmarkMxM(b *testing.B) {
+	benchMatrices(b, func(b *testing.B, A GrB.Matrix[float64]) {
+		n, err := A.Nrows()
+		benchOK(b, err)
+		benchAxBMethods(b, A, func(b *testing.B, desc *GrB.Descriptor) {
+			C, err := GrB.MatrixNew[float64](n, n)
+			benchOK(b, err)
+			defer func() {
+				benchOK(b, C.Free())
+			}()
+			benchLoop(b, A, func() {
+				// C<A> = A * A
+				benchOK(b, GrB.MxM(C, A.AsMask(), nil, GrB.PlusTimesSemiring[float64](), A, A, desc))
+				benchOK(b, C.Wait(GrB.Materialize))
+			})
+		})
+	})
+}
+
+func BenchmarkMxMUnmasked(b *testing.B) {
+	benchMatrices(b, func(b *testing.B, A GrB.Matrix[float64]) {
+		n, err := A.Nrows()
+		benchOK(b, err)
+		benchAxBMethods(b, A, func(b *testing.B, desc *GrB.Descriptor) {
+			C, err := GrB.MatrixNew[float64](n, n)
+			benchOK(b, err)
+			defer func() {
+				benchOK(b, C.Free())
+			}()
+			benchLoop(b, A, func() {
+				// C = A * A
+				benchOK(b, GrB.MxM(C, nil, nil, GrB.PlusTimesSemiring[float64](), A, A, desc))
+				benchOK(b, C.Wait(GrB.Materialize))
+			})
+		})
+	})
+}
+
+func BenchmarkMxV(b *testing.B) {
+	benchMatrices(b, func(b *testing.B, A GrB.Matrix[float64]) {
+		n, err := A.Nrows()
+		benchOK(b, err)
+		u := benchVector(b, n)
+		defer func() {
+			benchOK(b, u.Free())
+		}()
+		benchAxBMethods(b, A, func(b *testing.B, desc *GrB.Descriptor) {
+			w, err := GrB.VectorNew[float64](n)
+			benchOK(b, err)
+			defer func() {
+				benchOK(b, w.Free())
+			}()
+			benchLoop(b, A, func() {
+				benchOK(b, GrB.MxV(w, nil, nil, GrB.PlusTimesSemiring[float64](), A, u, desc))
+				benchOK(b, w.Wait(GrB.Materialize))
+			})
+		})
+	})
+}
+
+func BenchmarkVxM(b *testing.B) {
+	benchMatrices(b, func(b *testing.B, A GrB.Matrix[float64]) {
+		n, err := A.Nrows()
+		benchOK(b, err)
+		u := benchVector(b, n)
+		defer func() {
+			benchOK(b, u.Free())
+		}()
+		benchAxBMethods(b, A, func(b *testing.B, desc *GrB.Descriptor) {
+			w, err := GrB.VectorNew[float64](n)
+			benchOK(b, err)
+			defer func() {
+				benchOK(b, w.Free())
+			}()
+			benchLoop(b, A, func() {
+				benchOK(b, GrB.VxM(w, nil, nil, GrB.PlusTimesSemiring[float64](), u, A, desc))
+				benchOK(b, w.Wait(GrB.Materialize))
+			})
+		})
+	})
+}
+
+func BenchmarkEWiseAdd(b *testing.B) {
+	benchMatrices(b, func(b *testing.B, A GrB.Matrix[float64]) {
+		n, err := A.Nrows()
+		benchOK(b, err)
+		C, err := GrB.MatrixNew[float64](n, n)
+		benchOK(b, err)
+		defer func() {
+			benchOK(b, C.Free())
+		}()
+		benchLoop(b, A, func() {
+			// C = A + A'
+			benchOK(b, GrB.MatrixEWiseAddBinaryOp(C, nil, nil, GrB.Plus[float64](), A, A, GrB.DescT1))
+			benchOK(b, C.Wait(GrB.Materialize))
+		})
+	})
+}
+
+func BenchmarkEWiseMult(b *testing.B) {
+	benchMatrices(b, func(b *testing.B, A GrB.Matrix[float64]) {
+		n, err := A.Nrows()
+		benchOK(b, err)
+		C, err := GrB.MatrixNew[float64](n, n)
+		benchOK(b, err)
+		defer func() {
+			benchOK(b, C.Free())
+		}()
+		benchLoop(b, A, func() {
+			// C = A .* A'
+			benchOK(b, GrB.MatrixEWiseMultBinaryOp(C, nil, nil, GrB.Times[float64](), A, A, GrB.DescT1))
+			benchOK(b, C.Wait(GrB.Materialize))
+		})
+	})
+}
+
+// benchHalf returns the indices of every other row or column.
+func benchHalf(n int) []int {
+	indices := make([]int, 0, n/2)
+	for i := 0; i < n; i += 2 {
+		indices = append(indices, i)
+	}
+	return indices
+}
+
+func BenchmarkAssign(b *testing.B) {
+	benchMatrices(b, func(b *testing.B, A GrB.Matrix[float64]) {
+		n, err := A.Nrows()
+		benchOK(b, err)
+		half := benchHalf(n)
+		S, err := GrB.MatrixNew[float64](len(half), len(half))
+		benchOK(b, err)
+		defer func() {
+			benchOK(b, S.Free())
+		}()
+		benchOK(b, GrB.MatrixExtract(S, nil, nil, A, half, half, nil))
+		C, err := A.Dup()
+		benchOK(b, err)
+		defer func() {
+			benchOK(b, C.Free())
+		}()
+		plus := GrB.Plus[float64]()
+		benchLoop(b, A, func() {
+			// C(half, half) += S
+			benchOK(b, GrB.MatrixAssign(C, nil, &plus, S, half, half, nil))
+			benchOK(b, C.Wait(GrB.Materialize))
+		})
+	})
+}
+
+func BenchmarkExtract(b *testing.B) {
+	benchMatrices(b, func(b *testing.B, A GrB.Matrix[float64]) {
+		n, err := A.Nrows()
+		benchOK(b, err)
+		half := benchHalf(n)
+		C, err := GrB.MatrixNew[float64](len(half), len(half))
+		benchOK(b, err)
+		defer func() {
+			benchOK(b, C.Free())
+		}()
+		benchLoop(b, A, func() {
+			benchOK(b, GrB.MatrixExtract(C, nil, nil, A, half, half, nil))
+			benchOK(b, C.Wait(GrB.Materialize))
+		})
+	})
+}
+
+func BenchmarkTranspose(b *testing.B) {
+	benchMatrices(b, func(b *testing.B, A GrB.Matrix[float64]) {
+		n, err := A.Nrows()
+		benchOK(b, err)
+		C, err := GrB.MatrixNew[float64](n, n)
+		benchOK(b, err)
+		defer func() {
+			benchOK(b, C.Free())
+		}()
+		benchLoop(b, A, func() {
+			benchOK(b, GrB.Transpose(C, nil, nil, A, nil))
+			benchOK(b, C.Wait(GrB.Materialize))
+		})
+	})
+}
+
+func BenchmarkReduce(b *testing.B) {
+	benchMatrices(b, func(b *testing.B, A GrB.Matrix[float64]) {
+		n, err := A.Nrows()
+		benchOK(b, err)
+		b.Run("to=vector", func(b *testing.B) {
+			w, err := GrB.VectorNew[float64](n)
+			benchOK(b, err)
+			defer func() {
+				benchOK(b, w.Free())
+			}()
+			benchLoop(b, A, func() {
+				benchOK(b, GrB.MatrixReduceMonoid(w, nil, nil, GrB.PlusMonoid[float64](), A, nil))
+				benchOK(b, w.Wait(GrB.Materialize))
+			})
+		})
+		b.Run("to=scalar", func(b *testing.B) {
+			benchLoop(b, A, func() {
+				_, err := GrB.MatrixReduce(GrB.PlusMonoid[float64](), A, nil)
+				benchOK(b, err)
+			})
+		})
+	})
+}
+
+// BenchmarkSwitches measures the effect of the hyper switch and the bitmap switch
+// on C<A> = A * A', when GraphBLAS chooses the sparsity format automatically.
+func BenchmarkSwitches(b *testing.B) {
+	for _, g := range benchGraphs {
+		for _, hyperSwitch := range []float64{GrB.NeverHyper, 0.01, GrB.HyperDefault, 0.5} {
+			for _, bitmapSwitch := range []float64{0.01, 0.04, 0.1, 0.5} {
+				name := fmt.Sprintf("graph=%v/hyper=%v/bitmap=%v", g.name, hyperSwitch, bitmapSwitch)
+				b.Run(name, func(b *testing.B) {
+					A := benchMatrix(b, g, GrB.AutoSparsity)
+					defer func() {
+						benchOK(b, A.Free())
+					}()
+					benchOK(b, A.SetHyperSwitch(hyperSwitch))
+					benchOK(b, A.SetBitmapSwitch(bitmapSwitch))
+					benchOK(b, A.Wait(GrB.Materialize))
+					n, err := A.Nrows()
+					benchOK(b, err)
+					C, err := GrB.MatrixNew[float64](n, n)
+					benchOK(b, err)
+					defer func() {
+						benchOK(b, C.Free())
+					}()
+					benchOK(b, C.SetHyperSwitch(hyperSwitch))
+					benchOK(b, C.SetBitmapSwitch(bitmapSwitch))
+					benchLoop(b, A, func() {
+						benchOK(b, GrB.MxM(C, A.AsMask(), nil, GrB.PlusTimesSemiring[float64](), A, A, GrB.DescT1))
+						benchOK(b, C.Wait(GrB.Materialize))
+					})
+				})
+			}
+		}
+	}
+}
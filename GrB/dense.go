@@ -0,0 +1,232 @@
+package GrB
+
+// MatrixFromDense creates a new full matrix from a slice of rows. All rows must have the
+// same length, which determines the number of columns of the resulting matrix.
+//
+// The values are copied into memory allocated by the allocator registered with [InitWithMalloc],
+// and then moved into the matrix with [Matrix.PackFullR], so that the matrix does not share any
+// memory with rows.
+//
+// GraphBLAS API errors that may be returned:
+//   - [SliceMismatch], [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [OutOfMemory], [Panic]
+//
+// MatrixFromDense is a forGraphBLASGo extension.
+func MatrixFromDense[D any](rows [][]D) (matrix Matrix[D], err error) {
+	nrows := len(rows)
+	ncols := 0
+	if nrows > 0 {
+		ncols = len(rows[0])
+	}
+	for _, row := range rows {
+		if len(row) != ncols {
+			err = makeError(SliceMismatch)
+			return
+		}
+	}
+	return matrixFromRowMajor(nrows, ncols, func(ax []D) {
+		for i, row := range rows {
+			copy(ax[i*ncols:], row)
+		}
+	})
+}
+
+// MatrixFromRowMajor creates a new full matrix of the given dimensions from a slice of
+// values in row-major order, so that the value at position (i, j) is data[i*ncols + j].
+// len(data) must be equal to nrows * ncols.
+//
+// The values are copied into memory allocated by the allocator registered with [InitWithMalloc],
+// and then moved into the matrix with [Matrix.PackFullR], so that the matrix does not share any
+// memory with data.
+//
+// GraphBLAS API errors that may be returned:
+//   - [InvalidValue], [SliceMismatch], [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [OutOfMemory], [Panic]
+//
+// MatrixFromRowMajor is a forGraphBLASGo extension.
+func MatrixFromRowMajor[D any](nrows, ncols int, data []D) (matrix Matrix[D], err error) {
+	if nrows < 0 || ncols < 0 {
+		err = makeError(InvalidValue)
+		return
+	}
+	if len(data) != nrows*ncols {
+		err = makeError(SliceMismatch)
+		return
+	}
+	return matrixFromRowMajor(nrows, ncols, func(ax []D) {
+		copy(ax, data)
+	})
+}
+
+func matrixFromRowMajor[D any](nrows, ncols int, fill func(ax []D)) (matrix Matrix[D], err error) {
+	matrix, err = MatrixNew[D](nrows, ncols)
+	if err != nil || nrows == 0 || ncols == 0 {
+		return
+	}
+	ax := MakeSystemSlice[D](nrows * ncols)
+	fill(ax.UnsafeSlice())
+	if err = matrix.PackFullR(&ax, false, nil); err != nil {
+		ax.Free()
+		_ = matrix.Free()
+	}
+	return
+}
+
+// ToDense returns the content of the matrix as a slice of rows. Positions without
+// an entry in the matrix are set to fill. The matrix is not modified.
+//
+// GraphBLAS API errors that may be returned:
+//   - [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [InvalidObject], [OutOfMemory], [Panic]
+//
+// ToDense is a forGraphBLASGo extension.
+func (matrix Matrix[D]) ToDense(fill D) (rows [][]D, err error) {
+	nrows, ncols, err := matrix.Size()
+	if err != nil {
+		return
+	}
+	data, err := matrix.ToRowMajor(fill)
+	if err != nil {
+		return
+	}
+	rows = make([][]D, nrows)
+	for i := range rows {
+		rows[i] = data[i*ncols : (i+1)*ncols : (i+1)*ncols]
+	}
+	return
+}
+
+// ToRowMajor returns the content of the matrix as a slice of values in row-major order,
+// so that the value at position (i, j) is stored at index i*ncols + j. Positions without
+// an entry in the matrix are set to fill. The matrix is not modified.
+//
+// The values are obtained by assigning the matrix to a full matrix initialized with fill,
+// and then moving them out of GraphBLAS with [Matrix.UnpackFullR].
+//
+// GraphBLAS API errors that may be returned:
+//   - [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [InvalidObject], [OutOfMemory], [Panic]
+//
+// ToRowMajor is a forGraphBLASGo extension.
+func (matrix Matrix[D]) ToRowMajor(fill D) (data []D, err error) {
+	nrows, ncols, err := matrix.Size()
+	if err != nil {
+		return
+	}
+	data = make([]D, nrows*ncols)
+	if len(data) == 0 {
+		return
+	}
+	full, err := MatrixNew[D](nrows, ncols)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if ferr := full.Free(); err == nil {
+			err = ferr
+		}
+	}()
+	if err = MatrixAssignConstant(full, nil, nil, fill, All(nrows), All(ncols), nil); err != nil {
+		return nil, err
+	}
+	if err = MatrixAssign(full, matrix.AsMask(), nil, matrix, All(nrows), All(ncols), DescS); err != nil {
+		return nil, err
+	}
+	ax, iso, err := full.UnpackFullR(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer ax.Free()
+	copyFull(data, ax.UnsafeSlice(), iso)
+	return
+}
+
+// copyFull copies the values of a full matrix or vector, taking into account
+// that an iso-valued source only stores a single value.
+func copyFull[D any](dst, src []D, iso bool) {
+	if !iso {
+		copy(dst, src)
+		return
+	}
+	for i := range dst {
+		dst[i] = src[0]
+	}
+}
+
+// VectorFromDense creates a new full vector from a slice of values.
+//
+// The values are copied into memory allocated by the allocator registered with [InitWithMalloc],
+// and then moved into the vector with [Vector.PackFull], so that the vector does not share any
+// memory with data.
+//
+// GraphBLAS API errors that may be returned:
+//   - [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [OutOfMemory], [Panic]
+//
+// VectorFromDense is a forGraphBLASGo extension.
+func VectorFromDense[D any](data []D) (vector Vector[D], err error) {
+	vector, err = VectorNew[D](len(data))
+	if err != nil || len(data) == 0 {
+		return
+	}
+	vx := MakeSystemSlice[D](len(data))
+	copy(vx.UnsafeSlice(), data)
+	if err = vector.PackFull(&vx, false, nil); err != nil {
+		vx.Free()
+		_ = vector.Free()
+	}
+	return
+}
+
+// ToDense returns the content of the vector as a slice of values. Positions without
+// an entry in the vector are set to fill. The vector is not modified.
+//
+// GraphBLAS API errors that may be returned:
+//   - [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [InvalidObject], [OutOfMemory], [Panic]
+//
+// ToDense is a forGraphBLASGo extension.
+func (vector Vector[D]) ToDense(fill D) (data []D, err error) {
+	size, err := vector.Size()
+	if err != nil {
+		return
+	}
+	data = make([]D, size)
+	if size == 0 {
+		return
+	}
+	full, err := VectorNew[D](size)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if ferr := full.Free(); err == nil {
+			err = ferr
+		}
+	}()
+	if err = VectorAssignConstant(full, nil, nil, fill, All(size), nil); err != nil {
+		return nil, err
+	}
+	if err = VectorAssign(full, vector.AsMask(), nil, vector, All(size), DescS); err != nil {
+		return nil, err
+	}
+	vx, iso, err := full.UnpackFull(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer vx.Free()
+	copyFull(data, vx.UnsafeSlice(), iso)
+	return
+}
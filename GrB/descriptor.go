@@ -63,19 +63,20 @@ const (
 
 	// AxBMethod is a descriptor for selecting the C=A*B algorithm.
 	// AxBMethod is a SuiteSparse:GraphBLAS extension.
-	AxBMethod DescField = 1000
+	AxBMethod DescField = C.GxB_AxB_METHOD
 
 	// SortHint controls sorting in [MxM], [MxV], [VxM], and reduction functions.
 	// SortHint is a SuiteSparse:GraphBLAS extension.
-	SortHint DescField = 35
+	SortHint DescField = C.GxB_SORT
 
-	// Compression selects the compression for [Matrix.Serialize].
+	// Compression selects the compression for [Matrix.Serialize]. Since Serialize does not take
+	// a descriptor and always uses the default compression, only [Matrix.SerializeBlob] uses it.
 	// Compression is a SuiteSparse:GraphBLAS extension.
-	Compression DescField = 36
+	Compression DescField = C.GxB_COMPRESSION
 
 	// Import selects between secure and fast packing.
 	// Import is a SuiteSparse:GraphBLAS extension.
-	Import DescField = 37 // a SuiteSparse:GraphBLAS extension
+	Import DescField = C.GxB_IMPORT // a SuiteSparse:GraphBLAS extension
 )
 
 func (field DescField) String() string {
@@ -110,26 +111,26 @@ const (
 
 	// AxBGustavson selects an extended version of Gustavson's method for [AxBMethod].
 	// AxBGustavson is a SuiteSparse:GraphBLAS extension.
-	AxBGustavson DescValue = 1001
+	AxBGustavson DescValue = C.GxB_AxB_GUSTAVSON
 
 	// AxBDot selects a very specialized method for [AxBMethod] that works well only if the
 	// mask is present, very sparse, and not complemented, when the output matrix is very
 	// small, or when the output matrix is bitmap or full.
 	// AxBDot is a SuiteSparse:GraphBLAS extension.
-	AxBDot DescValue = 1003
+	AxBDot DescValue = C.GxB_AxB_DOT
 
 	// AxBHash selects a hash-based method for [AxBMethod].
 	// AxBHash is a SuiteSparse:GraphBLAS extension.
-	AxBHash DescValue = 1004
+	AxBHash DescValue = C.GxB_AxB_HASH
 
 	// AxBSaxpy selects a saxpy-based method for [AxBMethod].
 	// AxBSaxpy is a SuiteSparse:GraphBLAS extension.
-	AxBSaxpy DescValue = 1005
+	AxBSaxpy DescValue = C.GxB_AxB_SAXPY
 
 	// SecureImport informs the pack functions that the data is being packed
 	// from an untrusted source, so additional checks will be made.
 	// SecureImport is a SuiteSparse:GraphBLAS extension.
-	SecureImport DescValue = 502
+	SecureImport DescValue = C.GxB_SECURE_IMPORT
 
 	// PreferSorted provides a hint to [MxM], [MxV], [VxM], and reduction functions
 	// to sort the output result. (This can be any value other than 0.)
@@ -378,7 +379,14 @@ func DescriptorNew() (descriptor Descriptor, err error) {
 // GraphBLAS execution errors that may cause a panic:
 //   - [OutOfMemory], [Panic]
 func (descriptor Descriptor) Set(field DescField, value DescValue) error {
-	info := Info(C.GrB_Descriptor_set(descriptor.grb, C.GrB_Desc_Field(field), C.GrB_Desc_Value(value)))
+	var info Info
+	switch field {
+	case SortHint, Compression, Import:
+		// GrB_Descriptor_set only supports the GrB_Desc_Value fields
+		info = Info(C.GxB_Desc_set_INT32(descriptor.grb, C.GrB_Desc_Field(field), C.int32_t(value)))
+	default:
+		info = Info(C.GrB_Descriptor_set(descriptor.grb, C.GrB_Desc_Field(field), C.GrB_Desc_Value(value)))
+	}
 	if info == success {
 		return nil
 	}
@@ -389,6 +397,15 @@ func (descriptor Descriptor) Set(field DescField, value DescValue) error {
 //
 // Get is a SuiteSparse:GraphBLAS extension.
 func (descriptor Descriptor) Get(field DescField) (DescValue, error) {
+	switch field {
+	case SortHint, Compression, Import:
+		var cvalue C.int32_t
+		info := Info(C.GxB_Desc_get_INT32(descriptor.grb, C.GrB_Desc_Field(field), &cvalue))
+		if info == success {
+			return DescValue(cvalue), nil
+		}
+		return 0, makeError(info)
+	}
 	var cvalue C.GrB_Desc_Value
 	info := Info(C.GxB_Descriptor_get(&cvalue, descriptor.grb, C.GrB_Desc_Field(field)))
 	if info == success {
@@ -0,0 +1,260 @@
+/*
+Package distributed provides experimental support for matrices and vectors that are too large for
+the memory of a single machine, by distributing them over a number of workers, for example
+separate processes on different machines.
+
+The workers are arranged in a two-dimensional [Grid] of pr x pc workers. A distributed [Matrix]
+is partitioned into a grid of pr x pc tiles of (almost) equal size, and the worker at position
+(i, j) of the grid owns tile (i, j) as a local [GrB.Matrix]. A distributed [Vector] is partitioned
+in the same way as the rows or the columns of a matrix, and each block is replicated across the
+workers of a grid row or grid column, respectively (see [Alignment]).
+
+Operations on distributed objects are collective: All workers of a grid must call the same operations
+in the same order with corresponding arguments, as in the SPMD model of MPI. Matrix multiplication
+is implemented with the SUMMA algorithm, where the workers multiply their local tiles in a sequence
+of steps, and exchange tiles along grid rows and grid columns between the steps. Tiles and blocks of
+vectors are exchanged as blobs created with [GrB.Matrix.Serialize], over a pluggable [Transport].
+The package provides [Loopback] for workers that run as goroutines of a single process, and [TCP]
+for workers that communicate over TCP connections.
+
+The caller is responsible for freeing all distributed objects returned by the functions in this
+package. Errors reported by GraphBLAS operations on the local tiles are returned like in the [GrB]
+package, and errors of the [Transport] are returned as they are. After an error, the state of
+the computation on the other workers is undefined.
+
+Package distributed is a forGraphBLASGo extension, and its API is experimental.
+*/
+package distributed
+
+import (
+	"github.com/intel/forGraphBLASGo/GrB"
+)
+
+// A Grid arranges the workers of a [Transport] in a two-dimensional grid. The worker with
+// rank r is at position (r / ncols, r % ncols) of the grid.
+type Grid struct {
+	transport    Transport
+	nrows, ncols int
+}
+
+// GridNew arranges the workers of the transport in an nrows x ncols grid.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidValue]: nrows * ncols is not the number of workers of the transport.
+func GridNew(transport Transport, nrows, ncols int) (*Grid, error) {
+	if nrows <= 0 || ncols <= 0 || nrows*ncols != transport.Size() {
+		return nil, GrB.MakeError(GrB.InvalidValue)
+	}
+	return &Grid{transport: transport, nrows: nrows, ncols: ncols}, nil
+}
+
+// Transport returns the transport of the grid.
+func (grid *Grid) Transport() Transport {
+	return grid.transport
+}
+
+// Size returns the number of rows and columns of the grid.
+func (grid *Grid) Size() (nrows, ncols int) {
+	return grid.nrows, grid.ncols
+}
+
+// Position returns the position of this worker in the grid.
+func (grid *Grid) Position() (row, col int) {
+	rank := grid.transport.Rank()
+	return rank / grid.ncols, rank % grid.ncols
+}
+
+func (grid *Grid) rank(row, col int) int {
+	return row*grid.ncols + col
+}
+
+// partition splits n indices into the given number of parts of almost equal size,
+// and returns the offsets of the parts, where part k holds the indices offsets[k]..offsets[k+1]-1.
+func partition(n, parts int) []int {
+	offsets := make([]int, parts+1)
+	for k := range parts {
+		offsets[k+1] = offsets[k] + n/parts
+		if k < n%parts {
+			offsets[k+1]++
+		}
+	}
+	return offsets
+}
+
+// indexRange returns the indices begin..end-1.
+func indexRange(begin, end int) []int {
+	indices := make([]int, end-begin)
+	for k := range indices {
+		indices[k] = begin + k
+	}
+	return indices
+}
+
+// extractMatrix sets c = a(rowBegin..rowEnd-1, colBegin..colEnd-1). GraphBLAS does not accept
+// empty index lists, so extractMatrix does nothing if the range is empty.
+func extractMatrix[D any](c, a GrB.Matrix[D], rowBegin, rowEnd, colBegin, colEnd int) error {
+	if rowBegin == rowEnd || colBegin == colEnd {
+		return nil
+	}
+	return GrB.MatrixExtract(c, nil, nil, a, indexRange(rowBegin, rowEnd), indexRange(colBegin, colEnd), nil)
+}
+
+// assignMatrix sets c(rowBegin..rowEnd-1, colBegin..colEnd-1) = a, or does nothing if the range is empty.
+func assignMatrix[D any](c, a GrB.Matrix[D], rowBegin, rowEnd, colBegin, colEnd int) error {
+	if rowBegin == rowEnd || colBegin == colEnd {
+		return nil
+	}
+	return GrB.MatrixAssign(c, nil, nil, a, indexRange(rowBegin, rowEnd), indexRange(colBegin, colEnd), nil)
+}
+
+// extractVector sets w = u(begin..end-1), or does nothing if the range is empty.
+func extractVector[D any](w, u GrB.Vector[D], begin, end int) error {
+	if begin == end {
+		return nil
+	}
+	return GrB.VectorExtract(w, nil, nil, u, indexRange(begin, end), nil)
+}
+
+// assignVector sets w(begin..end-1) = u, or accumulates u into it, or does nothing if the range is empty.
+func assignVector[D any](w GrB.Vector[D], accum *GrB.BinaryOp[D, D, D], u GrB.Vector[D], begin, end int) error {
+	if begin == end {
+		return nil
+	}
+	return GrB.VectorAssign(w, nil, accum, u, indexRange(begin, end), nil)
+}
+
+func serialize[D any](matrix GrB.Matrix[D]) (data []byte, err error) {
+	size, err := matrix.SerializeSize()
+	if err != nil {
+		return
+	}
+	data = make([]byte, size)
+	size, err = matrix.Serialize(data)
+	return data[:size], err
+}
+
+// sendMatrix sends matrix to all given workers. Matrices without any positions (with zero rows
+// or columns) are not sent, since the receivers can create them without communication. Matrices
+// without entries are sent as empty messages, since SuiteSparse:GraphBLAS fails to deserialize
+// some of them, as described under "Known issues" in [GrB].
+func sendMatrix[D any](grid *Grid, to []int, matrix GrB.Matrix[D]) error {
+	if len(to) == 0 {
+		return nil
+	}
+	nrows, ncols, err := matrix.Size()
+	if err != nil || nrows == 0 || ncols == 0 {
+		return err
+	}
+	nvals, err := matrix.Nvals()
+	if err != nil {
+		return err
+	}
+	var data []byte
+	if nvals > 0 {
+		if data, err = serialize(matrix); err != nil {
+			return err
+		}
+	}
+	for _, rank := range to {
+		if err = grid.transport.Send(rank, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recvMatrix receives an nrows x ncols matrix sent with sendMatrix.
+func recvMatrix[D any](grid *Grid, from, nrows, ncols int) (matrix GrB.Matrix[D], err error) {
+	if nrows == 0 || ncols == 0 {
+		return GrB.MatrixNew[D](nrows, ncols)
+	}
+	data, err := grid.transport.Recv(from)
+	if err != nil {
+		return
+	}
+	if len(data) == 0 {
+		return GrB.MatrixNew[D](nrows, ncols)
+	}
+	return GrB.MatrixDeserialize[D](data)
+}
+
+// sendVector sends vector to all given workers, as an n x 1 matrix.
+func sendVector[D any](grid *Grid, to []int, vector GrB.Vector[D]) (err error) {
+	defer GrB.CheckErrors(&err)
+	if len(to) == 0 {
+		return nil
+	}
+	n, err := vector.Size()
+	GrB.OK(err)
+	if n == 0 {
+		return nil
+	}
+	matrix, err := GrB.MatrixNew[D](n, 1)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(matrix.Free())
+	}()
+	GrB.OK(GrB.MatrixColAssign(matrix, nil, nil, vector, GrB.All(n), 0, nil))
+	return sendMatrix(grid, to, matrix)
+}
+
+// recvVector receives a vector of size n sent with sendVector.
+func recvVector[D any](grid *Grid, from, n int) (vector GrB.Vector[D], err error) {
+	defer GrB.CheckErrors(&err)
+	matrix, err := recvMatrix[D](grid, from, n, 1)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(matrix.Free())
+	}()
+	vector, err = GrB.VectorNew[D](n)
+	GrB.OK(err)
+	if n == 0 {
+		return
+	}
+	if err = GrB.MatrixColExtract(vector, nil, nil, matrix, GrB.All(n), 0, nil); err != nil {
+		_ = vector.Free()
+	}
+	return
+}
+
+// allReduce combines the values of the given workers with the monoid, which must include this
+// worker. All of these workers obtain the same result, since the values are combined in the
+// order of the workers.
+func allReduce[D any](grid *Grid, ranks []int, op GrB.Monoid[D], value D) (result D, err error) {
+	defer GrB.CheckErrors(&err)
+	self := grid.transport.Rank()
+	scalar, err := GrB.MatrixNew[D](1, 1)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(scalar.Free())
+	}()
+	GrB.OK(scalar.SetElement(value, 0, 0))
+	var others []int
+	for _, rank := range ranks {
+		if rank != self {
+			others = append(others, rank)
+		}
+	}
+	GrB.OK(sendMatrix(grid, others, scalar))
+
+	values, err := GrB.VectorNew[D](len(ranks))
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(values.Free())
+	}()
+	for k, rank := range ranks {
+		if rank == self {
+			GrB.OK(values.SetElement(value, k))
+			continue
+		}
+		partial, err := recvMatrix[D](grid, rank, 1, 1)
+		GrB.OK(err)
+		v, ok, err := partial.ExtractElement(0, 0)
+		GrB.OK(partial.Free())
+		GrB.OK(err)
+		if ok {
+			GrB.OK(values.SetElement(v, k))
+		}
+	}
+	return GrB.VectorReduce(op, values, nil)
+}
@@ -0,0 +1,70 @@
+package distributed_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"github.com/intel/forGraphBLASGo/GrB/distributed"
+	"sync"
+)
+
+// The result of MxM may be one of its operands, as in M = M * M.
+func Example_aliasedMxM() {
+	const root, n = 0, 6
+	transports := distributed.Loopback(4)
+	var wg sync.WaitGroup
+	errs := make([]error, len(transports))
+	var same bool
+	for rank, transport := range transports {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[rank] = func() (err error) {
+				defer GrB.CheckErrors(&err)
+				grid, err := distributed.GridNew(transport, 2, 2)
+				GrB.OK(err)
+				// a path 0 -> 1 -> ... -> n-1 with weights 1..n-1, and its square computed locally
+				var A, C GrB.Matrix[int]
+				if rank == root {
+					A, err = GrB.MatrixNew[int](n, n)
+					GrB.OK(err)
+					defer func() {
+						GrB.OK(A.Free())
+					}()
+					for i := range n - 1 {
+						GrB.OK(A.SetElement(i+1, i, i+1))
+					}
+					C, err = GrB.MatrixNew[int](n, n)
+					GrB.OK(err)
+					defer func() {
+						GrB.OK(C.Free())
+					}()
+					GrB.OK(GrB.MxM(C, nil, nil, GrB.PlusTimesSemiring[int](), A, A, nil))
+				}
+				M, err := distributed.MatrixScatter(grid, root, A, n, n)
+				GrB.OK(err)
+				defer func() {
+					GrB.OK(M.Free())
+				}()
+				GrB.OK(distributed.MxM(M, GrB.PlusTimesSemiring[int](), M, M))
+				B, err := M.Gather(root)
+				GrB.OK(err)
+				if rank == root {
+					defer func() {
+						GrB.OK(B.Free())
+					}()
+					same = equal(C, B)
+				}
+				return transport.Close()
+			}()
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			panic(err)
+		}
+	}
+	fmt.Println(same)
+	// Output:
+	// true
+}
@@ -0,0 +1,62 @@
+package distributed_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"github.com/intel/forGraphBLASGo/GrB/distributed"
+	"sync"
+)
+
+// Iso-valued matrices without entries, such as the tiles of a sparse iso-valued matrix,
+// cannot be serialized and deserialized by SuiteSparse:GraphBLAS, and must not be sent as such.
+func Example_isoValued() {
+	const root, n = 0, 6
+	transports := distributed.Loopback(4)
+	var wg sync.WaitGroup
+	errs := make([]error, len(transports))
+	var same bool
+	for rank, transport := range transports {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[rank] = func() (err error) {
+				defer GrB.CheckErrors(&err)
+				grid, err := distributed.GridNew(transport, 2, 2)
+				GrB.OK(err)
+				// an iso-valued matrix with entries in the upper left tile only
+				var A GrB.Matrix[int]
+				if rank == root {
+					A, err = GrB.MatrixNew[int](n, n)
+					GrB.OK(err)
+					defer func() {
+						GrB.OK(A.Free())
+					}()
+					GrB.OK(GrB.MatrixAssignConstant(A, nil, nil, 1, []int{0, 1}, []int{0, 1, 2}, nil))
+				}
+				M, err := distributed.MatrixScatter(grid, root, A, n, n)
+				GrB.OK(err)
+				defer func() {
+					GrB.OK(M.Free())
+				}()
+				B, err := M.Gather(root)
+				GrB.OK(err)
+				if rank == root {
+					defer func() {
+						GrB.OK(B.Free())
+					}()
+					same = equal(A, B)
+				}
+				return transport.Close()
+			}()
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			panic(err)
+		}
+	}
+	fmt.Println(same)
+	// Output:
+	// true
+}
@@ -0,0 +1,44 @@
+package distributed_test
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB/distributed"
+	"net"
+)
+
+// A message header with an implausible length fails the connection instead of
+// allocating the claimed amount of memory.
+func Example_tcpMessageSize() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+	addrs := []string{listener.Addr().String(), ""}
+
+	// a peer that claims to be worker 1, and then sends the header of a huge message
+	go func() {
+		conn, err := net.Dial("tcp", addrs[0])
+		if err != nil {
+			panic(err)
+		}
+		var header [16]byte
+		binary.BigEndian.PutUint64(header[:8], 1)
+		binary.BigEndian.PutUint64(header[8:], 1<<62)
+		if _, err = conn.Write(header[:]); err != nil {
+			panic(err)
+		}
+	}()
+
+	transport, err := distributed.TCP(0, listener, addrs)
+	if err != nil {
+		panic(err)
+	}
+	defer func() {
+		_ = transport.Close()
+	}()
+	_, err = transport.Recv(1)
+	fmt.Println(err)
+	// Output:
+	// distributed: message of 4611686018427387904 bytes exceeds MaxMessageSize
+}
@@ -0,0 +1,262 @@
+package distributed_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"github.com/intel/forGraphBLASGo/GrB/distributed"
+	"net"
+	"slices"
+	"sync"
+)
+
+// matrix returns an nrows x ncols matrix with a deterministic pattern of entries.
+func matrix(nrows, ncols, seed int) (A GrB.Matrix[int], err error) {
+	if A, err = GrB.MatrixNew[int](nrows, ncols); err != nil {
+		return
+	}
+	for i := range nrows {
+		for j := range ncols {
+			if (i*ncols+j+seed)%3 != 0 {
+				if err = A.SetElement((i+1)*(j+seed)%7, i, j); err != nil {
+					return
+				}
+			}
+		}
+	}
+	return
+}
+
+func equal(A, B GrB.Matrix[int]) bool {
+	var ai, aj, ax, bi, bj, bx []int
+	if A.ExtractTuples(&ai, &aj, &ax) != nil || B.ExtractTuples(&bi, &bj, &bx) != nil {
+		return false
+	}
+	return slices.Equal(ai, bi) && slices.Equal(aj, bj) && slices.Equal(ax, bx)
+}
+
+func equalVectors(u, v GrB.Vector[int]) bool {
+	var ui, ux, vi, vx []int
+	if u.ExtractTuples(&ui, &ux) != nil || v.ExtractTuples(&vi, &vx) != nil {
+		return false
+	}
+	return slices.Equal(ui, vi) && slices.Equal(ux, vx)
+}
+
+// worker runs on each worker of an nrows x ncols grid, and reports its findings on worker 0.
+func worker(transport distributed.Transport, nrows, ncols int, report func(...any)) (err error) {
+	defer GrB.CheckErrors(&err)
+	const root = 0
+	grid, err := distributed.GridNew(transport, nrows, ncols)
+	GrB.OK(err)
+
+	// the input is only created on the root worker
+	var A, B GrB.Matrix[int]
+	var u GrB.Vector[int]
+	if transport.Rank() == root {
+		A, err = matrix(7, 5, 1)
+		GrB.OK(err)
+		B, err = matrix(5, 2, 2)
+		GrB.OK(err)
+		u, err = GrB.VectorFromDense([]int{1, 2, 3, 4, 5})
+		GrB.OK(err)
+		defer func() {
+			GrB.OK(A.Free())
+			GrB.OK(B.Free())
+			GrB.OK(u.Free())
+		}()
+	}
+
+	dA, err := distributed.MatrixScatter(grid, root, A, 7, 5)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(dA.Free())
+	}()
+	dB, err := distributed.MatrixScatter(grid, root, B, 5, 2)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(dB.Free())
+	}()
+	du, err := distributed.VectorScatter(grid, root, u, 5, distributed.ColAligned)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(du.Free())
+	}()
+
+	dC, err := distributed.MatrixNew[int](grid, 7, 2)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(dC.Free())
+	}()
+	GrB.OK(distributed.MxM(dC, GrB.PlusTimes[int](), dA, dB))
+
+	dw, err := distributed.VectorNew[int](grid, 7, distributed.RowAligned)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(dw.Free())
+	}()
+	GrB.OK(distributed.MxV(dw, GrB.PlusTimes[int](), dA, du))
+
+	dE, err := distributed.MatrixNew[int](grid, 7, 5)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(dE.Free())
+	}()
+	GrB.OK(distributed.MatrixEWiseAdd(dE, GrB.Plus[int](), dA, dA))
+	sumA, err := distributed.MatrixReduce(GrB.PlusMonoid[int](), dA)
+	GrB.OK(err)
+	sumE, err := distributed.MatrixReduce(GrB.PlusMonoid[int](), dE)
+	GrB.OK(err)
+
+	// the row sums of A are A * 1
+	ones, err := distributed.VectorNew[int](grid, 5, distributed.ColAligned)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(ones.Free())
+	}()
+	begin, end := ones.LocalRange()
+	GrB.OK(GrB.VectorAssignConstant(ones.Local, nil, nil, 1, GrB.All(end-begin), nil))
+	rowSums, err := distributed.VectorNew[int](grid, 7, distributed.RowAligned)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(rowSums.Free())
+	}()
+	GrB.OK(distributed.MatrixReduceRows(rowSums, GrB.PlusMonoid[int](), dA))
+	degrees, err := distributed.VectorNew[int](grid, 7, distributed.RowAligned)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(degrees.Free())
+	}()
+	GrB.OK(distributed.MxV(degrees, GrB.PlusTimes[int](), dA, ones))
+	GrB.OK(distributed.VectorEWiseAdd(degrees, GrB.Minus[int](), degrees, rowSums))
+	differences, err := distributed.VectorReduce(GrB.PlusMonoid[int](), degrees)
+	GrB.OK(err)
+
+	// w is row aligned, and becomes column aligned
+	realigned, err := dw.Realign()
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(realigned.Free())
+	}()
+	sumW, err := distributed.VectorReduce(GrB.PlusMonoid[int](), dw)
+	GrB.OK(err)
+	sumRealigned, err := distributed.VectorReduce(GrB.PlusMonoid[int](), realigned)
+	GrB.OK(err)
+
+	nvals, err := dA.Nvals()
+	GrB.OK(err)
+
+	C, err := dC.Gather(root)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(C.Free())
+	}()
+	w, err := dw.Gather(root)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(w.Free())
+	}()
+	w2, err := realigned.Gather(root)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(w2.Free())
+	}()
+	if transport.Rank() != root {
+		return
+	}
+
+	expectedC, err := GrB.MatrixNew[int](7, 2)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(expectedC.Free())
+	}()
+	GrB.OK(GrB.MxM(expectedC, nil, nil, GrB.PlusTimes[int](), A, B, nil))
+	expectedW, err := GrB.VectorNew[int](7)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(expectedW.Free())
+	}()
+	GrB.OK(GrB.MxV(expectedW, nil, nil, GrB.PlusTimes[int](), A, u, nil))
+	expectedNvals, err := A.Nvals()
+	GrB.OK(err)
+
+	report("MxM:", equal(C, expectedC))
+	report("MxV:", equalVectors(w, expectedW), equalVectors(w2, expectedW))
+	report("Reduce:", sumE == 2*sumA, sumW == sumRealigned, differences)
+	report("Nvals:", nvals == expectedNvals)
+	return
+}
+
+// run runs worker on all transports concurrently.
+func run(transports []distributed.Transport, nrows, ncols int) {
+	var wg sync.WaitGroup
+	var reports []string
+	errs := make([]error, len(transports))
+	for rank, transport := range transports {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[rank] = worker(transport, nrows, ncols, func(a ...any) {
+				reports = append(reports, fmt.Sprintln(a...))
+			})
+			if err := transport.Close(); errs[rank] == nil {
+				errs[rank] = err
+			}
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			panic(err)
+		}
+	}
+	for _, report := range reports {
+		fmt.Print(report)
+	}
+}
+
+func Example_loopback() {
+	run(distributed.Loopback(6), 2, 3)
+	// Output:
+	// MxM: true
+	// MxV: true true
+	// Reduce: true true 0
+	// Nvals: true
+}
+
+func Example_tcp() {
+	const n = 4
+	listeners := make([]net.Listener, n)
+	addrs := make([]string, n)
+	for rank := range listeners {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			panic(err)
+		}
+		listeners[rank] = listener
+		addrs[rank] = listener.Addr().String()
+	}
+
+	transports := make([]distributed.Transport, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for rank := range transports {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			transports[rank], errs[rank] = distributed.TCP(rank, listeners[rank], addrs)
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	run(transports, 2, 2)
+	// Output:
+	// MxM: true
+	// MxV: true true
+	// Reduce: true true 0
+	// Nvals: true
+}
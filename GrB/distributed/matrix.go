@@ -0,0 +1,193 @@
+package distributed
+
+import (
+	"github.com/intel/forGraphBLASGo/GrB"
+)
+
+// A Matrix is distributed over the workers of a [Grid] of pr x pc workers. The rows of the
+// matrix are partitioned into pr blocks, and the columns into pc blocks, of almost equal size.
+// The worker at position (i, j) of the grid owns the tile with the rows of block i and the
+// columns of block j.
+//
+// The methods of Matrix that communicate with other workers are collective, and must be
+// called by all workers of the grid.
+type Matrix[D any] struct {
+	grid                   *Grid
+	nrows, ncols           int
+	rowOffsets, colOffsets []int
+
+	// Local is the tile owned by this worker. The entry Local(i, j) corresponds to
+	// the entry (rowBegin + i, colBegin + j) of the distributed matrix, where rowBegin
+	// and colBegin are returned by [Matrix.LocalRange].
+	Local GrB.Matrix[D]
+}
+
+// MatrixNew creates a new distributed matrix of the given size without any entries.
+// MatrixNew does not communicate with other workers, but must be called on all workers
+// of the grid to create the matrix.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidValue]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func MatrixNew[D any](grid *Grid, nrows, ncols int) (*Matrix[D], error) {
+	if nrows < 0 || ncols < 0 {
+		return nil, GrB.MakeError(GrB.InvalidValue)
+	}
+	matrix := &Matrix[D]{
+		grid:       grid,
+		nrows:      nrows,
+		ncols:      ncols,
+		rowOffsets: partition(nrows, grid.nrows),
+		colOffsets: partition(ncols, grid.ncols),
+	}
+	rowBegin, rowEnd, colBegin, colEnd := matrix.LocalRange()
+	local, err := GrB.MatrixNew[D](rowEnd-rowBegin, colEnd-colBegin)
+	if err != nil {
+		return nil, err
+	}
+	matrix.Local = local
+	return matrix, nil
+}
+
+// Grid returns the grid over which the matrix is distributed.
+func (matrix *Matrix[D]) Grid() *Grid {
+	return matrix.grid
+}
+
+// Size returns the dimensions of the distributed matrix.
+func (matrix *Matrix[D]) Size() (nrows, ncols int) {
+	return matrix.nrows, matrix.ncols
+}
+
+// LocalRange returns the rows rowBegin..rowEnd-1 and the columns colBegin..colEnd-1
+// of the distributed matrix that are held by the local tile of this worker.
+func (matrix *Matrix[D]) LocalRange() (rowBegin, rowEnd, colBegin, colEnd int) {
+	return matrix.tileRange(matrix.grid.Position())
+}
+
+func (matrix *Matrix[D]) tileRange(row, col int) (rowBegin, rowEnd, colBegin, colEnd int) {
+	return matrix.rowOffsets[row], matrix.rowOffsets[row+1], matrix.colOffsets[col], matrix.colOffsets[col+1]
+}
+
+// Free frees the local tile of this worker.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.Panic]
+func (matrix *Matrix[D]) Free() error {
+	return matrix.Local.Free()
+}
+
+// Nvals returns the number of entries of the distributed matrix on all workers.
+//
+// Nvals is collective.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func (matrix *Matrix[D]) Nvals() (int, error) {
+	nvals, err := matrix.Local.Nvals()
+	if err != nil {
+		return 0, err
+	}
+	return allReduce(matrix.grid, indexRange(0, matrix.grid.transport.Size()), GrB.PlusMonoid[int](), nvals)
+}
+
+// sameShape reports whether a is distributed over the same grid with the same partition as b.
+func sameShape[DA, DB any](a *Matrix[DA], b *Matrix[DB]) bool {
+	return a.grid == b.grid && a.nrows == b.nrows && a.ncols == b.ncols
+}
+
+// MatrixScatter creates a distributed nrows x ncols matrix from the matrix a on the worker
+// with rank root, which sends each tile to its owner. a is only used on the root worker,
+// and must have the given size.
+//
+// MatrixScatter is collective.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch], [GrB.InvalidValue], [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func MatrixScatter[D any](grid *Grid, root int, a GrB.Matrix[D], nrows, ncols int) (matrix *Matrix[D], err error) {
+	// registered before CheckErrors, so that it sees the recovered error
+	defer func() {
+		if err != nil && matrix != nil {
+			_ = matrix.Free()
+			matrix = nil
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+	matrix, err = MatrixNew[D](grid, nrows, ncols)
+	GrB.OK(err)
+
+	if grid.transport.Rank() == root {
+		r, c, err := a.Size()
+		GrB.OK(err)
+		if r != nrows || c != ncols {
+			GrB.OK(GrB.MakeError(GrB.DimensionMismatch))
+		}
+		for row := range grid.nrows {
+			for col := range grid.ncols {
+				rowBegin, rowEnd, colBegin, colEnd := matrix.tileRange(row, col)
+				tile, err := GrB.MatrixNew[D](rowEnd-rowBegin, colEnd-colBegin)
+				GrB.OK(err)
+				err = extractMatrix(tile, a, rowBegin, rowEnd, colBegin, colEnd)
+				if err == nil {
+					err = sendMatrix(grid, []int{grid.rank(row, col)}, tile)
+				}
+				GrB.OK(tile.Free())
+				GrB.OK(err)
+			}
+		}
+	}
+
+	rowBegin, rowEnd, colBegin, colEnd := matrix.LocalRange()
+	tile, err := recvMatrix[D](grid, root, rowEnd-rowBegin, colEnd-colBegin)
+	GrB.OK(err)
+	GrB.OK(matrix.Local.Free())
+	matrix.Local = tile
+	return
+}
+
+// Gather returns the distributed matrix as a single matrix on the worker with rank root,
+// to which all workers send their tiles. On the other workers, Gather returns an invalid matrix.
+//
+// Gather is collective.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func (matrix *Matrix[D]) Gather(root int) (a GrB.Matrix[D], err error) {
+	// registered before CheckErrors, so that it sees the recovered error
+	defer func() {
+		if err != nil {
+			_ = a.Free()
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+	grid := matrix.grid
+	GrB.OK(sendMatrix(grid, []int{root}, matrix.Local))
+	if grid.transport.Rank() != root {
+		return
+	}
+
+	a, err = GrB.MatrixNew[D](matrix.nrows, matrix.ncols)
+	GrB.OK(err)
+	for row := range grid.nrows {
+		for col := range grid.ncols {
+			rowBegin, rowEnd, colBegin, colEnd := matrix.tileRange(row, col)
+			tile, err := recvMatrix[D](grid, grid.rank(row, col), rowEnd-rowBegin, colEnd-colBegin)
+			GrB.OK(err)
+			err = assignMatrix(a, tile, rowBegin, rowEnd, colBegin, colEnd)
+			GrB.OK(tile.Free())
+			GrB.OK(err)
+		}
+	}
+	return
+}
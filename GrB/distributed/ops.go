@@ -0,0 +1,312 @@
+package distributed
+
+import (
+	"github.com/intel/forGraphBLASGo/GrB"
+	"slices"
+	"sort"
+)
+
+// blockOf returns the block k with offsets[k] <= index < offsets[k+1].
+func blockOf(offsets []int, index int) int {
+	return sort.SearchInts(offsets, index+1) - 1
+}
+
+// addOperator returns the binary operator of the additive monoid of op.
+func addOperator[DC, DA, DB any](op GrB.Semiring[DC, DA, DB]) (add GrB.BinaryOp[DC, DC, DC], err error) {
+	monoid, err := op.Add()
+	if err != nil {
+		return
+	}
+	return monoid.Operator()
+}
+
+// others returns ranks without the rank of this worker.
+func others(grid *Grid, ranks []int) []int {
+	self := grid.transport.Rank()
+	return slices.DeleteFunc(slices.Clone(ranks), func(rank int) bool {
+		return rank == self
+	})
+}
+
+// rowRanks returns the ranks of the workers in the given grid row.
+func rowRanks(grid *Grid, row int) (ranks []int) {
+	for col := range grid.ncols {
+		ranks = append(ranks, grid.rank(row, col))
+	}
+	return
+}
+
+// colRanks returns the ranks of the workers in the given grid column.
+func colRanks(grid *Grid, col int) (ranks []int) {
+	for row := range grid.nrows {
+		ranks = append(ranks, grid.rank(row, col))
+	}
+	return
+}
+
+// allReduceVector combines the partial vectors of the given workers, which must include
+// this worker, into w with op. All of these workers obtain the same result, since the partial
+// vectors are combined in the order of the workers.
+func allReduceVector[D any](grid *Grid, ranks []int, op GrB.BinaryOp[D, D, D], partial, w GrB.Vector[D]) (err error) {
+	defer GrB.CheckErrors(&err)
+	GrB.OK(sendVector(grid, others(grid, ranks), partial))
+	n, err := w.Size()
+	GrB.OK(err)
+	GrB.OK(w.Clear())
+	for _, rank := range ranks {
+		if rank == grid.transport.Rank() {
+			GrB.OK(assignVector(w, &op, partial, 0, n))
+			continue
+		}
+		other, err := recvVector[D](grid, rank, n)
+		GrB.OK(err)
+		err = assignVector(w, &op, other, 0, n)
+		GrB.OK(other.Free())
+		GrB.OK(err)
+	}
+	return
+}
+
+// MxM computes the distributed matrix product c = a * b on a semiring with the SUMMA algorithm.
+// The previous content of c is replaced. c may be the same matrix as a or b.
+//
+// The inner dimension is split into panels, such that the columns of each panel belong to a
+// single block of columns of a, and the rows of each panel belong to a single block of rows of b.
+// For each panel, the owners of the corresponding parts of the tiles of a send them to the other
+// workers of their grid row, the owners of the corresponding parts of the tiles of b send them to
+// the other workers of their grid column, and each worker accumulates the product of the parts
+// it holds into a temporary tile, using the additive monoid of op, which finally replaces its tile of c.
+//
+// MxM is collective.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch], [GrB.DomainMismatch], [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func MxM[DC, DA, DB any](c *Matrix[DC], op GrB.Semiring[DC, DA, DB], a *Matrix[DA], b *Matrix[DB]) (err error) {
+	defer GrB.CheckErrors(&err)
+	if a.grid != c.grid || b.grid != c.grid || a.nrows != c.nrows || b.ncols != c.ncols || a.ncols != b.nrows {
+		return GrB.MakeError(GrB.DimensionMismatch)
+	}
+	grid := c.grid
+	row, col := grid.Position()
+	add, err := addOperator(op)
+	GrB.OK(err)
+
+	// the panels are delimited by the union of the column offsets of a and the row offsets of b
+	boundaries := append(slices.Clone(a.colOffsets), b.rowOffsets...)
+	slices.Sort(boundaries)
+	boundaries = slices.Compact(boundaries)
+	rowBegin, rowEnd, colBegin, colEnd := c.LocalRange()
+	nrows, ncols := rowEnd-rowBegin, colEnd-colBegin
+
+	// the product is computed into a temporary tile, since c.Local may be a.Local or b.Local
+	product, err := GrB.MatrixNew[DC](nrows, ncols)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(product.Free())
+	}()
+	var accum *GrB.BinaryOp[DC, DC, DC]
+	for k := 0; k+1 < len(boundaries); k++ {
+		begin, end := boundaries[k], boundaries[k+1]
+		aOwner, bOwner := blockOf(a.colOffsets, begin), blockOf(b.rowOffsets, begin)
+
+		var aPanel GrB.Matrix[DA]
+		if col == aOwner {
+			aPanel, err = GrB.MatrixNew[DA](nrows, end-begin)
+			GrB.OK(err)
+			offset := a.colOffsets[aOwner]
+			GrB.OK(extractMatrix(aPanel, a.Local, 0, nrows, begin-offset, end-offset))
+			GrB.OK(sendMatrix(grid, others(grid, rowRanks(grid, row)), aPanel))
+		}
+		var bPanel GrB.Matrix[DB]
+		if row == bOwner {
+			bPanel, err = GrB.MatrixNew[DB](end-begin, ncols)
+			GrB.OK(err)
+			offset := b.rowOffsets[bOwner]
+			GrB.OK(extractMatrix(bPanel, b.Local, begin-offset, end-offset, 0, ncols))
+			GrB.OK(sendMatrix(grid, others(grid, colRanks(grid, col)), bPanel))
+		}
+		if col != aOwner {
+			aPanel, err = recvMatrix[DA](grid, grid.rank(row, aOwner), nrows, end-begin)
+			GrB.OK(err)
+		}
+		if row != bOwner {
+			bPanel, err = recvMatrix[DB](grid, grid.rank(bOwner, col), end-begin, ncols)
+			GrB.OK(err)
+		}
+
+		err = GrB.MxM(product, nil, accum, op, aPanel, bPanel, nil)
+		GrB.OK(aPanel.Free())
+		GrB.OK(bPanel.Free())
+		GrB.OK(err)
+		accum = &add
+	}
+	GrB.OK(c.Local.Clear())
+	GrB.OK(assignMatrix(c.Local, product, 0, nrows, 0, ncols))
+	return
+}
+
+// MxV computes the distributed matrix-vector product w = a * u on a semiring. u must be
+// column aligned, and w must be row aligned. The previous content of w is replaced.
+//
+// Each worker multiplies its tile of a with its block of u, and the workers of each grid row
+// combine their partial results with the additive monoid of op.
+//
+// MxV is collective.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch], [GrB.DomainMismatch], [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func MxV[Dw, DA, Du any](w *Vector[Dw], op GrB.Semiring[Dw, DA, Du], a *Matrix[DA], u *Vector[Du]) (err error) {
+	defer GrB.CheckErrors(&err)
+	if a.grid != w.grid || u.grid != w.grid || w.alignment != RowAligned || u.alignment != ColAligned ||
+		a.nrows != w.size || a.ncols != u.size {
+		return GrB.MakeError(GrB.DimensionMismatch)
+	}
+	grid := w.grid
+	row, _ := grid.Position()
+	add, err := addOperator(op)
+	GrB.OK(err)
+
+	n, err := w.Local.Size()
+	GrB.OK(err)
+	partial, err := GrB.VectorNew[Dw](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(partial.Free())
+	}()
+	GrB.OK(GrB.MxV(partial, nil, nil, op, a.Local, u.Local, nil))
+	return allReduceVector(grid, rowRanks(grid, row), add, partial, w.Local)
+}
+
+// MatrixEWiseAdd computes the element-wise union c = a ⊕ b of two distributed matrices of
+// the same size. Since the tiles of a, b, and c are owned by the same workers, each worker
+// only operates on its own tiles, without communication. The previous content of c is replaced.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch], [GrB.DomainMismatch], [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func MatrixEWiseAdd[DC, DA, DB any](c *Matrix[DC], op GrB.BinaryOp[DC, DA, DB], a *Matrix[DA], b *Matrix[DB]) error {
+	if !sameShape(c, a) || !sameShape(c, b) {
+		return GrB.MakeError(GrB.DimensionMismatch)
+	}
+	return GrB.MatrixEWiseAddBinaryOp(c.Local, nil, nil, op, a.Local, b.Local, nil)
+}
+
+// MatrixEWiseMult is like [MatrixEWiseAdd], except that it computes the element-wise
+// intersection c = a ⊗ b.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch], [GrB.DomainMismatch], [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func MatrixEWiseMult[DC, DA, DB any](c *Matrix[DC], op GrB.BinaryOp[DC, DA, DB], a *Matrix[DA], b *Matrix[DB]) error {
+	if !sameShape(c, a) || !sameShape(c, b) {
+		return GrB.MakeError(GrB.DimensionMismatch)
+	}
+	return GrB.MatrixEWiseMultBinaryOp(c.Local, nil, nil, op, a.Local, b.Local, nil)
+}
+
+// VectorEWiseAdd computes the element-wise union w = u ⊕ v of two distributed vectors of the
+// same size and alignment, without communication. The previous content of w is replaced.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch], [GrB.DomainMismatch], [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func VectorEWiseAdd[Dw, Du, Dv any](w *Vector[Dw], op GrB.BinaryOp[Dw, Du, Dv], u *Vector[Du], v *Vector[Dv]) error {
+	if !sameVectorShape(w, u) || !sameVectorShape(w, v) {
+		return GrB.MakeError(GrB.DimensionMismatch)
+	}
+	return GrB.VectorEWiseAddBinaryOp(w.Local, nil, nil, op, u.Local, v.Local, nil)
+}
+
+// VectorEWiseMult is like [VectorEWiseAdd], except that it computes the element-wise
+// intersection w = u ⊗ v.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch], [GrB.DomainMismatch], [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func VectorEWiseMult[Dw, Du, Dv any](w *Vector[Dw], op GrB.BinaryOp[Dw, Du, Dv], u *Vector[Du], v *Vector[Dv]) error {
+	if !sameVectorShape(w, u) || !sameVectorShape(w, v) {
+		return GrB.MakeError(GrB.DimensionMismatch)
+	}
+	return GrB.VectorEWiseMultBinaryOp(w.Local, nil, nil, op, u.Local, v.Local, nil)
+}
+
+// MatrixReduce reduces all entries of a distributed matrix to a scalar with a monoid,
+// which is returned on all workers.
+//
+// MatrixReduce is collective.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DomainMismatch], [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func MatrixReduce[D any](op GrB.Monoid[D], a *Matrix[D]) (result D, err error) {
+	partial, err := GrB.MatrixReduce(op, a.Local, nil)
+	if err != nil {
+		return
+	}
+	return allReduce(a.grid, indexRange(0, a.grid.transport.Size()), op, partial)
+}
+
+// MatrixReduceRows reduces each row of a distributed matrix to a scalar with a monoid,
+// and stores the results in the row-aligned vector w. The previous content of w is replaced.
+//
+// MatrixReduceRows is collective.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch], [GrB.DomainMismatch], [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func MatrixReduceRows[D any](w *Vector[D], op GrB.Monoid[D], a *Matrix[D]) (err error) {
+	defer GrB.CheckErrors(&err)
+	if a.grid != w.grid || w.alignment != RowAligned || a.nrows != w.size {
+		return GrB.MakeError(GrB.DimensionMismatch)
+	}
+	grid := w.grid
+	row, _ := grid.Position()
+	add, err := op.Operator()
+	GrB.OK(err)
+
+	n, err := w.Local.Size()
+	GrB.OK(err)
+	partial, err := GrB.VectorNew[D](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(partial.Free())
+	}()
+	GrB.OK(GrB.MatrixReduceMonoid(partial, nil, nil, op, a.Local, nil))
+	return allReduceVector(grid, rowRanks(grid, row), add, partial, w.Local)
+}
+
+// VectorReduce reduces all entries of a distributed vector to a scalar with a monoid,
+// which is returned on all workers.
+//
+// VectorReduce is collective.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DomainMismatch], [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func VectorReduce[D any](op GrB.Monoid[D], u *Vector[D]) (result D, err error) {
+	partial, err := GrB.VectorReduce(op, u.Local, nil)
+	if err != nil {
+		return
+	}
+	return allReduce(u.grid, u.peers(), op, partial)
+}
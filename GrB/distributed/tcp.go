@@ -0,0 +1,227 @@
+package distributed
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// DialTimeout is the maximum time [TCP] keeps trying to connect to another worker,
+// which gives the workers time to start up.
+var DialTimeout = 30 * time.Second
+
+// MaxMessageSize is the largest message in bytes that [TCP] sends or receives. A larger
+// length in a message header is treated as a protocol error, so that a corrupted stream
+// or a misbehaving peer cannot make a worker allocate arbitrary amounts of memory.
+var MaxMessageSize int64 = 1 << 32
+
+type tcp struct {
+	rank     int
+	listener net.Listener
+	conns    []net.Conn
+	writers  []*bufio.Writer
+	locks    []sync.Mutex
+	queues   []*queue // queues[from]
+	self     *queue
+}
+
+// TCP returns a transport that connects the worker with the given rank to the
+// other workers over TCP connections, for example to the worker processes on the
+// same or on other machines. addrs holds the addresses of all workers, and listener
+// must already be listening on addrs[rank]. Each worker connects to the workers with
+// lower ranks and accepts connections from the workers with higher ranks, so TCP
+// blocks until all workers have called it. The listener is closed when the transport
+// is closed.
+func TCP(rank int, listener net.Listener, addrs []string) (_ Transport, err error) {
+	n := len(addrs)
+	if rank < 0 || rank >= n {
+		return nil, errInvalidRank
+	}
+	t := &tcp{
+		rank:     rank,
+		listener: listener,
+		conns:    make([]net.Conn, n),
+		writers:  make([]*bufio.Writer, n),
+		locks:    make([]sync.Mutex, n),
+		queues:   make([]*queue, n),
+		self:     newQueue(),
+	}
+	defer func() {
+		if err != nil {
+			_ = t.Close()
+		}
+	}()
+
+	// accept the connections from the workers with higher ranks in the background
+	accepted := make(chan error, 1)
+	go func() {
+		accepted <- t.accept(n - 1 - rank)
+	}()
+	for other := 0; other < rank && err == nil; other++ {
+		err = t.connect(other, addrs[other])
+	}
+	if err != nil {
+		// stop accepting, so that the accepting goroutine is done before the cleanup
+		_ = listener.Close()
+		<-accepted
+		return nil, err
+	}
+	if err = <-accepted; err != nil {
+		return nil, err
+	}
+
+	for other, conn := range t.conns {
+		if conn == nil {
+			continue
+		}
+		t.writers[other] = bufio.NewWriter(conn)
+		t.queues[other] = newQueue()
+		go receive(bufio.NewReader(conn), t.queues[other])
+	}
+	return t, nil
+}
+
+// connect connects to the worker with the given rank, and sends it the rank of this worker.
+func (t *tcp) connect(other int, addr string) (err error) {
+	deadline := time.Now().Add(DialTimeout)
+	var conn net.Conn
+	for {
+		if conn, err = net.DialTimeout("tcp", addr, time.Until(deadline)); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.conns[other] = conn
+	var header [8]byte
+	binary.BigEndian.PutUint64(header[:], uint64(t.rank))
+	_, err = conn.Write(header[:])
+	return
+}
+
+// accept accepts n connections, each of which starts with the rank of the connecting worker.
+func (t *tcp) accept(n int) error {
+	for ; n > 0; n-- {
+		conn, err := t.listener.Accept()
+		if err != nil {
+			return err
+		}
+		var header [8]byte
+		if _, err = io.ReadFull(conn, header[:]); err != nil {
+			_ = conn.Close()
+			return err
+		}
+		other := int(binary.BigEndian.Uint64(header[:]))
+		if other <= t.rank || other >= len(t.conns) || t.conns[other] != nil {
+			_ = conn.Close()
+			return fmt.Errorf("distributed: unexpected connection from worker %v", other)
+		}
+		t.conns[other] = conn
+	}
+	return nil
+}
+
+// receive reads length-prefixed messages from r into q, until an error occurs.
+func receive(r *bufio.Reader, q *queue) {
+	for {
+		var header [8]byte
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			if err == io.EOF {
+				err = ErrClosed
+			}
+			q.close(err)
+			return
+		}
+		size := binary.BigEndian.Uint64(header[:])
+		if size > uint64(MaxMessageSize) {
+			q.close(fmt.Errorf("distributed: message of %v bytes exceeds MaxMessageSize", size))
+			return
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(r, data); err != nil {
+			q.close(err)
+			return
+		}
+		if q.push(data) != nil {
+			return
+		}
+	}
+}
+
+func (t *tcp) Rank() int {
+	return t.rank
+}
+
+func (t *tcp) Size() int {
+	return len(t.conns)
+}
+
+func (t *tcp) Send(to int, data []byte) error {
+	if to < 0 || to >= len(t.conns) {
+		return errInvalidRank
+	}
+	if to == t.rank {
+		return t.self.push(data)
+	}
+	t.locks[to].Lock()
+	defer t.locks[to].Unlock()
+	w := t.writers[to]
+	if w == nil {
+		return ErrClosed
+	}
+	if int64(len(data)) > MaxMessageSize {
+		return fmt.Errorf("distributed: message of %v bytes exceeds MaxMessageSize", len(data))
+	}
+	var header [8]byte
+	binary.BigEndian.PutUint64(header[:], uint64(len(data)))
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func (t *tcp) Recv(from int) ([]byte, error) {
+	if from < 0 || from >= len(t.conns) {
+		return nil, errInvalidRank
+	}
+	if from == t.rank {
+		return t.self.pop()
+	}
+	if t.queues[from] == nil {
+		return nil, ErrClosed
+	}
+	return t.queues[from].pop()
+}
+
+func (t *tcp) Close() (err error) {
+	t.self.close(ErrClosed)
+	for _, q := range t.queues {
+		if q != nil {
+			q.close(ErrClosed)
+		}
+	}
+	for other, conn := range t.conns {
+		if conn == nil {
+			continue
+		}
+		t.locks[other].Lock()
+		if cerr := conn.Close(); err == nil {
+			err = cerr
+		}
+		t.writers[other] = nil
+		t.locks[other].Unlock()
+	}
+	if lerr := t.listener.Close(); err == nil {
+		err = lerr
+	}
+	return
+}
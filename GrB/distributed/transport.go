@@ -0,0 +1,142 @@
+package distributed
+
+import (
+	"errors"
+	"sync"
+)
+
+// A Transport delivers messages between the workers of a distributed computation.
+// Each worker holds its own Transport, and the workers are identified by their
+// ranks 0..Size()-1.
+//
+// Messages sent from one worker to another are received in the order in which
+// they were sent. Send must not wait for the message to be received, so that
+// all workers can first send and then receive their messages in each step of
+// a computation.
+type Transport interface {
+	// Rank returns the rank of this worker.
+	Rank() int
+
+	// Size returns the number of workers.
+	Size() int
+
+	// Send sends a message to worker to, which may be this worker. The Transport
+	// takes ownership of data, so the caller must not modify it after Send returns.
+	Send(to int, data []byte) error
+
+	// Recv returns the next message from worker from, and blocks until it is available.
+	Recv(from int) ([]byte, error)
+
+	// Close releases the resources of this end of the Transport. Pending and
+	// subsequent calls of Recv return [ErrClosed] once all messages received
+	// before have been consumed.
+	Close() error
+}
+
+// ErrClosed is returned by the operations of a [Transport] that has been closed.
+var ErrClosed = errors.New("distributed: transport closed")
+
+// queue is an unbounded FIFO queue of messages.
+type queue struct {
+	mutex    sync.Mutex
+	nonempty sync.Cond
+	messages [][]byte
+	err      error
+}
+
+func newQueue() *queue {
+	q := new(queue)
+	q.nonempty.L = &q.mutex
+	return q
+}
+
+func (q *queue) push(data []byte) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.err != nil {
+		return q.err
+	}
+	q.messages = append(q.messages, data)
+	q.nonempty.Signal()
+	return nil
+}
+
+func (q *queue) pop() ([]byte, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	for len(q.messages) == 0 {
+		if q.err != nil {
+			return nil, q.err
+		}
+		q.nonempty.Wait()
+	}
+	data := q.messages[0]
+	q.messages[0] = nil
+	q.messages = q.messages[1:]
+	return data, nil
+}
+
+// close makes subsequent calls of push fail, and pop fail once the queue is empty.
+func (q *queue) close(err error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.err == nil {
+		q.err = err
+	}
+	q.nonempty.Broadcast()
+}
+
+type loopback struct {
+	rank   int
+	queues [][]*queue // queues[from][to]
+}
+
+// Loopback returns n connected in-process transports, one for each of n workers that
+// run as goroutines of the same process. Messages are passed by reference, without
+// any copying.
+func Loopback(n int) []Transport {
+	queues := make([][]*queue, n)
+	for from := range queues {
+		queues[from] = make([]*queue, n)
+		for to := range queues[from] {
+			queues[from][to] = newQueue()
+		}
+	}
+	transports := make([]Transport, n)
+	for rank := range transports {
+		transports[rank] = &loopback{rank: rank, queues: queues}
+	}
+	return transports
+}
+
+func (t *loopback) Rank() int {
+	return t.rank
+}
+
+func (t *loopback) Size() int {
+	return len(t.queues)
+}
+
+func (t *loopback) Send(to int, data []byte) error {
+	if to < 0 || to >= len(t.queues) {
+		return errInvalidRank
+	}
+	return t.queues[t.rank][to].push(data)
+}
+
+func (t *loopback) Recv(from int) ([]byte, error) {
+	if from < 0 || from >= len(t.queues) {
+		return nil, errInvalidRank
+	}
+	return t.queues[from][t.rank].pop()
+}
+
+func (t *loopback) Close() error {
+	for other := range t.queues {
+		t.queues[t.rank][other].close(ErrClosed)
+		t.queues[other][t.rank].close(ErrClosed)
+	}
+	return nil
+}
+
+var errInvalidRank = errors.New("distributed: invalid rank")
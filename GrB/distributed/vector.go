@@ -0,0 +1,322 @@
+package distributed
+
+import (
+	"github.com/intel/forGraphBLASGo/GrB"
+)
+
+// Alignment specifies how a distributed [Vector] is partitioned.
+type Alignment int
+
+const (
+	// RowAligned vectors are partitioned like the rows of a distributed [Matrix]: A vector
+	// is split into pr blocks, and the worker at position (i, j) of the grid holds block i.
+	// The result of [MxV] is row aligned.
+	RowAligned Alignment = iota
+
+	// ColAligned vectors are partitioned like the columns of a distributed [Matrix]: A vector
+	// is split into pc blocks, and the worker at position (i, j) of the grid holds block j.
+	// The input of [MxV] is column aligned.
+	ColAligned
+)
+
+// A Vector is distributed over the workers of a [Grid]. Depending on its [Alignment],
+// each block of the vector is replicated across the workers of a grid row or grid column.
+//
+// The methods of Vector that communicate with other workers are collective, and must be
+// called by all workers of the grid.
+type Vector[D any] struct {
+	grid      *Grid
+	size      int
+	alignment Alignment
+	offsets   []int
+
+	// Local is the block held by this worker. The entry Local(i) corresponds to the entry
+	// begin + i of the distributed vector, where begin is returned by [Vector.LocalRange].
+	Local GrB.Vector[D]
+}
+
+// VectorNew creates a new distributed vector of the given size and alignment, without any
+// entries. VectorNew does not communicate with other workers, but must be called on all
+// workers of the grid to create the vector.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidValue]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func VectorNew[D any](grid *Grid, size int, alignment Alignment) (*Vector[D], error) {
+	if size < 0 {
+		return nil, GrB.MakeError(GrB.InvalidValue)
+	}
+	vector := &Vector[D]{grid: grid, size: size, alignment: alignment}
+	switch alignment {
+	case RowAligned:
+		vector.offsets = partition(size, grid.nrows)
+	case ColAligned:
+		vector.offsets = partition(size, grid.ncols)
+	default:
+		return nil, GrB.MakeError(GrB.InvalidValue)
+	}
+	begin, end := vector.LocalRange()
+	local, err := GrB.VectorNew[D](end - begin)
+	if err != nil {
+		return nil, err
+	}
+	vector.Local = local
+	return vector, nil
+}
+
+// Grid returns the grid over which the vector is distributed.
+func (vector *Vector[D]) Grid() *Grid {
+	return vector.grid
+}
+
+// Size returns the size of the distributed vector.
+func (vector *Vector[D]) Size() int {
+	return vector.size
+}
+
+// Alignment returns the alignment of the distributed vector.
+func (vector *Vector[D]) Alignment() Alignment {
+	return vector.alignment
+}
+
+// LocalRange returns the indices begin..end-1 of the distributed vector that are held
+// by the local block of this worker.
+func (vector *Vector[D]) LocalRange() (begin, end int) {
+	block := vector.block()
+	return vector.offsets[block], vector.offsets[block+1]
+}
+
+// block returns the index of the block held by this worker.
+func (vector *Vector[D]) block() int {
+	row, col := vector.grid.Position()
+	if vector.alignment == RowAligned {
+		return row
+	}
+	return col
+}
+
+// holders returns the ranks of the workers that hold the given block.
+func (vector *Vector[D]) holders(block int) (ranks []int) {
+	grid := vector.grid
+	if vector.alignment == RowAligned {
+		for col := range grid.ncols {
+			ranks = append(ranks, grid.rank(block, col))
+		}
+	} else {
+		for row := range grid.nrows {
+			ranks = append(ranks, grid.rank(row, block))
+		}
+	}
+	return
+}
+
+// canonical returns the rank of the worker that holds the canonical copy of the given block.
+func (vector *Vector[D]) canonical(block int) int {
+	if vector.alignment == RowAligned {
+		return vector.grid.rank(block, 0)
+	}
+	return vector.grid.rank(0, block)
+}
+
+// Free frees the local block of this worker.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.Panic]
+func (vector *Vector[D]) Free() error {
+	return vector.Local.Free()
+}
+
+// Nvals returns the number of entries of the distributed vector on all workers.
+//
+// Nvals is collective.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func (vector *Vector[D]) Nvals() (int, error) {
+	nvals, err := vector.Local.Nvals()
+	if err != nil {
+		return 0, err
+	}
+	// the workers of a grid row (or column) together hold each block exactly once
+	return allReduce(vector.grid, vector.peers(), GrB.PlusMonoid[int](), nvals)
+}
+
+// peers returns the ranks of the workers that together hold each block of the vector exactly
+// once, and include this worker: the workers of the same grid column for row-aligned vectors,
+// and the workers of the same grid row for column-aligned vectors.
+func (vector *Vector[D]) peers() (ranks []int) {
+	grid := vector.grid
+	row, col := grid.Position()
+	if vector.alignment == RowAligned {
+		for r := range grid.nrows {
+			ranks = append(ranks, grid.rank(r, col))
+		}
+	} else {
+		for c := range grid.ncols {
+			ranks = append(ranks, grid.rank(row, c))
+		}
+	}
+	return
+}
+
+func sameVectorShape[DU, DV any](u *Vector[DU], v *Vector[DV]) bool {
+	return u.grid == v.grid && u.size == v.size && u.alignment == v.alignment
+}
+
+// VectorScatter creates a distributed vector of the given size and alignment from the vector
+// u on the worker with rank root, which sends each block to the workers that hold it.
+// u is only used on the root worker, and must have the given size.
+//
+// VectorScatter is collective.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch], [GrB.InvalidValue], [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func VectorScatter[D any](grid *Grid, root int, u GrB.Vector[D], size int, alignment Alignment) (vector *Vector[D], err error) {
+	// registered before CheckErrors, so that it sees the recovered error
+	defer func() {
+		if err != nil && vector != nil {
+			_ = vector.Free()
+			vector = nil
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+	vector, err = VectorNew[D](grid, size, alignment)
+	GrB.OK(err)
+
+	if grid.transport.Rank() == root {
+		n, err := u.Size()
+		GrB.OK(err)
+		if n != size {
+			GrB.OK(GrB.MakeError(GrB.DimensionMismatch))
+		}
+		for block := range len(vector.offsets) - 1 {
+			begin, end := vector.offsets[block], vector.offsets[block+1]
+			part, err := GrB.VectorNew[D](end - begin)
+			GrB.OK(err)
+			err = extractVector(part, u, begin, end)
+			if err == nil {
+				err = sendVector(grid, vector.holders(block), part)
+			}
+			GrB.OK(part.Free())
+			GrB.OK(err)
+		}
+	}
+
+	begin, end := vector.LocalRange()
+	part, err := recvVector[D](grid, root, end-begin)
+	GrB.OK(err)
+	GrB.OK(vector.Local.Free())
+	vector.Local = part
+	return
+}
+
+// Gather returns the distributed vector as a single vector on the worker with rank root.
+// On the other workers, Gather returns an invalid vector.
+//
+// Gather is collective.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func (vector *Vector[D]) Gather(root int) (u GrB.Vector[D], err error) {
+	// registered before CheckErrors, so that it sees the recovered error
+	defer func() {
+		if err != nil {
+			_ = u.Free()
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+	grid := vector.grid
+	if grid.transport.Rank() == vector.canonical(vector.block()) {
+		GrB.OK(sendVector(grid, []int{root}, vector.Local))
+	}
+	if grid.transport.Rank() != root {
+		return
+	}
+
+	u, err = GrB.VectorNew[D](vector.size)
+	GrB.OK(err)
+	for block := range len(vector.offsets) - 1 {
+		part, err := recvVector[D](grid, vector.canonical(block), vector.offsets[block+1]-vector.offsets[block])
+		GrB.OK(err)
+		err = assignVector(u, nil, part, vector.offsets[block], vector.offsets[block+1])
+		GrB.OK(part.Free())
+		GrB.OK(err)
+	}
+	return
+}
+
+// Realign returns a copy of the distributed vector with the other alignment. Each worker
+// only exchanges parts of blocks with the workers of its grid row or grid column.
+//
+// Realign is collective.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func (vector *Vector[D]) Realign() (realigned *Vector[D], err error) {
+	// registered before CheckErrors, so that it sees the recovered error
+	defer func() {
+		if err != nil && realigned != nil {
+			_ = realigned.Free()
+			realigned = nil
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+	grid := vector.grid
+	alignment := RowAligned
+	if vector.alignment == RowAligned {
+		alignment = ColAligned
+	}
+	realigned, err = VectorNew[D](grid, vector.size, alignment)
+	GrB.OK(err)
+
+	// The peers of a worker hold each source block exactly once, and all need the same target
+	// block: For row-aligned sources, the peers are the workers of grid column j, which all need
+	// target block j, and for column-aligned sources, the peers are the workers of grid row i,
+	// which all need target block i. So each worker sends the overlap of its source block with
+	// the target block to its peers, and assembles the target block from the overlaps it receives.
+	row, col := grid.Position()
+	sourceBlock, targetBlock := row, col
+	if vector.alignment == ColAligned {
+		sourceBlock, targetBlock = col, row
+	}
+
+	sourceBegin, sourceEnd := vector.offsets[sourceBlock], vector.offsets[sourceBlock+1]
+	targetBegin, targetEnd := realigned.offsets[targetBlock], realigned.offsets[targetBlock+1]
+	if begin, end := max(sourceBegin, targetBegin), min(sourceEnd, targetEnd); begin < end {
+		part, err := GrB.VectorNew[D](end - begin)
+		GrB.OK(err)
+		err = extractVector(part, vector.Local, begin-sourceBegin, end-sourceBegin)
+		if err == nil {
+			err = sendVector(grid, vector.peers(), part)
+		}
+		GrB.OK(part.Free())
+		GrB.OK(err)
+	}
+
+	for k, peer := range vector.peers() {
+		begin, end := max(vector.offsets[k], targetBegin), min(vector.offsets[k+1], targetEnd)
+		if begin >= end {
+			continue
+		}
+		part, err := recvVector[D](grid, peer, end-begin)
+		GrB.OK(err)
+		err = assignVector(realigned.Local, nil, part, begin-targetBegin, end-targetBegin)
+		GrB.OK(part.Free())
+		GrB.OK(err)
+	}
+	return
+}
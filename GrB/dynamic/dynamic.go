@@ -0,0 +1,333 @@
+/*
+Package dynamic provides undirected graphs that change over time, and results of graph
+algorithms that are maintained incrementally as the graphs change, instead of being recomputed
+from scratch.
+
+Changes are applied to a [Graph] in batches with [Graph.Apply], which accepts the edges to insert
+and to delete as boolean matrices. The edges are symmetrized, self-edges are ignored, and the
+batch is reduced to the edges that actually change: the inserted edges that are not yet in the
+graph, and the deleted edges that are in the graph and not inserted again. Deleted edges are
+removed from the adjacency matrix with a masked [GrB.MatrixAssign], and inserted edges are added
+with [GrB.MatrixEWiseAddBinaryOp].
+
+A Graph maintains the degrees of its vertices, the number of its triangles, and the levels of
+breadth-first searches from the sources registered with [Graph.TrackBFS]. The change in the number
+of triangles is computed from products of the changed edges with the adjacency matrix, the levels
+are lowered by relaxing the inserted edges, and a breadth-first search is only recomputed if a
+deletion leaves a vertex without any neighbor on the previous level. Subscribers registered with
+[Graph.Subscribe] are notified of each non-empty batch of changes.
+
+The vectors and matrices returned by a Graph are owned by the Graph, and must not be modified
+or freed. A Graph must not be used by several goroutines at the same time.
+
+Package dynamic is a forGraphBLASGo extension, and its API is experimental.
+*/
+package dynamic
+
+import (
+	"github.com/intel/forGraphBLASGo/GrB"
+	"github.com/intel/forGraphBLASGo/GrB/algorithm"
+)
+
+// A Delta describes the changes applied by a call of [Graph.Apply]. Inserts and Deletes are
+// symmetric, disjoint, and have no entries on the diagonal. Inserts holds the edges that were
+// not in the graph before, and Deletes holds the edges that were in the graph before, but no
+// longer are. Triangles is the change in the number of triangles.
+type Delta struct {
+	Inserts, Deletes GrB.Matrix[bool]
+	Triangles        int
+}
+
+// A Subscriber is notified of a [Delta]. The matrices of the delta are only valid
+// during the call of the subscriber.
+type Subscriber func(delta Delta) error
+
+// A Graph is an undirected graph without self-edges.
+type Graph struct {
+	size        int
+	adjacency   GrB.Matrix[bool]
+	degrees     GrB.Vector[int]
+	triangles   int
+	levels      map[int]GrB.Vector[int] // BFS levels, indexed by sources
+	subscribers map[int]Subscriber
+	nextID      int
+}
+
+// New creates a graph with n vertices and without any edges.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidValue]: n is negative.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func New(n int) (graph *Graph, err error) {
+	if n < 0 {
+		return nil, GrB.InvalidValue
+	}
+	graph = &Graph{
+		size:        n,
+		levels:      make(map[int]GrB.Vector[int]),
+		subscribers: make(map[int]Subscriber),
+	}
+	defer func() {
+		if err != nil {
+			_ = graph.Free()
+			graph = nil
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+	graph.adjacency, err = GrB.MatrixNew[bool](n, n)
+	GrB.OK(err)
+	graph.degrees, err = GrB.VectorNew[int](n)
+	GrB.OK(err)
+	if n > 0 {
+		GrB.OK(GrB.VectorAssignConstant(graph.degrees, nil, nil, 0, GrB.All(n), nil))
+	}
+	return
+}
+
+// FromMatrix creates a graph with the edges of the n x n adjacency matrix A, which
+// is symmetrized. The values of the entries of A and its diagonal are ignored.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch]: A is not square.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func FromMatrix[D GrB.Predefined](A GrB.Matrix[D]) (graph *Graph, err error) {
+	nrows, ncols, err := A.Size()
+	if err != nil {
+		return
+	}
+	if nrows != ncols {
+		return nil, GrB.DimensionMismatch
+	}
+	if graph, err = New(nrows); err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = graph.Free()
+			graph = nil
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+	edges, err := symmetricPattern(A)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(edges.Free())
+	}()
+	GrB.OK(graph.Apply(&edges, nil))
+	return
+}
+
+// Free frees the vectors and matrices of the graph.
+func (graph *Graph) Free() (err error) {
+	record := func(ferr error) {
+		if err == nil {
+			err = ferr
+		}
+	}
+	if graph.adjacency.Valid() {
+		record(graph.adjacency.Free())
+	}
+	if graph.degrees.Valid() {
+		record(graph.degrees.Free())
+	}
+	for _, level := range graph.levels {
+		record(level.Free())
+	}
+	return
+}
+
+// Size returns the number of vertices of the graph.
+func (graph *Graph) Size() int {
+	return graph.size
+}
+
+// Adjacency returns the symmetric adjacency matrix of the graph.
+func (graph *Graph) Adjacency() GrB.Matrix[bool] {
+	return graph.adjacency
+}
+
+// Degrees returns a full vector with the degree of each vertex.
+func (graph *Graph) Degrees() GrB.Vector[int] {
+	return graph.degrees
+}
+
+// Triangles returns the number of triangles in the graph.
+func (graph *Graph) Triangles() int {
+	return graph.triangles
+}
+
+// TrackBFS starts maintaining the levels of a breadth-first search from the given source, and
+// returns them. In the vector of levels, level(i) is the length of the shortest path from the
+// source to vertex i. Vertices that are not reachable from the source have no entry. If the
+// source is already tracked, its levels are returned.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidIndex]: source is not a vertex of the graph.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func (graph *Graph) TrackBFS(source int) (level GrB.Vector[int], err error) {
+	if source < 0 || source >= graph.size {
+		return level, GrB.InvalidIndex
+	}
+	level, ok := graph.levels[source]
+	if ok {
+		return
+	}
+	if level, err = algorithm.BFSLevels(graph.adjacency, source); err == nil {
+		graph.levels[source] = level
+	}
+	return
+}
+
+// Levels returns the levels of the breadth-first search from the given source,
+// and whether the source is tracked.
+func (graph *Graph) Levels(source int) (level GrB.Vector[int], ok bool) {
+	level, ok = graph.levels[source]
+	return
+}
+
+// UntrackBFS stops maintaining the levels of the breadth-first search from the given source,
+// and frees them. Untracked sources are ignored.
+func (graph *Graph) UntrackBFS(source int) (err error) {
+	if level, ok := graph.levels[source]; ok {
+		delete(graph.levels, source)
+		err = level.Free()
+	}
+	return
+}
+
+// Subscribe registers a subscriber, which is called at the end of each call of [Graph.Apply]
+// that changes the graph, and returns a function that unregisters the subscriber. Subscribers
+// are called in unspecified order.
+func (graph *Graph) Subscribe(subscriber Subscriber) (unsubscribe func()) {
+	id := graph.nextID
+	graph.nextID++
+	graph.subscribers[id] = subscriber
+	return func() {
+		delete(graph.subscribers, id)
+	}
+}
+
+// Apply deletes the edges in deletes from the graph, and then inserts the edges in inserts.
+// Either matrix may be nil. The matrices must be n x n, where n is the number of vertices of
+// the graph. They are symmetrized, and the values of their entries and their diagonals are
+// ignored. The degrees, the number of triangles, and the tracked levels are updated, and the
+// subscribers are notified if the graph changed. If a subscriber returns an error, the remaining
+// subscribers are still notified, and the first error is returned.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch]: One of the matrices does not have the size of the graph.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func (graph *Graph) Apply(inserts, deletes *GrB.Matrix[bool]) (err error) {
+	n := graph.size
+	var ins, del, empty GrB.Matrix[bool]
+	defer func() {
+		for _, m := range []GrB.Matrix[bool]{ins, del, empty} {
+			if m.Valid() {
+				if ferr := m.Free(); err == nil {
+					err = ferr
+				}
+			}
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+
+	batch := func(edges *GrB.Matrix[bool]) GrB.Matrix[bool] {
+		if edges == nil {
+			m, err := GrB.MatrixNew[bool](n, n)
+			GrB.OK(err)
+			return m
+		}
+		nrows, ncols, err := edges.Size()
+		GrB.OK(err)
+		if nrows != n || ncols != n {
+			GrB.OK(GrB.DimensionMismatch)
+		}
+		m, err := symmetricPattern(*edges)
+		GrB.OK(err)
+		return m
+	}
+	ins = batch(inserts)
+	del = batch(deletes)
+	if n == 0 {
+		return
+	}
+	empty, err = GrB.MatrixNew[bool](n, n)
+	GrB.OK(err)
+
+	// del<!struct(A)> = empty, del<struct(ins)> = empty, ins<struct(A)> = empty
+	GrB.OK(GrB.MatrixAssign(del, graph.adjacency.AsMask(), nil, empty, GrB.All(n), GrB.All(n), GrB.DescSC))
+	GrB.OK(GrB.MatrixAssign(del, &ins, nil, empty, GrB.All(n), GrB.All(n), GrB.DescS))
+	GrB.OK(GrB.MatrixAssign(ins, graph.adjacency.AsMask(), nil, empty, GrB.All(n), GrB.All(n), GrB.DescS))
+	ndel, err := del.Nvals()
+	GrB.OK(err)
+	nins, err := ins.Nvals()
+	GrB.OK(err)
+	if ndel == 0 && nins == 0 {
+		return
+	}
+	triangles := graph.triangles
+
+	if ndel > 0 {
+		// A<struct(del)> = empty
+		GrB.OK(GrB.MatrixAssign(graph.adjacency, &del, nil, empty, GrB.All(n), GrB.All(n), GrB.DescS))
+		t, err := triangleDelta(graph.adjacency, del)
+		GrB.OK(err)
+		graph.triangles -= t
+		minus := GrB.Minus[int]()
+		GrB.OK(GrB.MatrixReduceMonoid(graph.degrees, nil, &minus, GrB.PlusMonoid[int](), GrB.MatrixView[int, bool](del), nil))
+		for source, level := range graph.levels {
+			GrB.OK(graph.deleteLevels(source, level, del))
+		}
+	}
+
+	if nins > 0 {
+		t, err := triangleDelta(graph.adjacency, ins)
+		GrB.OK(err)
+		graph.triangles += t
+		// A = A lor ins
+		GrB.OK(GrB.MatrixEWiseAddBinaryOp(graph.adjacency, nil, nil, GrB.LorBool, graph.adjacency, ins, nil))
+		plus := GrB.Plus[int]()
+		GrB.OK(GrB.MatrixReduceMonoid(graph.degrees, nil, &plus, GrB.PlusMonoid[int](), GrB.MatrixView[int, bool](ins), nil))
+		for _, level := range graph.levels {
+			GrB.OK(graph.insertLevels(level, ins))
+		}
+	}
+
+	delta := Delta{Inserts: ins, Deletes: del, Triangles: graph.triangles - triangles}
+	for _, subscriber := range graph.subscribers {
+		if serr := subscriber(delta); err == nil {
+			err = serr
+		}
+	}
+	return
+}
+
+// symmetricPattern returns a new boolean matrix with an entry true at (i, j) and (j, i) for
+// each entry A(i, j) with i != j.
+func symmetricPattern[D GrB.Predefined](A GrB.Matrix[D]) (P GrB.Matrix[bool], err error) {
+	defer GrB.CheckErrors(&err)
+	n, err := A.Nrows()
+	GrB.OK(err)
+	P, err = GrB.MatrixNew[bool](n, n)
+	GrB.OK(err)
+	defer func() {
+		if err != nil {
+			_ = P.Free()
+		}
+	}()
+	if n == 0 {
+		return
+	}
+	GrB.OK(GrB.MatrixAssignConstant(P, A.AsMask(), nil, true, GrB.All(n), GrB.All(n), GrB.DescS))
+	GrB.OK(GrB.MatrixEWiseAddBinaryOp(P, nil, nil, GrB.LorBool, P, P, GrB.DescT1))
+	GrB.OK(GrB.MatrixSelect(P, nil, nil, GrB.Offdiag[bool](), P, 0, nil))
+	return
+}
@@ -0,0 +1,149 @@
+package dynamic_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"github.com/intel/forGraphBLASGo/GrB/algorithm"
+	"github.com/intel/forGraphBLASGo/GrB/dynamic"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func Example() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	// a path 0 - 1 - 2 - 3 - 4
+	const n = 5
+	A, err := GrB.MatrixNew[int](n, n)
+	OK(err)
+	defer func() {
+		OK(A.Free())
+	}()
+	OK(A.Build([]int{0, 1, 2, 3}, []int{1, 2, 3, 4}, []int{1, 1, 1, 1}, nil))
+	g, err := dynamic.FromMatrix(A)
+	OK(err)
+	defer func() {
+		OK(g.Free())
+	}()
+
+	nvals := func(m GrB.Matrix[bool]) int {
+		nvals, err := m.Nvals()
+		OK(err)
+		return nvals
+	}
+	unsubscribe := g.Subscribe(func(delta dynamic.Delta) error {
+		fmt.Println("inserted:", nvals(delta.Inserts)/2, "deleted:", nvals(delta.Deletes)/2, "triangles:", delta.Triangles)
+		return nil
+	})
+	level, err := g.TrackBFS(0)
+	OK(err)
+	values := func(v GrB.Vector[int]) []int {
+		var values []int
+		OK(v.ExtractTuples(nil, &values))
+		return values
+	}
+	show := func() {
+		fmt.Println("degrees:", values(g.Degrees()), "triangles:", g.Triangles(), "levels:", values(level))
+	}
+	show()
+
+	edges := func(pairs ...[2]int) *GrB.Matrix[bool] {
+		m, err := GrB.MatrixNew[bool](n, n)
+		OK(err)
+		for _, pair := range pairs {
+			OK(m.SetElement(true, pair[0], pair[1]))
+		}
+		return &m
+	}
+	batch := func(inserts, deletes *GrB.Matrix[bool]) {
+		OK(g.Apply(inserts, deletes))
+		for _, m := range []*GrB.Matrix[bool]{inserts, deletes} {
+			if m != nil {
+				OK(m.Free())
+			}
+		}
+		show()
+	}
+
+	// close the triangles 0 - 1 - 2 and 1 - 2 - 3, and a shortcut from 4 to 0
+	batch(edges([2]int{2, 0}, [2]int{1, 3}, [2]int{4, 0}, [2]int{1, 2}), nil)
+	// remove the edge 1 - 2, which is in both triangles, and insert and delete 3 - 0
+	batch(edges([2]int{3, 0}), edges([2]int{1, 2}, [2]int{0, 3}))
+	// nothing changes
+	batch(edges([2]int{4, 3}), edges([2]int{2, 4}))
+	unsubscribe()
+	// disconnect vertex 4
+	batch(nil, edges([2]int{4, 0}, [2]int{3, 4}))
+
+	// random batches on a larger graph, compared with recomputations from scratch
+	const m = 200
+	r := rand.New(rand.NewSource(42))
+	random := func(k int) *GrB.Matrix[bool] {
+		b, err := GrB.MatrixNew[bool](m, m)
+		OK(err)
+		for range k {
+			OK(b.SetElement(true, r.Intn(m), r.Intn(m)))
+		}
+		return &b
+	}
+	h, err := dynamic.New(m)
+	OK(err)
+	defer func() {
+		OK(h.Free())
+	}()
+	sources := []int{0, 17, 123}
+	for _, source := range sources {
+		_, err = h.TrackBFS(source)
+		OK(err)
+	}
+	mismatches := 0
+	for range 30 {
+		inserts, deletes := random(60), random(40)
+		OK(h.Apply(inserts, deletes))
+		OK(inserts.Free())
+		OK(deletes.Free())
+
+		t, err := algorithm.TriangleCount(h.Adjacency())
+		OK(err)
+		if t != h.Triangles() {
+			mismatches++
+		}
+		for _, source := range sources {
+			expected, err := algorithm.BFSLevels(h.Adjacency(), source)
+			OK(err)
+			var ei, ev, ai, av []int
+			OK(expected.ExtractTuples(&ei, &ev))
+			actual, _ := h.Levels(source)
+			OK(actual.ExtractTuples(&ai, &av))
+			if !slices.Equal(ei, ai) || !slices.Equal(ev, av) {
+				mismatches++
+			}
+			OK(expected.Free())
+		}
+	}
+	fmt.Println("mismatches:", mismatches, "triangles:", h.Triangles())
+
+	// Output:
+	// degrees: [1 2 2 2 1] triangles: 0 levels: [0 1 2 3 4]
+	// inserted: 3 deleted: 0 triangles: 2
+	// degrees: [3 3 3 3 2] triangles: 2 levels: [0 1 1 2 1]
+	// inserted: 1 deleted: 1 triangles: 1
+	// degrees: [4 2 2 4 2] triangles: 3 levels: [0 1 1 1 1]
+	// degrees: [4 2 2 4 2] triangles: 3 levels: [0 1 1 1 1]
+	// degrees: [3 2 2 3 0] triangles: 2 levels: [0 1 1 1]
+	// mismatches: 0 triangles: 769
+}
@@ -0,0 +1,129 @@
+package dynamic
+
+import (
+	"github.com/intel/forGraphBLASGo/GrB"
+	"github.com/intel/forGraphBLASGo/GrB/algorithm"
+)
+
+// triangleDelta returns the number of triangles of B + X with at least one edge in X, for
+// symmetric matrices B and X without common entries and without entries on their diagonals.
+//
+// The number of triangles of such a matrix M is trace(M³) / 6, and
+// trace((B + X)³) - trace(B³) = 3 trace(B B X) + 3 trace(B X X) + trace(X X X).
+// Since X is symmetric, trace(Y X) is the sum of the entries of Y masked with X.
+func triangleDelta(B, X GrB.Matrix[bool]) (delta int, err error) {
+	defer GrB.CheckErrors(&err)
+	n, err := B.Nrows()
+	GrB.OK(err)
+	C, err := GrB.MatrixNew[int](n, n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(C.Free())
+	}()
+	// sum returns the sum of the entries of L plus.pair R, masked with X
+	sum := func(L, R GrB.Matrix[bool]) int {
+		GrB.OK(GrB.MxM(C, X.AsMask(), nil, GrB.PlusOneb[int](), GrB.MatrixView[int, bool](L), GrB.MatrixView[int, bool](R), GrB.DescRS))
+		s, err := GrB.MatrixReduce(GrB.PlusMonoid[int](), C, nil)
+		GrB.OK(err)
+		return s
+	}
+	return (3*sum(B, B) + 3*sum(B, X) + sum(X, X)) / 6, nil
+}
+
+// insertLevels lowers the BFS levels after the edges in ins have been inserted into the graph.
+// Starting with the inserted edges, the edges from the vertices whose levels were lowered in the
+// previous step are relaxed, until no more levels are lowered.
+func (graph *Graph) insertLevels(level GrB.Vector[int], ins GrB.Matrix[bool]) (err error) {
+	defer GrB.CheckErrors(&err)
+	n := graph.size
+	q, err := level.Dup()
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(q.Free())
+	}()
+	t, err := GrB.VectorNew[int](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(t.Free())
+	}()
+	lower, err := GrB.VectorNew[bool](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(lower.Free())
+	}()
+
+	for edges := ins; ; edges = graph.adjacency {
+		// t = (q min.first edges) + 1
+		GrB.OK(GrB.VxM(t, nil, nil, GrB.MinFirstSemiring[int](), q, GrB.MatrixView[int, bool](edges), nil))
+		GrB.OK(GrB.VectorApplyBinaryOp2nd(t, nil, nil, GrB.Plus[int](), t, 1, nil))
+		// q<t < level> = t, q<!struct(level)> = t
+		GrB.OK(GrB.VectorEWiseMultBinaryOp(lower, nil, nil, GrB.Lt[int](), t, level, nil))
+		GrB.OK(q.Clear())
+		GrB.OK(GrB.VectorAssign(q, &lower, nil, t, GrB.All(n), nil))
+		GrB.OK(GrB.VectorAssign(q, level.AsMask(), nil, t, GrB.All(n), GrB.DescSC))
+		nq, err := q.Nvals()
+		GrB.OK(err)
+		if nq == 0 {
+			return nil
+		}
+		// level<struct(q)> = q
+		GrB.OK(GrB.VectorAssign(level, q.AsMask(), nil, q, GrB.All(n), GrB.DescS))
+	}
+}
+
+// deleteLevels updates the BFS levels from source after the edges in del have been deleted from
+// the graph. The levels only change if a vertex lost an edge to a neighbor on the previous level,
+// and no other neighbor on the previous level remains. In that case, the breadth-first search
+// is recomputed.
+func (graph *Graph) deleteLevels(source int, level GrB.Vector[int], del GrB.Matrix[bool]) (err error) {
+	defer GrB.CheckErrors(&err)
+	n := graph.size
+	t, err := GrB.VectorNew[int](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(t.Free())
+	}()
+	eq, err := GrB.VectorNew[bool](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(eq.Free())
+	}()
+	lost, err := GrB.VectorNew[bool](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(lost.Free())
+	}()
+
+	// trues returns the number of entries of eq that are true
+	trues := func() int {
+		GrB.OK(GrB.VectorAssignConstant(lost, &eq, nil, true, GrB.All(n), GrB.DescR))
+		nvals, err := lost.Nvals()
+		GrB.OK(err)
+		return nvals
+	}
+
+	// lost = vertices with a deleted edge to a neighbor on the previous level,
+	// as the levels of neighbors differ by at most 1
+	GrB.OK(GrB.VxM(t, nil, nil, GrB.MinFirstSemiring[int](), level, GrB.MatrixView[int, bool](del), nil))
+	GrB.OK(GrB.VectorApplyBinaryOp2nd(t, nil, nil, GrB.Plus[int](), t, 1, nil))
+	GrB.OK(GrB.VectorEWiseMultBinaryOp(eq, nil, nil, GrB.Eq[int](), t, level, nil))
+	nlost := trues()
+	if nlost == 0 {
+		return
+	}
+
+	// t<struct(lost), replace> = (level min.first A) + 1
+	GrB.OK(GrB.VxM(t, &lost, nil, GrB.MinFirstSemiring[int](), level, GrB.MatrixView[int, bool](graph.adjacency), GrB.DescRS))
+	GrB.OK(GrB.VectorApplyBinaryOp2nd(t, nil, nil, GrB.Plus[int](), t, 1, nil))
+	GrB.OK(GrB.VectorEWiseMultBinaryOp(eq, nil, nil, GrB.Eq[int](), t, level, nil))
+	if trues() == nlost {
+		return
+	}
+
+	fresh, err := algorithm.BFSLevels(graph.adjacency, source)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(fresh.Free())
+	}()
+	return GrB.VectorAssign(level, nil, nil, fresh, GrB.All(n), nil)
+}
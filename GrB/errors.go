@@ -186,6 +186,17 @@ func GlobalSetPanicOnError(onNotOff bool) error {
 	return nil
 }
 
+// MakeError reports a GraphBLAS API or execution error in the same way as the functions
+// of this package: it panics if info is an execution error, or if panicking on API errors
+// has been enabled with [GlobalSetPanicOnError], and otherwise returns info. MakeError
+// is intended for packages built on top of forGraphBLASGo. info must not be an
+// informational return code.
+//
+// MakeError is a forGraphBLASGo extension.
+func MakeError(info Info) error {
+	return makeError(info)
+}
+
 func makeError(info Info) error {
 	if isInformational(info) {
 		panic(fmt.Errorf("informational return code %w must not be returned by forGraphBLASGo - this should not happen", info))
@@ -0,0 +1,72 @@
+package GrB_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"runtime"
+	"testing"
+)
+
+func Example_borrow() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	// a 4 x 4 matrix in CSR format, produced by Go code
+	ptr := []int{0, 2, 3, 3, 5}
+	col := []int{1, 3, 2, 0, 1}
+	val := []float64{1, 2, 3, 4, 5}
+
+	A, err := GrB.MatrixNew[float64](4, 4)
+	OK(err)
+	ap := GrB.BorrowSlice(ptr)
+	aj := GrB.BorrowSlice(col)
+	ax := GrB.BorrowSlice(val)
+	OK(A.PackCSR(&ap, &aj, &ax, false, false, nil))
+
+	// GraphBLAS reads the Go slices directly
+	sum, err := GrB.MatrixReduce(GrB.PlusMonoid[float64](), A, nil)
+	OK(err)
+	fmt.Println(sum)
+
+	// modifying the matrix moves the content to memory allocated by GraphBLAS
+	OK(A.SetElement(6, 2, 2))
+	OK(A.Wait(GrB.Materialize))
+	nvals, err := A.Nvals()
+	OK(err)
+	fmt.Println(nvals)
+
+	OK(A.Free())
+	runtime.GC()
+
+	// borrowed memory can also be moved back out of a matrix
+	B, err := GrB.MatrixNew[float64](4, 4)
+	OK(err)
+	ap = GrB.BorrowSlice(ptr)
+	aj = GrB.BorrowSlice(col)
+	ax = GrB.BorrowSlice(val)
+	OK(B.PackCSR(&ap, &aj, &ax, false, false, nil))
+	ap, aj, ax, _, _, err = B.UnpackCSR(false, nil)
+	OK(err)
+	fmt.Println(ap.UnsafeSlice(), aj.UnsafeSlice(), ax.UnsafeSlice())
+	ap.Free()
+	aj.Free()
+	ax.Free()
+	OK(B.Free())
+
+	// Output:
+	// 15
+	// 6
+	// [0 2 3 3 5] [1 3 2 0 1] [1 2 3 4 5]
+}
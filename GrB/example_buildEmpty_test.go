@@ -0,0 +1,57 @@
+package GrB_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"testing"
+)
+
+func Example_buildEmpty() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	// building from empty slices leaves an empty matrix or vector empty
+	A, err := GrB.MatrixNew[float64](3, 4)
+	OK(err)
+	defer func() {
+		OK(A.Free())
+	}()
+	OK(A.Build(nil, nil, nil, nil))
+	u, err := GrB.VectorNew[int](5)
+	OK(err)
+	defer func() {
+		OK(u.Free())
+	}()
+	s, err := GrB.ScalarNew[int]()
+	OK(err)
+	defer func() {
+		OK(s.Free())
+	}()
+	OK(s.SetElement(1))
+	OK(u.BuildScalar([]int{}, s))
+	anvals, err := A.Nvals()
+	OK(err)
+	unvals, err := u.Nvals()
+	OK(err)
+	fmt.Println(anvals, unvals)
+
+	// like with tuples, the output must not have entries
+	OK(u.SetElement(2, 0))
+	fmt.Println(u.Build(nil, nil, nil))
+
+	// Output:
+	// 0 0
+	// GraphBLAS API error: output not empty
+}
@@ -0,0 +1,73 @@
+package GrB_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"testing"
+)
+
+func Example_dense() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	A, err := GrB.MatrixFromDense([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+	OK(err)
+	defer func() {
+		OK(A.Free())
+	}()
+
+	// drop the entries on the diagonal, and compute the transpose
+	B, err := GrB.MatrixNew[float64](3, 2)
+	OK(err)
+	defer func() {
+		OK(B.Free())
+	}()
+	OK(GrB.MatrixSelect(B, nil, nil, GrB.Offdiag[float64](), A, 0, GrB.DescT0))
+
+	rows, err := B.ToDense(-1)
+	OK(err)
+	fmt.Println(rows)
+
+	C, err := GrB.MatrixFromRowMajor(2, 2, []int{1, 0, 0, 1})
+	OK(err)
+	defer func() {
+		OK(C.Free())
+	}()
+	data, err := C.ToRowMajor(0)
+	OK(err)
+	fmt.Println(data)
+
+	u, err := GrB.VectorFromDense([]int{7, 8, 9, 10})
+	OK(err)
+	defer func() {
+		OK(u.Free())
+	}()
+	OK(u.RemoveElement(1))
+	values, err := u.ToDense(0)
+	OK(err)
+	fmt.Println(values)
+
+	_, err = GrB.MatrixFromDense([][]int{{1, 2}, {3}})
+	fmt.Println(err)
+
+	// Output:
+	// [[-1 4] [2 -1] [3 6]]
+	// [1 0 0 1]
+	// [7 0 9 10]
+	// GraphBLAS API error: slice mismatch
+}
@@ -0,0 +1,68 @@
+package GrB_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"testing"
+)
+
+func Example_descriptorExtensions() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	desc, err := GrB.DescriptorNew()
+	OK(err)
+	defer func() {
+		OK(desc.Free())
+	}()
+
+	// SortHint, Compression, and Import hold integers rather than GrB_Desc_Value settings;
+	// SuiteSparse:GraphBLAS stores any nonzero SortHint as 1
+	OK(desc.Set(GrB.SortHint, GrB.PreferSorted))
+	OK(desc.Set(GrB.Compression, GrB.CompressionZSTD3))
+	OK(desc.Set(GrB.Import, GrB.SecureImport))
+	for _, field := range []GrB.DescField{GrB.SortHint, GrB.Compression, GrB.Import} {
+		value, err := desc.Get(field)
+		OK(err)
+		fmt.Printf("%v: %d\n", field, value)
+	}
+
+	// the compression setting is used by SerializeBlob
+	A, err := GrB.MatrixNew[float64](100, 100)
+	OK(err)
+	defer func() {
+		OK(A.Free())
+	}()
+	for i := range 100 {
+		OK(A.SetElement(float64(i), i, i))
+	}
+	blob, err := A.SerializeBlob(&desc)
+	OK(err)
+	defer blob.Free()
+	B, err := GrB.MatrixDeserialize[float64](blob.UnsafeSlice())
+	OK(err)
+	defer func() {
+		OK(B.Free())
+	}()
+	nvals, err := B.Nvals()
+	OK(err)
+	fmt.Println(nvals)
+
+	// Output:
+	// sort control: 1
+	// compression: 3003
+	// import security: 7080
+	// 100
+}
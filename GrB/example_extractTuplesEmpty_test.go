@@ -0,0 +1,60 @@
+package GrB_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"testing"
+	"unsafe"
+)
+
+type point struct {
+	X, Y float32
+}
+
+func Example_extractTuplesEmpty() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	_, err := GrB.TypeNew[point](int(unsafe.Sizeof(point{})))
+	OK(err)
+	A, err := GrB.MatrixNew[point](3, 4)
+	OK(err)
+	defer func() {
+		OK(A.Free())
+	}()
+	u, err := GrB.VectorNew[point](5)
+	OK(err)
+	defer func() {
+		OK(u.Free())
+	}()
+
+	// nothing is appended to the slices for a matrix or vector without entries
+	rows, cols, values := []int{7}, []int(nil), []point(nil)
+	OK(A.ExtractTuples(&rows, &cols, &values))
+	fmt.Println(rows, cols == nil, values == nil)
+	var indices []int
+	var uvalues []point
+	OK(u.ExtractTuples(&indices, &uvalues))
+	fmt.Println(indices == nil, uvalues == nil)
+
+	OK(A.SetElement(point{1, 2}, 1, 3))
+	OK(A.ExtractTuples(&rows, &cols, &values))
+	fmt.Println(rows, cols, values)
+
+	// Output:
+	// [7] true true
+	// true true
+	// [7 1] [3] [{1 2}]
+}
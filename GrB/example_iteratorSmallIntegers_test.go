@@ -0,0 +1,63 @@
+package GrB_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"testing"
+)
+
+func Example_iteratorSmallIntegers() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	u, err := GrB.VectorNew[int16](3)
+	OK(err)
+	defer func() {
+		OK(u.Free())
+	}()
+	v, err := GrB.VectorNew[uint16](3)
+	OK(err)
+	defer func() {
+		OK(v.Free())
+	}()
+	OK(u.Build([]int{0, 1, 2}, []int16{-3, 300, -32768}, nil))
+	OK(v.Build([]int{0, 1, 2}, []uint16{7, 40000, 65535}, nil))
+
+	it, err := u.IteratorNew(nil)
+	OK(err)
+	var itEntries []string
+	ok, err := it.Seek(0)
+	for ; ok && err == nil; ok, err = it.Next() {
+		itEntries = append(itEntries, fmt.Sprint(it.GetIndex(), ":", it.Get()))
+	}
+	OK(err)
+	OK(it.Free())
+	fmt.Println(itEntries)
+
+	jt, err := v.IteratorNew(nil)
+	OK(err)
+	var jtEntries []string
+	ok, err = jt.Seek(0)
+	for ; ok && err == nil; ok, err = jt.Next() {
+		jtEntries = append(jtEntries, fmt.Sprint(jt.GetIndex(), ":", jt.Get()))
+	}
+	OK(err)
+	OK(jt.Free())
+	fmt.Println(jtEntries)
+
+	// Output:
+	// [0:-3 1:300 2:-32768]
+	// [0:7 1:40000 2:65535]
+}
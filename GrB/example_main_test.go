@@ -1,3 +1,5 @@
+//go:build !memoryaccounting
+
 package GrB_test
 
 import (
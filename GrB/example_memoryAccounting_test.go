@@ -0,0 +1,84 @@
+//go:build memoryaccounting
+
+package GrB_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"testing"
+)
+
+// With the memoryaccounting build tag, all tests and examples of the GrB package run
+// with memory accounting, which must be enabled before GraphBLAS allocates any memory:
+//
+//	go test -tags memoryaccounting ./GrB
+func TestMain(m *testing.M) {
+	if err := GrB.InitWithMemoryAccounting(GrB.NonBlocking, 0); err != nil {
+		panic(err)
+	}
+	defer func() {
+		if err := GrB.Finalize(); err != nil {
+			panic(err)
+		}
+	}()
+	m.Run()
+}
+
+func Example_memoryAccounting() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.InitWithMemoryAccounting(GrB.NonBlocking, 0))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	GrB.ResetMemoryPeak()
+	before := GrB.MemoryStats()
+
+	data := make([]float64, 1000*1000)
+	for i := range data {
+		data[i] = float64(i % 7)
+	}
+	A, err := GrB.MatrixFromRowMajor(1000, 1000, data)
+	OK(err)
+	after := GrB.MemoryStats()
+	fmt.Println(after.Live-before.Live >= 1000*1000*8, after.Peak >= after.Live, after.Allocations > before.Allocations)
+
+	// limit further allocations to a small amount of memory
+	OK(GrB.SetMemoryBudget(after.Live + 1024))
+	func() {
+		defer func() {
+			fmt.Println("recovered:", recover())
+		}()
+		B, err := GrB.MatrixNew[float64](1000, 1000)
+		OK(err)
+		defer func() {
+			OK(B.Free())
+		}()
+		OK(GrB.MxM(B, nil, nil, GrB.PlusTimesSemiring[float64](), A, A, nil))
+		OK(B.Wait(GrB.Materialize))
+	}()
+	OK(GrB.SetMemoryBudget(0))
+
+	OK(A.Free())
+	fmt.Println(GrB.MemoryStats().Live == before.Live)
+
+	// a failed initialization keeps the allocator in place
+	fmt.Println(GrB.InitWithMemoryAccounting(GrB.NonBlocking, 0))
+	fmt.Println(GrB.MemoryStats().Live == before.Live)
+
+	// Output:
+	// true true true
+	// recovered: GraphBLAS execution error: out of memory
+	// true
+	// GraphBLAS API error: invalid value
+	// true
+}
@@ -0,0 +1,126 @@
+package GrB_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"testing"
+)
+
+func Example_permutation() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	A, err := GrB.MatrixFromDense([][]int{
+		{11, 12, 13},
+		{21, 22, 23},
+		{31, 32, 33},
+	})
+	OK(err)
+	defer func() {
+		OK(A.Free())
+	}()
+
+	p, err := GrB.PermutationFromSlice([]int{2, 0, 1})
+	OK(err)
+	defer func() {
+		OK(p.Free())
+	}()
+	q, err := p.Inverse()
+	OK(err)
+	defer func() {
+		OK(q.Free())
+	}()
+	indices, err := q.Indices()
+	OK(err)
+	fmt.Println(indices)
+
+	// P*A*P'
+	B, err := A.PermuteSymmetric(p)
+	OK(err)
+	defer func() {
+		OK(B.Free())
+	}()
+	rows, err := B.ToDense(0)
+	OK(err)
+	fmt.Println(rows)
+
+	// only permute the columns
+	C, err := A.Permute(nil, &p)
+	OK(err)
+	defer func() {
+		OK(C.Free())
+	}()
+	rows, err = C.ToDense(0)
+	OK(err)
+	fmt.Println(rows)
+
+	// undo the permutation
+	D, err := B.PermuteSymmetric(q)
+	OK(err)
+	defer func() {
+		OK(D.Free())
+	}()
+	rows, err = D.ToDense(0)
+	OK(err)
+	fmt.Println(rows)
+
+	P, err := p.Matrix()
+	OK(err)
+	defer func() {
+		OK(P.Free())
+	}()
+	pattern, err := P.ToDense(false)
+	OK(err)
+	fmt.Println(pattern)
+
+	u, err := GrB.VectorFromDense([]float64{1.5, 2.5, 3.5})
+	OK(err)
+	defer func() {
+		OK(u.Free())
+	}()
+	w, err := u.Permute(p)
+	OK(err)
+	defer func() {
+		OK(w.Free())
+	}()
+	values, err := w.ToDense(0)
+	OK(err)
+	fmt.Println(values)
+
+	// a Permutation can be modified like any vector, but duplicate indices are rejected
+	bad, err := GrB.PermutationNew(3)
+	OK(err)
+	defer func() {
+		OK(bad.Free())
+	}()
+	OK(bad.SetElement(0, 1))
+	_, err = u.Permute(bad)
+	fmt.Println(err)
+	_, err = bad.Matrix()
+	fmt.Println(err)
+	OK(bad.SetElement(7, 1))
+	_, err = bad.Inverse()
+	fmt.Println(err)
+	// Output:
+	// [1 2 0]
+	// [[33 31 32] [13 11 12] [23 21 22]]
+	// [[13 11 12] [23 21 22] [33 31 32]]
+	// [[11 12 13] [21 22 23] [31 32 33]]
+	// [[false false true] [true false false] [false true false]]
+	// [3.5 1.5 2.5]
+	// GraphBLAS API error: invalid value
+	// GraphBLAS API error: invalid value
+	// GraphBLAS API error: invalid value
+}
@@ -0,0 +1,89 @@
+package GrB_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"testing"
+)
+
+func Example_registry() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	// names as they may appear in a configuration file
+	config := struct {
+		semiring, monoid, select_ string
+	}{"min.plus", "max", "offdiag"}
+
+	A, err := GrB.MatrixFromDense([][]float64{
+		{0, 2, 5},
+		{1, 0, 1},
+		{4, 3, 0},
+	})
+	OK(err)
+	defer func() {
+		OK(A.Free())
+	}()
+
+	s, err := GrB.SemiringByName[float64](config.semiring)
+	OK(err)
+	m, err := GrB.MonoidByName[float64](config.monoid)
+	OK(err)
+	op, err := GrB.IndexUnaryOpByName[bool, float64, int64](config.select_)
+	OK(err)
+
+	// C = A min.+ A, without the diagonal
+	C, err := GrB.MatrixNew[float64](3, 3)
+	OK(err)
+	defer func() {
+		OK(C.Free())
+	}()
+	OK(GrB.MxM(C, nil, nil, s, A, A, nil))
+	OK(GrB.MatrixSelect(C, nil, nil, op, C, 0, nil))
+	rows, err := C.ToDense(0)
+	OK(err)
+	fmt.Println(rows)
+
+	// largest shortest two-hop distance
+	max, err := GrB.MatrixReduce(m, C, nil)
+	OK(err)
+	fmt.Println(max)
+
+	// user-defined operators can be registered under custom names
+	OK(GrB.RegisterSemiring("shortest_path", GrB.MinPlusSemiring[float64]()))
+	_, err = GrB.SemiringByName[float64]("ShortestPath")
+	fmt.Println(err)
+
+	// "pair" is an alias for "oneb" only as a whole word
+	pair, err := GrB.SemiringByName[float64]("PlusPair")
+	OK(err)
+	fmt.Println(pair == GrB.PlusOneb[float64]())
+	OK(GrB.RegisterBinaryOp("repair", GrB.Max[float64]()))
+	_, err = GrB.BinaryOpByName[float64]("reoneb")
+	fmt.Println(errors.Is(err, GrB.InvalidValue))
+
+	// names are registered per domain
+	_, err = GrB.SemiringByName[bool]("min_plus")
+	fmt.Println(err, errors.Is(err, GrB.InvalidValue))
+
+	// Output:
+	// [[0 2 3] [1 0 1] [4 3 0]]
+	// 4
+	// <nil>
+	// true
+	// true
+	// semiring "min_plus" not found for domain bool: GraphBLAS API error: invalid value true
+}
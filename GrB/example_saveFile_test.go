@@ -0,0 +1,92 @@
+package GrB_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func Example_saveFile() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	// a 1000 x 1000 matrix with a few diagonals
+	const n = 1000
+	var rows, cols []int
+	var values []float64
+	for i := 0; i < n; i++ {
+		for _, j := range []int{i - 7, i, i + 3} {
+			if j >= 0 && j < n {
+				rows = append(rows, i)
+				cols = append(cols, j)
+				values = append(values, float64(i)/float64(j+1))
+			}
+		}
+	}
+	A, err := GrB.MatrixNew[float64](n, n)
+	OK(err)
+	defer func() {
+		OK(A.Free())
+	}()
+	OK(A.Build(rows, cols, values, nil))
+
+	dir, err := os.MkdirTemp("", "example_saveFile")
+	OK(err)
+	defer func() {
+		OK(os.RemoveAll(dir))
+	}()
+	name := filepath.Join(dir, "A.grb")
+
+	desc, err := GrB.DescriptorNew()
+	OK(err)
+	defer func() {
+		OK(desc.Free())
+	}()
+	OK(desc.Set(GrB.Compression, GrB.CompressionZSTD))
+	OK(A.SaveFile(name, &desc))
+
+	B, err := GrB.MatrixLoadFile[float64](name)
+	OK(err)
+	defer func() {
+		OK(B.Free())
+	}()
+
+	nvals, err := B.Nvals()
+	OK(err)
+	var arows, acols, brows, bcols []int
+	var avalues, bvalues []float64
+	OK(B.ExtractTuples(&brows, &bcols, &bvalues))
+	OK(A.ExtractTuples(&arows, &acols, &avalues))
+	fmt.Println(nvals == len(rows), slices.Equal(arows, brows), slices.Equal(acols, bcols), slices.Equal(avalues, bvalues))
+
+	_, err = GrB.MatrixLoadFile[int](name)
+	fmt.Println(err)
+
+	_, err = GrB.MatrixLoadFile[float64](filepath.Join(dir, "missing.grb"))
+	fmt.Println(os.IsNotExist(err))
+
+	empty := filepath.Join(dir, "empty.grb")
+	OK(os.WriteFile(empty, nil, 0o644))
+	_, err = GrB.MatrixLoadFile[float64](empty)
+	fmt.Println(err)
+	// Output:
+	// true true true true
+	// GraphBLAS API error: domain mismatch
+	// true
+	// GraphBLAS API error: invalid value
+}
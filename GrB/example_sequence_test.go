@@ -0,0 +1,117 @@
+package GrB_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"testing"
+	"time"
+)
+
+/*
+ * Same as LevelBreadthFirstSearch, except that the traversal is cancelled
+ * when ctx is done, or when it takes longer than the given timeout.
+ */
+func LevelBreadthFirstSearchContext(ctx context.Context, timeout time.Duration, A GrB.Matrix[bool], s GrB.Index) (v GrB.Vector[int], err error) {
+	n, err := A.Nrows()
+	if err != nil {
+		return
+	}
+
+	v, err = GrB.VectorNew[int](n)
+	if err != nil {
+		return
+	}
+
+	err = GrB.RunSequence(ctx, timeout, func(seq *GrB.Sequence) error {
+		// vertices visited in each level
+		q, err := GrB.VectorNew[bool](n)
+		if err != nil {
+			return err
+		}
+		// q vector no longer needed after the sequence
+		seq.Temporary(&q)
+
+		// q[s] = true, false everywhere else
+		if err = q.SetElement(true, s); err != nil {
+			return err
+		}
+
+		// succ == true when some successor found
+		for d, succ := 1, true; succ; d++ {
+			if err = seq.Do(
+				// v[q] = d
+				func() error {
+					return GrB.VectorAssignConstant(v, &q, nil, d, GrB.All(n), nil)
+				},
+				// q [!v] = q ||.&& A ; finds all the unvisited successors from current q
+				func() error {
+					return GrB.VxM(q, v.AsMask(), nil, GrB.LorLandSemiringBool, q, A, GrB.DescRC)
+				},
+				// succ = ||(q)
+				func() (err error) {
+					succ, err = GrB.VectorReduce(GrB.LorMonoidBool, q, nil)
+					return
+				},
+			); err != nil {
+				return err
+			}
+		}
+		return v.WaitContext(seq.Context(), GrB.Materialize)
+	})
+	if err != nil {
+		_ = v.Free()
+	}
+	return
+}
+
+func Example_sequence() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	A, err := GrB.MatrixNew[bool](15, 15)
+	OK(err)
+	defer func() {
+		OK(A.Free())
+	}()
+
+	OK(A.Build(
+		[]int{0, 0, 0, 0, 1, 1, 2, 2, 3, 3, 4, 4, 6, 7, 10, 11},
+		[]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 13, 14, 14},
+		[]bool{true, true, true, true, true, true, true, true, true, true, true, true, true, true, true, true},
+		nil,
+	))
+
+	v, err := LevelBreadthFirstSearchContext(context.Background(), time.Minute, A, 0)
+	OK(err)
+	defer func() {
+		OK(v.Free())
+	}()
+
+	var indices, values []int
+	OK(v.ExtractTuples(&indices, &values))
+	fmt.Println(indices)
+	fmt.Println(values)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = LevelBreadthFirstSearchContext(ctx, 0, A, 0)
+	fmt.Println(err)
+
+	// Output:
+	// [0 1 2 3 4 5 6 7 8 9 10 11 12 13 14]
+	// [1 2 2 2 2 3 3 3 3 3 3 3 3 4 4]
+	// context canceled
+}
@@ -0,0 +1,55 @@
+package GrB_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"testing"
+)
+
+func Example_serializeBlob() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	A, err := GrB.MatrixNew[int32](3, 3)
+	OK(err)
+	defer func() {
+		OK(A.Free())
+	}()
+	OK(A.Build([]int{0, 1, 2}, []int{1, 2, 0}, []int32{10, 20, 30}, nil))
+
+	// SerializeBlob allocates the result, which must be freed explicitly
+	blob, err := A.SerializeBlob(nil)
+	OK(err)
+	defer blob.Free()
+
+	// the type name allows choosing the domain of the deserialized matrix
+	name, err := GrB.DeserializeTypeName(blob.UnsafeSlice())
+	OK(err)
+	fmt.Println(name)
+
+	B, err := GrB.MatrixDeserialize[int32](blob.UnsafeSlice())
+	OK(err)
+	defer func() {
+		OK(B.Free())
+	}()
+	var rows, cols []int
+	var values []int32
+	OK(B.ExtractTuples(&rows, &cols, &values))
+	fmt.Println(rows, cols, values)
+
+	// Output:
+	// int32_t
+	// [0 1 2] [1 2 0] [10 20 30]
+}
@@ -0,0 +1,62 @@
+package GrB_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"testing"
+)
+
+func Example_stats() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	A, err := GrB.MatrixNew[float64](5, 5)
+	OK(err)
+	defer func() {
+		OK(A.Free())
+	}()
+	OK(A.Build(
+		[]int{0, 0, 1, 1, 2, 3, 3},
+		[]int{0, 1, 0, 3, 2, 1, 3},
+		[]float64{1, 2, 2, 5, 3, 5, 4},
+		nil,
+	))
+
+	stats, err := A.Stats()
+	OK(err)
+	fmt.Println(stats.Nrows, stats.Ncols, stats.Nvals, stats.Density)
+	fmt.Println(stats.MinRowDegree, stats.MaxRowDegree, stats.MeanRowDegree, stats.EmptyRows)
+	fmt.Println(stats.MinColDegree, stats.MaxColDegree, stats.MeanColDegree, stats.EmptyCols)
+	fmt.Println(stats.DiagonalEntries, stats.LowerBandwidth, stats.UpperBandwidth, stats.Bandwidth)
+	fmt.Println(stats.StructurallySymmetric, stats.NumericallySymmetric, stats.Iso)
+
+	OK(A.SetElement(6, 3, 1))
+	stats, err = A.Stats()
+	OK(err)
+	fmt.Println(stats.StructurallySymmetric, stats.NumericallySymmetric)
+
+	OK(A.SetElement(1, 4, 0))
+	stats, err = A.Stats()
+	OK(err)
+	fmt.Println(stats.StructurallySymmetric, stats.NumericallySymmetric, stats.EmptyRows, stats.LowerBandwidth)
+	// Output:
+	// 5 5 7 0.28
+	// 0 2 1.4 1
+	// 0 2 1.4 1
+	// 3 2 2 2
+	// true true false
+	// true false
+	// false false 0 4
+}
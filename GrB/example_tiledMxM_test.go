@@ -0,0 +1,76 @@
+package GrB_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"slices"
+	"testing"
+)
+
+func Example_tiledMxM() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	// band matrices A (40 x 30) and B (30 x 50)
+	band := func(nrows, ncols, width int) GrB.Matrix[int] {
+		var rows, cols, values []int
+		for i := 0; i < nrows; i++ {
+			for j := max(0, i-width); j < min(ncols, i+width+1); j++ {
+				rows = append(rows, i)
+				cols = append(cols, j)
+				values = append(values, i+2*j+1)
+			}
+		}
+		M, err := GrB.MatrixNew[int](nrows, ncols)
+		OK(err)
+		OK(M.Build(rows, cols, values, nil))
+		return M
+	}
+	A := band(40, 30, 3)
+	B := band(30, 50, 5)
+	defer func() {
+		OK(A.Free())
+		OK(B.Free())
+	}()
+
+	C, err := GrB.MatrixNew[int](40, 50)
+	OK(err)
+	defer func() {
+		OK(C.Free())
+	}()
+	OK(GrB.MxM(C, nil, nil, GrB.PlusTimes[int](), A, B, nil))
+
+	// the same product, computed in 3 x 2 blocks, with the inner dimension split into 4 blocks,
+	// on at most 3 goroutines with 2 threads each
+	T, err := GrB.MatrixNew[int](40, 50)
+	OK(err)
+	defer func() {
+		OK(T.Free())
+	}()
+	OK(GrB.TiledMxM(T, GrB.PlusTimes[int](), A, B, GrB.TiledOptions{
+		RowTiles:   3,
+		ColTiles:   2,
+		InnerTiles: 4,
+		Workers:    3,
+		NThreads:   2,
+	}))
+
+	var cRows, cCols, cValues, tRows, tCols, tValues []int
+	OK(C.ExtractTuples(&cRows, &cCols, &cValues))
+	OK(T.ExtractTuples(&tRows, &tCols, &tValues))
+	fmt.Println(len(tValues), slices.Equal(cRows, tRows) && slices.Equal(cCols, tCols) && slices.Equal(cValues, tValues))
+	// Output:
+	// 504 true
+}
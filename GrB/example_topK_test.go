@@ -0,0 +1,81 @@
+package GrB_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"testing"
+)
+
+func Example_topK() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	// a similarity matrix
+	A, err := GrB.MatrixFromDense([][]float64{
+		{0.0, 0.9, 0.3, 0.9, 0.5},
+		{0.9, 0.0, 0.1, 0.2, 0.2},
+		{0.3, 0.1, 0.0, 0.7, 0.8},
+		{0.9, 0.2, 0.7, 0.0, 0.7},
+	})
+	OK(err)
+	defer func() {
+		OK(A.Free())
+	}()
+	// drop the self-similarities
+	OK(GrB.MatrixSelect(A, nil, nil, GrB.Offdiag[float64](), A, 0, nil))
+
+	C, err := GrB.MatrixNew[float64](4, 2)
+	OK(err)
+	defer func() {
+		OK(C.Free())
+	}()
+	P, err := GrB.MatrixNew[int](4, 2)
+	OK(err)
+	defer func() {
+		OK(P.Free())
+	}()
+
+	OK(GrB.MatrixTopKPerRow(&C, &P, A, 2, GrB.Gt[float64]()))
+	values, err := C.ToDense(0)
+	OK(err)
+	indices, err := P.ToDense(-1)
+	OK(err)
+	fmt.Println(values)
+	fmt.Println(indices)
+
+	Ccol, err := GrB.MatrixNew[float64](1, 5)
+	OK(err)
+	defer func() {
+		OK(Ccol.Free())
+	}()
+	Pcol, err := GrB.MatrixNew[int](1, 5)
+	OK(err)
+	defer func() {
+		OK(Pcol.Free())
+	}()
+
+	OK(GrB.MatrixTopKPerCol(&Ccol, &Pcol, A, 1, GrB.Lt[float64]()))
+	values, err = Ccol.ToDense(0)
+	OK(err)
+	indices, err = Pcol.ToDense(-1)
+	OK(err)
+	fmt.Println(values)
+	fmt.Println(indices)
+	// Output:
+	// [[0.9 0.9] [0.9 0.2] [0.8 0.7] [0.9 0.7]]
+	// [[1 3] [0 3] [4 3] [0 2]]
+	// [[0.3 0.1 0.1 0.2 0.2]]
+	// [[2 2 1 1 1]]
+}
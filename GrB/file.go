@@ -0,0 +1,59 @@
+package GrB
+
+import "os"
+
+// SaveFile serializes the matrix with [Matrix.SerializeBlob], and writes the result to
+// the named file, which is created or truncated. The [Compression] field of desc selects
+// the compression method.
+//
+// GraphBLAS API errors that may be returned:
+//   - [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [InvalidObject], [OutOfMemory], [Panic]
+//
+// Errors of the file system are returned as they are.
+//
+// SaveFile is a forGraphBLASGo extension.
+func (matrix Matrix[D]) SaveFile(name string, desc *Descriptor) (err error) {
+	blob, err := matrix.SerializeBlob(desc)
+	if err != nil {
+		return
+	}
+	defer blob.Free()
+	return os.WriteFile(name, blob.UnsafeSlice(), 0o644)
+}
+
+// MatrixLoadFile constructs a new GraphBLAS matrix from the named file, which holds
+// a matrix serialized with [Matrix.SaveFile], [Matrix.SerializeBlob], or [Matrix.Serialize].
+//
+// On Linux, the file is mapped into memory with [syscall.Mmap], and the matrix is deserialized
+// directly from the mapped pages, so the content of the file is never copied into the Go heap.
+// On other systems, the file is read into memory first.
+//
+// GraphBLAS API errors that may be returned:
+//   - [DomainMismatch], [UninitializedObject]
+//   - [InvalidValue]: The file is empty.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [InvalidObject], [OutOfMemory], [Panic]
+//
+// Errors of the file system are returned as they are.
+//
+// MatrixLoadFile is a forGraphBLASGo extension.
+func MatrixLoadFile[D any](name string) (matrix Matrix[D], err error) {
+	data, unmap, err := mapFile(name)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if uerr := unmap(); err == nil {
+			err = uerr
+		}
+	}()
+	if len(data) == 0 {
+		err = makeError(InvalidValue)
+		return
+	}
+	return MatrixDeserialize[D](data)
+}
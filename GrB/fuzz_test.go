@@ -0,0 +1,872 @@
+package GrB_test
+
+import (
+	"bytes"
+	"slices"
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/intel/forGraphBLASGo/GrB"
+)
+
+// The fuzz targets in this file round-trip random data through the marshalling
+// layers of the binding, for every predefined element type and a user-defined
+// type, and for every storage format supported by export/import and pack/unpack.
+// Each target decodes its random input into a set of entries, moves these entries
+// into GraphBLAS and back out again, and checks that nothing was lost or changed
+// on the way.
+
+// udt is a user-defined element type without padding bytes.
+type udt struct {
+	A int32
+	B uint32
+}
+
+var udtOnce sync.Once
+
+func registerUDT(t *testing.T) {
+	udtOnce.Do(func() {
+		if _, err := GrB.TypeNew[udt](int(unsafe.Sizeof(udt{}))); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+type fuzzKernel int
+
+const (
+	kernelMatrixBuild fuzzKernel = iota
+	kernelVectorBuild
+	kernelMatrixExportImport
+	kernelMatrixPack
+	kernelVectorPack
+	kernelIterators
+)
+
+func runKernel[D any](t *testing.T, kernel fuzzKernel, nrows, ncols int, data []byte) {
+	switch kernel {
+	case kernelMatrixBuild:
+		fuzzMatrixBuild[D](t, nrows, ncols, data)
+	case kernelVectorBuild:
+		fuzzVectorBuild[D](t, nrows, data)
+	case kernelMatrixExportImport:
+		fuzzMatrixExportImport[D](t, nrows, ncols, data)
+	case kernelMatrixPack:
+		fuzzMatrixPack[D](t, nrows, ncols, data)
+	case kernelVectorPack:
+		fuzzVectorPack[D](t, nrows, data)
+	case kernelIterators:
+		fuzzIterators[D](t, nrows, ncols, data)
+	}
+}
+
+func runAllTypes(t *testing.T, kernel fuzzKernel, nrows, ncols uint8, data []byte) {
+	registerUDT(t)
+	m, n := int(nrows%32)+1, int(ncols%32)+1
+	t.Run("bool", func(t *testing.T) { runKernel[bool](t, kernel, m, n, data) })
+	t.Run("int", func(t *testing.T) { runKernel[int](t, kernel, m, n, data) })
+	t.Run("int8", func(t *testing.T) { runKernel[int8](t, kernel, m, n, data) })
+	t.Run("int16", func(t *testing.T) { runKernel[int16](t, kernel, m, n, data) })
+	t.Run("int32", func(t *testing.T) { runKernel[int32](t, kernel, m, n, data) })
+	t.Run("int64", func(t *testing.T) { runKernel[int64](t, kernel, m, n, data) })
+	t.Run("uint", func(t *testing.T) { runKernel[uint](t, kernel, m, n, data) })
+	t.Run("uint8", func(t *testing.T) { runKernel[uint8](t, kernel, m, n, data) })
+	t.Run("uint16", func(t *testing.T) { runKernel[uint16](t, kernel, m, n, data) })
+	t.Run("uint32", func(t *testing.T) { runKernel[uint32](t, kernel, m, n, data) })
+	t.Run("uint64", func(t *testing.T) { runKernel[uint64](t, kernel, m, n, data) })
+	t.Run("float32", func(t *testing.T) { runKernel[float32](t, kernel, m, n, data) })
+	t.Run("float64", func(t *testing.T) { runKernel[float64](t, kernel, m, n, data) })
+	t.Run("complex64", func(t *testing.T) { runKernel[complex64](t, kernel, m, n, data) })
+	t.Run("complex128", func(t *testing.T) { runKernel[complex128](t, kernel, m, n, data) })
+	t.Run("udt", func(t *testing.T) { runKernel[udt](t, kernel, m, n, data) })
+}
+
+func addSeeds(f *testing.F) {
+	f.Add(uint8(0), uint8(0), []byte{})
+	f.Add(uint8(4), uint8(5), []byte{0, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17})
+	f.Add(uint8(9), uint8(2), []byte{1, 1, 0xff, 0xfe, 0x7f, 0x80, 0, 1, 2, 2, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42})
+	f.Add(uint8(31), uint8(31), bytes.Repeat([]byte{3, 7, 0x55, 0xaa, 0x0f, 0xf0}, 40))
+	f.Add(uint8(1), uint8(200), bytes.Repeat([]byte{0x80}, 100))
+}
+
+func FuzzMatrixBuild(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, nrows, ncols uint8, data []byte) {
+		runAllTypes(t, kernelMatrixBuild, nrows, ncols, data)
+	})
+}
+
+func FuzzVectorBuild(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, size, _ uint8, data []byte) {
+		runAllTypes(t, kernelVectorBuild, size, 0, data)
+	})
+}
+
+func FuzzMatrixExportImport(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, nrows, ncols uint8, data []byte) {
+		runAllTypes(t, kernelMatrixExportImport, nrows, ncols, data)
+	})
+}
+
+func FuzzMatrixPack(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, nrows, ncols uint8, data []byte) {
+		runAllTypes(t, kernelMatrixPack, nrows, ncols, data)
+	})
+}
+
+func FuzzVectorPack(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, size, _ uint8, data []byte) {
+		runAllTypes(t, kernelVectorPack, size, 0, data)
+	})
+}
+
+func FuzzIterators(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, nrows, ncols uint8, data []byte) {
+		runAllTypes(t, kernelIterators, nrows, ncols, data)
+	})
+}
+
+// decoding of random input
+
+// decode interprets the first bytes of b as a value of type D.
+// Missing bytes are treated as zero.
+func decode[D any](b []byte) (d D) {
+	if p, ok := any(&d).(*bool); ok {
+		*p = len(b) > 0 && b[0]&1 != 0
+		return
+	}
+	copy(unsafe.Slice((*byte)(unsafe.Pointer(&d)), unsafe.Sizeof(d)), b)
+	return
+}
+
+// sameBits compares two values bit by bit, so that NaNs compare equal.
+func sameBits[D any](x, y D) bool {
+	return bytes.Equal(
+		unsafe.Slice((*byte)(unsafe.Pointer(&x)), unsafe.Sizeof(x)),
+		unsafe.Slice((*byte)(unsafe.Pointer(&y)), unsafe.Sizeof(y)),
+	)
+}
+
+// tuples holds the entries of a matrix or vector in row-major order,
+// without duplicates. For vectors, cols is nil.
+type tuples[D any] struct {
+	rows, cols []int
+	vals       []D
+}
+
+func (tp *tuples[D]) add(i, j int, x D) {
+	tp.rows = append(tp.rows, i)
+	tp.cols = append(tp.cols, j)
+	tp.vals = append(tp.vals, x)
+}
+
+// sorted returns a copy of tp in row-major order.
+func (tp tuples[D]) sorted() (result tuples[D]) {
+	perm := make([]int, len(tp.vals))
+	for k := range perm {
+		perm[k] = k
+	}
+	slices.SortFunc(perm, func(a, b int) int {
+		if tp.rows[a] != tp.rows[b] {
+			return tp.rows[a] - tp.rows[b]
+		}
+		if tp.cols == nil {
+			return 0
+		}
+		return tp.cols[a] - tp.cols[b]
+	})
+	for _, k := range perm {
+		result.rows = append(result.rows, tp.rows[k])
+		if tp.cols != nil {
+			result.cols = append(result.cols, tp.cols[k])
+		}
+		result.vals = append(result.vals, tp.vals[k])
+	}
+	return
+}
+
+func (tp tuples[D]) check(t *testing.T, what string, want tuples[D]) {
+	t.Helper()
+	got := tp.sorted()
+	if len(got.vals) != len(want.vals) || len(got.rows) != len(want.rows) || len(got.cols) != len(want.cols) {
+		t.Fatalf("%v: got %v entries, want %v", what, len(got.vals), len(want.vals))
+	}
+	for k := range want.vals {
+		if got.rows[k] != want.rows[k] || (want.cols != nil && got.cols[k] != want.cols[k]) || !sameBits(got.vals[k], want.vals[k]) {
+			if want.cols == nil {
+				t.Fatalf("%v: entry %v: got (%v) = %v, want (%v) = %v", what, k,
+					got.rows[k], got.vals[k], want.rows[k], want.vals[k])
+			}
+			t.Fatalf("%v: entry %v: got (%v, %v) = %v, want (%v, %v) = %v", what, k,
+				got.rows[k], got.cols[k], got.vals[k], want.rows[k], want.cols[k], want.vals[k])
+		}
+	}
+}
+
+// matrixTuples decodes data into entries of an nrows x ncols matrix.
+// Each entry takes two bytes for its position, followed by the bytes of its value.
+// Later entries for the same position replace earlier ones.
+func matrixTuples[D any](nrows, ncols int, data []byte) (tp tuples[D]) {
+	var d D
+	step := 2 + int(unsafe.Sizeof(d))
+	entries := make(map[[2]int]D)
+	for len(data) >= 2 {
+		end := min(step, len(data))
+		entries[[2]int{int(data[0]) % nrows, int(data[1]) % ncols}] = decode[D](data[2:end])
+		data = data[end:]
+	}
+	for i := 0; i < nrows; i++ {
+		for j := 0; j < ncols; j++ {
+			if x, ok := entries[[2]int{i, j}]; ok {
+				tp.add(i, j, x)
+			}
+		}
+	}
+	return
+}
+
+// denseMatrixTuples decodes data into the values of a full nrows x ncols matrix.
+func denseMatrixTuples[D any](nrows, ncols int, data []byte) (tp tuples[D]) {
+	var d D
+	size := int(unsafe.Sizeof(d))
+	for i := 0; i < nrows; i++ {
+		for j := 0; j < ncols; j++ {
+			var x D
+			if p := (i*ncols + j) * size; p < len(data) {
+				x = decode[D](data[p:])
+			}
+			tp.add(i, j, x)
+		}
+	}
+	return
+}
+
+// vectorTuples decodes data into entries of a vector of the given size.
+func vectorTuples[D any](size int, data []byte) (tp tuples[D]) {
+	mt := matrixTuples[D](size, 1, data)
+	return tuples[D]{rows: mt.rows, vals: mt.vals}
+}
+
+func denseVectorTuples[D any](size int, data []byte) (tp tuples[D]) {
+	mt := denseMatrixTuples[D](size, 1, data)
+	return tuples[D]{rows: mt.rows, vals: mt.vals}
+}
+
+// construction and extraction
+
+func buildMatrix[D any](t *testing.T, nrows, ncols int, tp tuples[D]) GrB.Matrix[D] {
+	t.Helper()
+	a, err := GrB.MatrixNew[D](nrows, ncols)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = a.Free()
+	})
+	if err = a.Build(tp.rows, tp.cols, tp.vals, nil); err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func buildVector[D any](t *testing.T, size int, tp tuples[D]) GrB.Vector[D] {
+	t.Helper()
+	v, err := GrB.VectorNew[D](size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = v.Free()
+	})
+	if err = v.Build(tp.rows, tp.vals, nil); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func newMatrix[D any](t *testing.T, nrows, ncols int) GrB.Matrix[D] {
+	return buildMatrix[D](t, nrows, ncols, tuples[D]{})
+}
+
+func newVector[D any](t *testing.T, size int) GrB.Vector[D] {
+	return buildVector[D](t, size, tuples[D]{})
+}
+
+func matrixContent[D any](t *testing.T, a GrB.Matrix[D]) (tp tuples[D]) {
+	t.Helper()
+	if err := a.ExtractTuples(&tp.rows, &tp.cols, &tp.vals); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func vectorContent[D any](t *testing.T, v GrB.Vector[D]) (tp tuples[D]) {
+	t.Helper()
+	if err := v.ExtractTuples(&tp.rows, &tp.vals); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+// Build, ExtractTuples, ExtractElement
+
+func fuzzMatrixBuild[D any](t *testing.T, nrows, ncols int, data []byte) {
+	want := matrixTuples[D](nrows, ncols, data)
+	a := buildMatrix(t, nrows, ncols, want)
+	if nvals, err := a.Nvals(); err != nil || nvals != len(want.vals) {
+		t.Fatalf("Nvals: got %v, %v, want %v", nvals, err, len(want.vals))
+	}
+	matrixContent(t, a).check(t, "ExtractTuples", want)
+
+	var got tuples[D]
+	for i := 0; i < nrows; i++ {
+		for j := 0; j < ncols; j++ {
+			x, ok, err := a.ExtractElement(i, j)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok {
+				got.add(i, j, x)
+			}
+		}
+	}
+	got.check(t, "ExtractElement", want)
+
+	// SetElement into an empty matrix must produce the same content
+	b := newMatrix[D](t, nrows, ncols)
+	for k, x := range want.vals {
+		if err := b.SetElement(x, want.rows[k], want.cols[k]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	matrixContent(t, b).check(t, "SetElement", want)
+}
+
+func fuzzVectorBuild[D any](t *testing.T, size int, data []byte) {
+	want := vectorTuples[D](size, data)
+	v := buildVector(t, size, want)
+	if nvals, err := v.Nvals(); err != nil || nvals != len(want.vals) {
+		t.Fatalf("Nvals: got %v, %v, want %v", nvals, err, len(want.vals))
+	}
+	vectorContent(t, v).check(t, "ExtractTuples", want)
+
+	var got tuples[D]
+	for i := 0; i < size; i++ {
+		x, ok, err := v.ExtractElement(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok {
+			got.rows = append(got.rows, i)
+			got.vals = append(got.vals, x)
+		}
+	}
+	got.check(t, "ExtractElement", want)
+
+	w := newVector[D](t, size)
+	for k, x := range want.vals {
+		if err := w.SetElement(x, want.rows[k]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	vectorContent(t, w).check(t, "SetElement", want)
+}
+
+// Export, MatrixImport
+
+func fuzzMatrixExportImport[D any](t *testing.T, nrows, ncols int, data []byte) {
+	want := matrixTuples[D](nrows, ncols, data)
+	a := buildMatrix(t, nrows, ncols, want)
+	for _, format := range []GrB.Format{GrB.CsrFormat, GrB.CscFormat, GrB.CooFormat} {
+		indptr, indices, values, err := a.Export(format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var exported tuples[D]
+		switch format {
+		case GrB.CsrFormat:
+			exported = compressedTuples(nrows, indptr, nil, indices, values, false, false)
+		case GrB.CscFormat:
+			exported = compressedTuples(ncols, indptr, nil, indices, values, false, true)
+		case GrB.CooFormat:
+			exported = tuples[D]{rows: indptr, cols: indices, vals: values}
+		}
+		exported.check(t, "Export "+format.String(), want)
+
+		b, err := GrB.MatrixImport(nrows, ncols, indptr, indices, values, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		matrixContent(t, b).check(t, "MatrixImport "+format.String(), want)
+		if err = b.Free(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// compressedTuples decodes (hyper)sparse compressed arrays. If ah is nil,
+// vector k has index k, otherwise it has index ah[k]. Vectors are rows, unless
+// byCol is set.
+func compressedTuples[D any](nvec int, ap, ah, ai []int, ax []D, iso, byCol bool) (tp tuples[D]) {
+	for k := 0; k < nvec; k++ {
+		vec := k
+		if ah != nil {
+			vec = ah[k]
+		}
+		for p := ap[k]; p < ap[k+1]; p++ {
+			x := ax[0]
+			if !iso {
+				x = ax[p]
+			}
+			if byCol {
+				tp.add(ai[p], vec, x)
+			} else {
+				tp.add(vec, ai[p], x)
+			}
+		}
+	}
+	return
+}
+
+// bitmapTuples decodes bitmap or (if ab is nil) full arrays.
+func bitmapTuples[D any](nrows, ncols int, ab []bool, ax []D, iso, byCol bool) (tp tuples[D]) {
+	for i := 0; i < nrows; i++ {
+		for j := 0; j < ncols; j++ {
+			p := i*ncols + j
+			if byCol {
+				p = j*nrows + i
+			}
+			if ab != nil && !ab[p] {
+				continue
+			}
+			x := ax[0]
+			if !iso {
+				x = ax[p]
+			}
+			tp.add(i, j, x)
+		}
+	}
+	return
+}
+
+// pack, unpack
+
+func indexSlice(s GrB.SystemSlice[int]) []int {
+	return s.UnsafeSlice()
+}
+
+func bytesAs[T any](s GrB.SystemSlice[byte]) []T {
+	b := s.UnsafeSlice()
+	var x T
+	if len(b) == 0 {
+		return nil
+	}
+	return unsafe.Slice((*T)(unsafe.Pointer(&b[0])), uintptr(len(b))/unsafe.Sizeof(x))
+}
+
+func bytesAsIndices(s GrB.SystemSlice[byte]) []int {
+	u := bytesAs[uint64](s)
+	result := make([]int, len(u))
+	for i, x := range u {
+		result[i] = int(x)
+	}
+	return result
+}
+
+// matrixRoundTrip builds a matrix from want, calls unpack to unpack it and
+// decode the unpacked arrays, and then calls pack to pack these arrays into
+// a new matrix. Both the decoded arrays and the content of the new matrix
+// must be equal to want.
+func matrixRoundTrip[D any](
+	t *testing.T,
+	name string,
+	nrows, ncols int,
+	want tuples[D],
+	roundTrip func(a, b GrB.Matrix[D]) (unpacked tuples[D], err error),
+) {
+	t.Run(name, func(t *testing.T) {
+		a := buildMatrix(t, nrows, ncols, want)
+		b := newMatrix[D](t, nrows, ncols)
+		unpacked, err := roundTrip(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		unpacked.check(t, "unpacked", want)
+		if nvals, err := a.Nvals(); err != nil || nvals != 0 {
+			t.Fatalf("unpacked matrix has %v entries (%v)", nvals, err)
+		}
+		matrixContent(t, b).check(t, "packed", want)
+	})
+}
+
+func fuzzMatrixPack[D any](t *testing.T, nrows, ncols int, data []byte) {
+	sparse := matrixTuples[D](nrows, ncols, data)
+	dense := denseMatrixTuples[D](nrows, ncols, data)
+
+	matrixRoundTrip(t, "CSR", nrows, ncols, sparse, func(a, b GrB.Matrix[D]) (tp tuples[D], err error) {
+		ap, aj, ax, iso, jumbled, err := a.UnpackCSR(false, nil)
+		if err != nil || jumbled {
+			return
+		}
+		tp = compressedTuples(nrows, indexSlice(ap), nil, indexSlice(aj), ax.UnsafeSlice(), iso, false)
+		err = b.PackCSR(&ap, &aj, &ax, iso, false, nil)
+		return
+	})
+	matrixRoundTrip(t, "CSC", nrows, ncols, sparse, func(a, b GrB.Matrix[D]) (tp tuples[D], err error) {
+		ap, ai, ax, iso, jumbled, err := a.UnpackCSC(false, nil)
+		if err != nil || jumbled {
+			return
+		}
+		tp = compressedTuples(ncols, indexSlice(ap), nil, indexSlice(ai), ax.UnsafeSlice(), iso, true)
+		err = b.PackCSC(&ap, &ai, &ax, iso, false, nil)
+		return
+	})
+	matrixRoundTrip(t, "HyperCSR", nrows, ncols, sparse, func(a, b GrB.Matrix[D]) (tp tuples[D], err error) {
+		ap, ah, aj, ax, iso, nvec, jumbled, err := a.UnpackHyperCSR(false, nil)
+		if err != nil || jumbled {
+			return
+		}
+		tp = compressedTuples(nvec, indexSlice(ap), indexSlice(ah), indexSlice(aj), ax.UnsafeSlice(), iso, false)
+		err = b.PackHyperCSR(&ap, &ah, &aj, &ax, iso, nvec, false, nil)
+		return
+	})
+	matrixRoundTrip(t, "HyperCSC", nrows, ncols, sparse, func(a, b GrB.Matrix[D]) (tp tuples[D], err error) {
+		ap, ah, ai, ax, iso, nvec, jumbled, err := a.UnpackHyperCSC(false, nil)
+		if err != nil || jumbled {
+			return
+		}
+		tp = compressedTuples(nvec, indexSlice(ap), indexSlice(ah), indexSlice(ai), ax.UnsafeSlice(), iso, true)
+		err = b.PackHyperCSC(&ap, &ah, &ai, &ax, iso, nvec, false, nil)
+		return
+	})
+	matrixRoundTrip(t, "BitmapR", nrows, ncols, sparse, func(a, b GrB.Matrix[D]) (tp tuples[D], err error) {
+		ab, ax, iso, nvals, err := a.UnpackBitmapR(nil)
+		if err != nil {
+			return
+		}
+		tp = bitmapTuples(nrows, ncols, ab.UnsafeSlice(), ax.UnsafeSlice(), iso, false)
+		err = b.PackBitmapR(&ab, &ax, iso, nvals, nil)
+		return
+	})
+	matrixRoundTrip(t, "BitmapC", nrows, ncols, sparse, func(a, b GrB.Matrix[D]) (tp tuples[D], err error) {
+		ab, ax, iso, nvals, err := a.UnpackBitmapC(nil)
+		if err != nil {
+			return
+		}
+		tp = bitmapTuples(nrows, ncols, ab.UnsafeSlice(), ax.UnsafeSlice(), iso, true)
+		err = b.PackBitmapC(&ab, &ax, iso, nvals, nil)
+		return
+	})
+	matrixRoundTrip(t, "FullR", nrows, ncols, dense, func(a, b GrB.Matrix[D]) (tp tuples[D], err error) {
+		ax, iso, err := a.UnpackFullR(nil)
+		if err != nil {
+			return
+		}
+		tp = bitmapTuples(nrows, ncols, nil, ax.UnsafeSlice(), iso, false)
+		err = b.PackFullR(&ax, iso, nil)
+		return
+	})
+	matrixRoundTrip(t, "FullC", nrows, ncols, dense, func(a, b GrB.Matrix[D]) (tp tuples[D], err error) {
+		ax, iso, err := a.UnpackFullC(nil)
+		if err != nil {
+			return
+		}
+		tp = bitmapTuples(nrows, ncols, nil, ax.UnsafeSlice(), iso, true)
+		err = b.PackFullC(&ax, iso, nil)
+		return
+	})
+
+	matrixRoundTrip(t, "CSRBytes", nrows, ncols, sparse, func(a, b GrB.Matrix[D]) (tp tuples[D], err error) {
+		ap, aj, ax, iso, jumbled, err := a.UnpackCSRBytes(false, nil)
+		if err != nil || jumbled {
+			return
+		}
+		tp = compressedTuples(nrows, bytesAsIndices(ap), nil, bytesAsIndices(aj), bytesAs[D](ax), iso, false)
+		err = b.PackCSRBytes(&ap, &aj, &ax, iso, false, nil)
+		return
+	})
+	matrixRoundTrip(t, "CSCBytes", nrows, ncols, sparse, func(a, b GrB.Matrix[D]) (tp tuples[D], err error) {
+		ap, ai, ax, iso, jumbled, err := a.UnpackCSCBytes(false, nil)
+		if err != nil || jumbled {
+			return
+		}
+		tp = compressedTuples(ncols, bytesAsIndices(ap), nil, bytesAsIndices(ai), bytesAs[D](ax), iso, true)
+		err = b.PackCSCBytes(&ap, &ai, &ax, iso, false, nil)
+		return
+	})
+	matrixRoundTrip(t, "HyperCSRBytes", nrows, ncols, sparse, func(a, b GrB.Matrix[D]) (tp tuples[D], err error) {
+		ap, ah, aj, ax, iso, nvec, jumbled, err := a.UnpackHyperCSRBytes(false, nil)
+		if err != nil || jumbled {
+			return
+		}
+		tp = compressedTuples(nvec, bytesAsIndices(ap), bytesAsIndices(ah), bytesAsIndices(aj), bytesAs[D](ax), iso, false)
+		err = b.PackHyperCSRBytes(&ap, &ah, &aj, &ax, iso, nvec, false, nil)
+		return
+	})
+	matrixRoundTrip(t, "HyperCSCBytes", nrows, ncols, sparse, func(a, b GrB.Matrix[D]) (tp tuples[D], err error) {
+		ap, ah, ai, ax, iso, nvec, jumbled, err := a.UnpackHyperCSCBytes(false, nil)
+		if err != nil || jumbled {
+			return
+		}
+		tp = compressedTuples(nvec, bytesAsIndices(ap), bytesAsIndices(ah), bytesAsIndices(ai), bytesAs[D](ax), iso, true)
+		err = b.PackHyperCSCBytes(&ap, &ah, &ai, &ax, iso, nvec, false, nil)
+		return
+	})
+	matrixRoundTrip(t, "BitmapRBytes", nrows, ncols, sparse, func(a, b GrB.Matrix[D]) (tp tuples[D], err error) {
+		ab, ax, iso, nvals, err := a.UnpackBitmapRBytes(nil)
+		if err != nil {
+			return
+		}
+		tp = bitmapTuples(nrows, ncols, bytesAs[bool](ab), bytesAs[D](ax), iso, false)
+		err = b.PackBitmapRBytes(&ab, &ax, iso, nvals, nil)
+		return
+	})
+	matrixRoundTrip(t, "BitmapCBytes", nrows, ncols, sparse, func(a, b GrB.Matrix[D]) (tp tuples[D], err error) {
+		ab, ax, iso, nvals, err := a.UnpackBitmapCBytes(nil)
+		if err != nil {
+			return
+		}
+		tp = bitmapTuples(nrows, ncols, bytesAs[bool](ab), bytesAs[D](ax), iso, true)
+		err = b.PackBitmapCBytes(&ab, &ax, iso, nvals, nil)
+		return
+	})
+	matrixRoundTrip(t, "FullRBytes", nrows, ncols, dense, func(a, b GrB.Matrix[D]) (tp tuples[D], err error) {
+		ax, iso, err := a.UnpackFullRBytes(nil)
+		if err != nil {
+			return
+		}
+		tp = bitmapTuples(nrows, ncols, nil, bytesAs[D](ax), iso, false)
+		err = b.PackFullRBytes(&ax, iso, nil)
+		return
+	})
+	matrixRoundTrip(t, "FullCBytes", nrows, ncols, dense, func(a, b GrB.Matrix[D]) (tp tuples[D], err error) {
+		ax, iso, err := a.UnpackFullCBytes(nil)
+		if err != nil {
+			return
+		}
+		tp = bitmapTuples(nrows, ncols, nil, bytesAs[D](ax), iso, true)
+		err = b.PackFullCBytes(&ax, iso, nil)
+		return
+	})
+}
+
+func vectorRoundTrip[D any](
+	t *testing.T,
+	name string,
+	size int,
+	want tuples[D],
+	roundTrip func(u, v GrB.Vector[D]) (unpacked tuples[D], err error),
+) {
+	t.Run(name, func(t *testing.T) {
+		u := buildVector(t, size, want)
+		v := newVector[D](t, size)
+		unpacked, err := roundTrip(u, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		unpacked.check(t, "unpacked", want)
+		if nvals, err := u.Nvals(); err != nil || nvals != 0 {
+			t.Fatalf("unpacked vector has %v entries (%v)", nvals, err)
+		}
+		vectorContent(t, v).check(t, "packed", want)
+	})
+}
+
+// asVectorTuples drops the column indices of n x 1 matrix tuples.
+func asVectorTuples[D any](tp tuples[D]) tuples[D] {
+	return tuples[D]{rows: tp.rows, vals: tp.vals}
+}
+
+func fuzzVectorPack[D any](t *testing.T, size int, data []byte) {
+	sparse := vectorTuples[D](size, data)
+	dense := denseVectorTuples[D](size, data)
+	vectorRoundTrip(t, "CSC", size, sparse, func(u, v GrB.Vector[D]) (tp tuples[D], err error) {
+		vi, vx, iso, nvals, jumbled, err := u.UnpackCSC(false, nil)
+		if err != nil || jumbled {
+			return
+		}
+		tp = asVectorTuples(compressedTuples(1, []int{0, nvals}, nil, indexSlice(vi), vx.UnsafeSlice(), iso, true))
+		err = v.PackCSC(&vi, &vx, iso, nvals, false, nil)
+		return
+	})
+	vectorRoundTrip(t, "Bitmap", size, sparse, func(u, v GrB.Vector[D]) (tp tuples[D], err error) {
+		vb, vx, iso, nvals, err := u.UnpackBitmap(nil)
+		if err != nil {
+			return
+		}
+		tp = asVectorTuples(bitmapTuples(size, 1, vb.UnsafeSlice(), vx.UnsafeSlice(), iso, true))
+		err = v.PackBitmap(&vb, &vx, iso, nvals, nil)
+		return
+	})
+	vectorRoundTrip(t, "Full", size, dense, func(u, v GrB.Vector[D]) (tp tuples[D], err error) {
+		vx, iso, err := u.UnpackFull(nil)
+		if err != nil {
+			return
+		}
+		tp = asVectorTuples(bitmapTuples(size, 1, nil, vx.UnsafeSlice(), iso, true))
+		err = v.PackFull(&vx, iso, nil)
+		return
+	})
+	vectorRoundTrip(t, "CSCBytes", size, sparse, func(u, v GrB.Vector[D]) (tp tuples[D], err error) {
+		vi, vx, iso, nvals, jumbled, err := u.UnpackCSCBytes(false, nil)
+		if err != nil || jumbled {
+			return
+		}
+		tp = asVectorTuples(compressedTuples(1, []int{0, nvals}, nil, bytesAsIndices(vi), bytesAs[D](vx), iso, true))
+		err = v.PackCSCBytes(&vi, &vx, iso, nvals, false, nil)
+		return
+	})
+	vectorRoundTrip(t, "BitmapBytes", size, sparse, func(u, v GrB.Vector[D]) (tp tuples[D], err error) {
+		vb, vx, iso, nvals, err := u.UnpackBitmapBytes(nil)
+		if err != nil {
+			return
+		}
+		tp = asVectorTuples(bitmapTuples(size, 1, bytesAs[bool](vb), bytesAs[D](vx), iso, true))
+		err = v.PackBitmapBytes(&vb, &vx, iso, nvals, nil)
+		return
+	})
+	vectorRoundTrip(t, "FullBytes", size, dense, func(u, v GrB.Vector[D]) (tp tuples[D], err error) {
+		vx, iso, err := u.UnpackFullBytes(nil)
+		if err != nil {
+			return
+		}
+		tp = asVectorTuples(bitmapTuples(size, 1, nil, bytesAs[D](vx), iso, true))
+		err = v.PackFullBytes(&vx, iso, nil)
+		return
+	})
+}
+
+// iterators
+
+func fuzzIterators[D any](t *testing.T, nrows, ncols int, data []byte) {
+	for _, dense := range []bool{false, true} {
+		want := matrixTuples[D](nrows, ncols, data)
+		if dense {
+			want = denseMatrixTuples[D](nrows, ncols, data)
+		}
+		for _, sparsity := range []GrB.Sparsity{GrB.Hypersparse, GrB.Sparse, GrB.Bitmap, GrB.Full} {
+			for _, layout := range []GrB.Layout{GrB.ByRow, GrB.ByCol} {
+				a := buildMatrix(t, nrows, ncols, want)
+				if err := a.SetLayout(layout); err != nil {
+					t.Fatal(err)
+				}
+				if err := a.SetSparsityControl(sparsity); err != nil {
+					t.Fatal(err)
+				}
+				what := sparsity.String() + "/" + layout.String()
+				entryIteratorContent(t, a).check(t, "EntryIterator "+what, want)
+				if layout == GrB.ByRow {
+					rowIteratorContent(t, a).check(t, "RowIterator "+what, want)
+				} else {
+					colIteratorContent(t, a).check(t, "ColIterator "+what, want)
+				}
+			}
+		}
+
+		vwant := vectorTuples[D](nrows, data)
+		if dense {
+			vwant = denseVectorTuples[D](nrows, data)
+		}
+		for _, sparsity := range []GrB.Sparsity{GrB.Sparse, GrB.Bitmap, GrB.Full} {
+			v := buildVector(t, nrows, vwant)
+			if err := v.SetSparsityControl(sparsity); err != nil {
+				t.Fatal(err)
+			}
+			vectorIteratorContent(t, v).check(t, "VectorIterator "+sparsity.String(), vwant)
+		}
+	}
+}
+
+func entryIteratorContent[D any](t *testing.T, a GrB.Matrix[D]) (tp tuples[D]) {
+	t.Helper()
+	it, err := a.IteratorNew(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = it.Free()
+	}()
+	ok, err := it.Seek(0)
+	for ; ok && err == nil; ok, err = it.Next() {
+		i, j := it.GetIndex()
+		tp.add(i, j, it.Get())
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func rowIteratorContent[D any](t *testing.T, a GrB.Matrix[D]) (tp tuples[D]) {
+	t.Helper()
+	it, err := a.RowIteratorNew(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = it.Free()
+	}()
+	ok, exhausted, err := it.SeekRow(0)
+	for err == nil && !exhausted {
+		for ; ok && err == nil; ok, err = it.NextCol() {
+			tp.add(it.GetRowIndex(), it.GetColIndex(), it.Get())
+		}
+		if err == nil {
+			ok, exhausted, err = it.NextRow()
+		}
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func colIteratorContent[D any](t *testing.T, a GrB.Matrix[D]) (tp tuples[D]) {
+	t.Helper()
+	it, err := a.ColIteratorNew(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = it.Free()
+	}()
+	ok, exhausted, err := it.SeekCol(0)
+	for err == nil && !exhausted {
+		for ; ok && err == nil; ok, err = it.NextRow() {
+			tp.add(it.GetRowIndex(), it.GetColIndex(), it.Get())
+		}
+		if err == nil {
+			ok, exhausted, err = it.NextCol()
+		}
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func vectorIteratorContent[D any](t *testing.T, v GrB.Vector[D]) (tp tuples[D]) {
+	t.Helper()
+	it, err := v.IteratorNew(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = it.Free()
+	}()
+	ok, err := it.Seek(0)
+	for ; ok && err == nil; ok, err = it.Next() {
+		tp.rows = append(tp.rows, it.GetIndex())
+		tp.vals = append(tp.vals, it.Get())
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
@@ -0,0 +1,83 @@
+package graphstore
+
+import "github.com/intel/forGraphBLASGo/GrB"
+
+// setAttribute stores the value in the named column of columns, creating the column if necessary.
+func setAttribute(columns map[string]map[int]any, name string, id int, value any) {
+	column, ok := columns[name]
+	if !ok {
+		column = make(map[int]any)
+		columns[name] = column
+	}
+	column[id] = value
+}
+
+// SetNodeAttribute sets the named attribute of the node with the given ID to value.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidIndex]: id is not the ID of a node in the graph.
+func (graph *Graph) SetNodeAttribute(id int, name string, value any) error {
+	if !graph.nodes.valid(id) {
+		return GrB.InvalidIndex
+	}
+	setAttribute(graph.nodeAttrs, name, id, value)
+	return nil
+}
+
+// NodeAttribute returns the named attribute of the node with the given ID,
+// and whether the node has that attribute.
+func (graph *Graph) NodeAttribute(id int, name string) (value any, ok bool) {
+	value, ok = graph.nodeAttrs[name][id]
+	return
+}
+
+// RemoveNodeAttribute removes the named attribute from the node with the given ID.
+func (graph *Graph) RemoveNodeAttribute(id int, name string) {
+	delete(graph.nodeAttrs[name], id)
+}
+
+// NodeAttributeColumn calls f for each node that has the named attribute, with the ID of
+// the node and the value of the attribute, in unspecified order. The iteration stops if
+// f returns false.
+func (graph *Graph) NodeAttributeColumn(name string, f func(id int, value any) bool) {
+	for id, value := range graph.nodeAttrs[name] {
+		if !f(id, value) {
+			return
+		}
+	}
+}
+
+// SetEdgeAttribute sets the named attribute of the edge with the given ID to value.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidIndex]: id is not the ID of an edge in the graph.
+func (graph *Graph) SetEdgeAttribute(id int, name string, value any) error {
+	if !graph.edges.valid(id) {
+		return GrB.InvalidIndex
+	}
+	setAttribute(graph.edgeAttrs, name, id, value)
+	return nil
+}
+
+// EdgeAttribute returns the named attribute of the edge with the given ID,
+// and whether the edge has that attribute.
+func (graph *Graph) EdgeAttribute(id int, name string) (value any, ok bool) {
+	value, ok = graph.edgeAttrs[name][id]
+	return
+}
+
+// RemoveEdgeAttribute removes the named attribute from the edge with the given ID.
+func (graph *Graph) RemoveEdgeAttribute(id int, name string) {
+	delete(graph.edgeAttrs[name], id)
+}
+
+// EdgeAttributeColumn calls f for each edge that has the named attribute, with the ID of
+// the edge and the value of the attribute, in unspecified order. The iteration stops if
+// f returns false.
+func (graph *Graph) EdgeAttributeColumn(name string, f func(id int, value any) bool) {
+	for id, value := range graph.edgeAttrs[name] {
+		if !f(id, value) {
+			return
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package graphstore_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"github.com/intel/forGraphBLASGo/GrB/graphstore"
+	"testing"
+)
+
+func Example() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	g, err := graphstore.New(2)
+	OK(err)
+	defer func() {
+		OK(g.Free())
+	}()
+
+	people, err := g.CreateNodes(4, "Person")
+	OK(err)
+	cities, err := g.CreateNodes(2, "City")
+	OK(err)
+	for i, name := range []string{"Alice", "Bob", "Carol", "Dave"} {
+		OK(g.SetNodeAttribute(people[i], "name", name))
+	}
+	for i, name := range []string{"Berlin", "Paris"} {
+		OK(g.SetNodeAttribute(cities[i], "name", name))
+	}
+	fmt.Println(people, cities, g.Capacity())
+
+	knows, err := g.CreateEdges("KNOWS", []graphstore.Edge{
+		{people[0], people[1]}, {people[0], people[2]}, {people[1], people[2]},
+		{people[2], people[3]}, {people[0], people[1]},
+	})
+	OK(err)
+	OK(g.SetEdgeAttribute(knows[0], "since", 2015))
+	_, err = g.CreateEdges("LIVES_IN", []graphstore.Edge{
+		{people[0], cities[0]}, {people[1], cities[0]}, {people[2], cities[1]}, {people[3], cities[1]},
+	})
+	OK(err)
+	fmt.Println(knows, g.EdgeCount(), g.Relations(), g.Labels())
+
+	// the people who live in a city where somebody lives whom Alice knows
+	query := func() {
+		K, _ := g.Relation("KNOWS")
+		L, _ := g.Relation("LIVES_IN")
+		persons, _ := g.Label("Person")
+		q, err := GrB.VectorNew[bool](g.Capacity())
+		OK(err)
+		defer func() {
+			OK(q.Free())
+		}()
+		OK(q.SetElement(true, people[0]))
+		OK(GrB.VxM(q, nil, nil, GrB.LorLandSemiringBool, q, K, nil))
+		OK(GrB.VxM(q, nil, nil, GrB.LorLandSemiringBool, q, L, nil))
+		OK(GrB.MxV(q, persons.AsMask(), nil, GrB.LorLandSemiringBool, L, q, GrB.DescRS))
+		var ids []int
+		OK(q.ExtractTuples(&ids, nil))
+		var names []any
+		for _, id := range ids {
+			name, _ := g.NodeAttribute(id, "name")
+			names = append(names, name)
+		}
+		fmt.Println(names)
+	}
+	query()
+
+	// Bob moves away, and Erin moves to Berlin
+	OK(g.DeleteNodes([]int{people[1]}))
+	fmt.Println(g.NodeCount(), g.EdgeCount())
+	erin, err := g.CreateNodes(1, "Person")
+	OK(err)
+	OK(g.SetNodeAttribute(erin[0], "name", "Erin"))
+	_, err = g.CreateEdges("KNOWS", []graphstore.Edge{{people[0], erin[0]}})
+	OK(err)
+	_, err = g.CreateEdges("LIVES_IN", []graphstore.Edge{{erin[0], cities[0]}})
+	OK(err)
+	fmt.Println(erin, g.EdgeCount())
+	query()
+
+	// Alice forgets about Carol
+	OK(g.DeleteEdges("KNOWS", []graphstore.Edge{{people[0], people[2]}}))
+	query()
+	since, ok := g.EdgeAttribute(knows[0], "since")
+	fmt.Println(since, ok)
+
+	// Output:
+	// [0 1 2 3] [4 5] 8
+	// [0 1 2 3 0] 8 [KNOWS LIVES_IN] [City Person]
+	// [Alice Bob Carol Dave]
+	// 5 5
+	// [1] 7
+	// [Alice Erin Carol Dave]
+	// [Alice Erin]
+	// <nil> false
+}
@@ -0,0 +1,520 @@
+/*
+Package graphstore provides a property graph on top of GraphBLAS matrices and vectors, in the
+style of RedisGraph.
+
+Nodes and edges are identified by integer IDs, which are allocated by the [Graph], and reused,
+smallest first, after they are deleted. Each node label is represented by a boolean vector that
+holds the IDs of the nodes with that label, and each relationship type is represented by a
+boolean adjacency matrix with an entry (src, dst) for each edge of that type from node src to node
+dst. There is at most one edge of a given relationship type between two nodes. The vectors and
+matrices all have the same size, the capacity of the graph, which is grown with
+[GrB.Vector.Resize] and [GrB.Matrix.Resize] when more node IDs are needed. Attributes of nodes
+and edges are stored in columns, one for each attribute name, which are keyed by node or edge
+IDs.
+
+Edges are inserted and deleted in batches: the edges of a batch are collected in a boolean mask
+with [GrB.Matrix.Build], and the mask is then applied to the adjacency matrix with
+[GrB.MatrixAssignConstant] or [GrB.MatrixAssign]. Labels are added to and removed from nodes in
+the same way.
+
+The vectors and matrices returned by [Graph.Nodes], [Graph.Label], and [Graph.Relation] are owned
+by the graph, and must not be modified or freed. They can be used as operands of GraphBLAS
+operations to query the graph, until the graph is modified next.
+
+A Graph must not be used by several goroutines at the same time.
+
+Package graphstore is a forGraphBLASGo extension, and its API is experimental.
+*/
+package graphstore
+
+import (
+	"github.com/intel/forGraphBLASGo/GrB"
+	"slices"
+)
+
+// An Edge connects the node with ID Src to the node with ID Dst.
+type Edge struct {
+	Src, Dst int
+}
+
+// edgeKey identifies an edge of a relationship type.
+type edgeKey struct {
+	relation string
+	Edge
+}
+
+// A Graph is a property graph with labeled nodes, typed edges, and attributes.
+type Graph struct {
+	capacity  int
+	nodes     idAllocator
+	edges     idAllocator
+	nodeSet   GrB.Vector[bool]
+	labels    map[string]GrB.Vector[bool]
+	relations map[string]GrB.Matrix[bool]
+	edgeIDs   map[edgeKey]int
+	edgeKeys  []edgeKey // indexed by edge IDs
+	nodeAttrs map[string]map[int]any
+	edgeAttrs map[string]map[int]any
+}
+
+// New creates a graph without any nodes, with room for capacity nodes before the vectors
+// and matrices of the graph are resized.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidValue]: capacity is negative.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func New(capacity int) (graph *Graph, err error) {
+	if capacity < 0 {
+		return nil, GrB.InvalidValue
+	}
+	capacity = max(1, capacity)
+	nodeSet, err := GrB.VectorNew[bool](capacity)
+	if err != nil {
+		return
+	}
+	return &Graph{
+		capacity:  capacity,
+		nodeSet:   nodeSet,
+		labels:    make(map[string]GrB.Vector[bool]),
+		relations: make(map[string]GrB.Matrix[bool]),
+		edgeIDs:   make(map[edgeKey]int),
+		nodeAttrs: make(map[string]map[int]any),
+		edgeAttrs: make(map[string]map[int]any),
+	}, nil
+}
+
+// Free frees the vectors and matrices of the graph.
+func (graph *Graph) Free() (err error) {
+	record := func(ferr error) {
+		if err == nil {
+			err = ferr
+		}
+	}
+	record(graph.nodeSet.Free())
+	for _, label := range graph.labels {
+		record(label.Free())
+	}
+	for _, relation := range graph.relations {
+		record(relation.Free())
+	}
+	return
+}
+
+// Capacity returns the size of the vectors and matrices of the graph.
+// All node IDs are smaller than the capacity.
+func (graph *Graph) Capacity() int {
+	return graph.capacity
+}
+
+// NodeCount returns the number of nodes in the graph.
+func (graph *Graph) NodeCount() int {
+	return graph.nodes.count
+}
+
+// EdgeCount returns the number of edges in the graph, over all relationship types.
+func (graph *Graph) EdgeCount() int {
+	return graph.edges.count
+}
+
+// Nodes returns a vector with an entry true for the ID of each node in the graph.
+func (graph *Graph) Nodes() GrB.Vector[bool] {
+	return graph.nodeSet
+}
+
+// Label returns the vector with an entry true for the ID of each node with the given label,
+// and whether the label has ever been used.
+func (graph *Graph) Label(label string) (vector GrB.Vector[bool], ok bool) {
+	vector, ok = graph.labels[label]
+	return
+}
+
+// Labels returns the labels that have been used in the graph, in sorted order.
+func (graph *Graph) Labels() []string {
+	return sortedKeys(graph.labels)
+}
+
+// Relation returns the adjacency matrix of the given relationship type, and whether
+// the relationship type has ever been used.
+func (graph *Graph) Relation(relation string) (matrix GrB.Matrix[bool], ok bool) {
+	matrix, ok = graph.relations[relation]
+	return
+}
+
+// Relations returns the relationship types that have been used in the graph, in sorted order.
+func (graph *Graph) Relations() []string {
+	return sortedKeys(graph.relations)
+}
+
+// HasNode reports whether id is the ID of a node in the graph.
+func (graph *Graph) HasNode(id int) bool {
+	return graph.nodes.valid(id)
+}
+
+// EdgeID returns the ID of the edge of the given relationship type from src to dst,
+// and whether that edge exists.
+func (graph *Graph) EdgeID(relation string, src, dst int) (id int, ok bool) {
+	id, ok = graph.edgeIDs[edgeKey{relation, Edge{src, dst}}]
+	return
+}
+
+// EdgeEnds returns the relationship type and the nodes of the edge with the given ID,
+// and whether that edge exists.
+func (graph *Graph) EdgeEnds(id int) (relation string, edge Edge, ok bool) {
+	if !graph.edges.valid(id) {
+		return
+	}
+	key := graph.edgeKeys[id]
+	return key.relation, key.Edge, true
+}
+
+// reserve ensures that the capacity of the graph is at least n,
+// growing the capacity at least geometrically.
+func (graph *Graph) reserve(n int) (err error) {
+	if n <= graph.capacity {
+		return
+	}
+	capacity := max(n, 2*graph.capacity)
+	if err = graph.nodeSet.Resize(capacity); err != nil {
+		return
+	}
+	for _, label := range graph.labels {
+		if err = label.Resize(capacity); err != nil {
+			return
+		}
+	}
+	for _, relation := range graph.relations {
+		if err = relation.Resize(capacity, capacity); err != nil {
+			return
+		}
+	}
+	graph.capacity = capacity
+	return
+}
+
+// label returns the vector for the given label, creating it if necessary.
+func (graph *Graph) label(label string) (vector GrB.Vector[bool], err error) {
+	vector, ok := graph.labels[label]
+	if ok {
+		return
+	}
+	if vector, err = GrB.VectorNew[bool](graph.capacity); err == nil {
+		graph.labels[label] = vector
+	}
+	return
+}
+
+// relation returns the matrix for the given relationship type, creating it if necessary.
+func (graph *Graph) relation(relation string) (matrix GrB.Matrix[bool], err error) {
+	matrix, ok := graph.relations[relation]
+	if ok {
+		return
+	}
+	if matrix, err = GrB.MatrixNew[bool](graph.capacity, graph.capacity); err == nil {
+		graph.relations[relation] = matrix
+	}
+	return
+}
+
+// checkNodes returns the given node IDs in sorted order without duplicates,
+// or [GrB.InvalidIndex] if one of them is not the ID of a node in the graph.
+func (graph *Graph) checkNodes(ids []int) ([]int, error) {
+	for _, id := range ids {
+		if !graph.nodes.valid(id) {
+			return nil, GrB.InvalidIndex
+		}
+	}
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+	return slices.Compact(ids), nil
+}
+
+// CreateNodes creates count new nodes with the given labels, and returns their IDs.
+// The IDs of deleted nodes are reused, smallest first, before new IDs are allocated.
+// If more IDs are needed than the capacity of the graph allows, the vectors and matrices
+// of the graph are resized.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidValue]: count is negative.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func (graph *Graph) CreateNodes(count int, labels ...string) (ids []int, err error) {
+	if count < 0 {
+		return nil, GrB.InvalidValue
+	}
+	if count == 0 {
+		return
+	}
+	if err = graph.reserve(graph.nodes.limit(count)); err != nil {
+		return
+	}
+	ids = graph.nodes.alloc(count)
+	if err = updateVector(graph.nodeSet, ids, true); err != nil {
+		return
+	}
+	for _, label := range labels {
+		if err = graph.SetLabel(label, ids); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// DeleteNodes deletes the nodes with the given IDs, together with their labels, their
+// attributes, and all edges from or to them. The IDs of the nodes and of the deleted edges
+// become available for reuse.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidIndex]: One of the IDs is not the ID of a node in the graph.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func (graph *Graph) DeleteNodes(ids []int) (err error) {
+	if ids, err = graph.checkNodes(ids); err != nil || len(ids) == 0 {
+		return
+	}
+	for _, relation := range graph.Relations() {
+		var edges []Edge
+		if edges, err = incidentEdges(graph.relations[relation], ids); err != nil {
+			return
+		}
+		if err = graph.deleteEdges(relation, edges); err != nil {
+			return
+		}
+	}
+	for _, label := range graph.labels {
+		if err = updateVector(label, ids, false); err != nil {
+			return
+		}
+	}
+	if err = updateVector(graph.nodeSet, ids, false); err != nil {
+		return
+	}
+	for _, column := range graph.nodeAttrs {
+		for _, id := range ids {
+			delete(column, id)
+		}
+	}
+	graph.nodes.release(ids)
+	return
+}
+
+// SetLabel adds the label to the nodes with the given IDs.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidIndex]: One of the IDs is not the ID of a node in the graph.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func (graph *Graph) SetLabel(label string, ids []int) (err error) {
+	if ids, err = graph.checkNodes(ids); err != nil {
+		return
+	}
+	vector, err := graph.label(label)
+	if err != nil {
+		return
+	}
+	return updateVector(vector, ids, true)
+}
+
+// RemoveLabel removes the label from the nodes with the given IDs.
+// Nodes without the label are ignored.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidIndex]: One of the IDs is not the ID of a node in the graph.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func (graph *Graph) RemoveLabel(label string, ids []int) (err error) {
+	if ids, err = graph.checkNodes(ids); err != nil {
+		return
+	}
+	if vector, ok := graph.labels[label]; ok {
+		err = updateVector(vector, ids, false)
+	}
+	return
+}
+
+// CreateEdges creates edges of the given relationship type, and returns their IDs. If an edge
+// of that type already exists between two nodes, or occurs several times in edges, it is
+// created only once, and its ID is returned for each occurrence.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidIndex]: One of the nodes is not in the graph.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func (graph *Graph) CreateEdges(relation string, edges []Edge) (ids []int, err error) {
+	for _, edge := range edges {
+		if !graph.nodes.valid(edge.Src) || !graph.nodes.valid(edge.Dst) {
+			return nil, GrB.InvalidIndex
+		}
+	}
+	matrix, err := graph.relation(relation)
+	if err != nil {
+		return
+	}
+	var created []Edge
+	ids = make([]int, len(edges))
+	for i, edge := range edges {
+		key := edgeKey{relation, edge}
+		id, ok := graph.edgeIDs[key]
+		if !ok {
+			id = graph.edges.alloc(1)[0]
+			if id == len(graph.edgeKeys) {
+				graph.edgeKeys = append(graph.edgeKeys, key)
+			} else {
+				graph.edgeKeys[id] = key
+			}
+			graph.edgeIDs[key] = id
+			created = append(created, edge)
+		}
+		ids[i] = id
+	}
+	err = updateMatrix(matrix, created, true)
+	return
+}
+
+// DeleteEdges deletes edges of the given relationship type, together with their attributes.
+// Edges that do not exist are ignored. The IDs of the deleted edges become available for reuse.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func (graph *Graph) DeleteEdges(relation string, edges []Edge) error {
+	return graph.deleteEdges(relation, edges)
+}
+
+func (graph *Graph) deleteEdges(relation string, edges []Edge) (err error) {
+	matrix, ok := graph.relations[relation]
+	if !ok {
+		return
+	}
+	var deleted []Edge
+	var ids []int
+	for _, edge := range edges {
+		key := edgeKey{relation, edge}
+		if id, ok := graph.edgeIDs[key]; ok {
+			delete(graph.edgeIDs, key)
+			deleted = append(deleted, edge)
+			ids = append(ids, id)
+		}
+	}
+	if err = updateMatrix(matrix, deleted, false); err != nil {
+		return
+	}
+	for _, column := range graph.edgeAttrs {
+		for _, id := range ids {
+			delete(column, id)
+		}
+	}
+	graph.edges.release(ids)
+	return
+}
+
+// incidentEdges returns the entries of matrix in the rows and columns with the
+// given sorted indices, which must not be empty.
+func incidentEdges(matrix GrB.Matrix[bool], indices []int) (edges []Edge, err error) {
+	defer GrB.CheckErrors(&err)
+	n, _, err := matrix.Size()
+	GrB.OK(err)
+	rows, err := GrB.MatrixNew[bool](len(indices), n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(rows.Free())
+	}()
+	GrB.OK(GrB.MatrixExtract(rows, nil, nil, matrix, indices, GrB.All(n), nil))
+	var is, js []int
+	GrB.OK(rows.ExtractTuples(&is, &js, nil))
+	for k := range is {
+		edges = append(edges, Edge{indices[is[k]], js[k]})
+	}
+	cols, err := GrB.MatrixNew[bool](n, len(indices))
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(cols.Free())
+	}()
+	GrB.OK(GrB.MatrixExtract(cols, nil, nil, matrix, GrB.All(n), indices, nil))
+	is, js = is[:0], js[:0]
+	GrB.OK(cols.ExtractTuples(&is, &js, nil))
+	for k := range is {
+		edges = append(edges, Edge{is[k], indices[js[k]]})
+	}
+	return
+}
+
+// updateVector sets the entries of vector at the given distinct indices to true if insert is true,
+// or deletes them otherwise. The indices are collected in a mask with [GrB.Vector.Build], which
+// is then applied to vector with a masked assignment.
+func updateVector(vector GrB.Vector[bool], indices []int, insert bool) (err error) {
+	if len(indices) == 0 {
+		return
+	}
+	defer GrB.CheckErrors(&err)
+	size, err := vector.Size()
+	GrB.OK(err)
+	mask, err := GrB.VectorNew[bool](size)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(mask.Free())
+	}()
+	GrB.OK(mask.Build(indices, trues(len(indices)), nil))
+	if insert {
+		return GrB.VectorAssignConstant(vector, &mask, nil, true, GrB.All(size), GrB.DescS)
+	}
+	empty, err := GrB.VectorNew[bool](size)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(empty.Free())
+	}()
+	return GrB.VectorAssign(vector, &mask, nil, empty, GrB.All(size), GrB.DescS)
+}
+
+// updateMatrix sets the entries of matrix at the given distinct edges to true if insert is true,
+// or deletes them otherwise. The edges are collected in a mask with [GrB.Matrix.Build], which
+// is then applied to matrix with a masked assignment.
+func updateMatrix(matrix GrB.Matrix[bool], edges []Edge, insert bool) (err error) {
+	if len(edges) == 0 {
+		return
+	}
+	defer GrB.CheckErrors(&err)
+	nrows, ncols, err := matrix.Size()
+	GrB.OK(err)
+	mask, err := GrB.MatrixNew[bool](nrows, ncols)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(mask.Free())
+	}()
+	srcs, dsts := make([]int, len(edges)), make([]int, len(edges))
+	for i, edge := range edges {
+		srcs[i], dsts[i] = edge.Src, edge.Dst
+	}
+	GrB.OK(mask.Build(srcs, dsts, trues(len(edges)), nil))
+	if insert {
+		return GrB.MatrixAssignConstant(matrix, &mask, nil, true, GrB.All(nrows), GrB.All(ncols), GrB.DescS)
+	}
+	empty, err := GrB.MatrixNew[bool](nrows, ncols)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(empty.Free())
+	}()
+	return GrB.MatrixAssign(matrix, &mask, nil, empty, GrB.All(nrows), GrB.All(ncols), GrB.DescS)
+}
+
+func trues(n int) []bool {
+	values := make([]bool, n)
+	for i := range values {
+		values[i] = true
+	}
+	return values
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
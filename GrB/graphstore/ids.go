@@ -0,0 +1,49 @@
+package graphstore
+
+import "slices"
+
+// idAllocator allocates non-negative IDs. IDs that are released are reused,
+// smallest first, before new IDs are allocated.
+type idAllocator struct {
+	used  []bool // indexed by IDs
+	free  []int  // released IDs, in descending order
+	count int    // number of IDs in use
+}
+
+// valid reports whether id is in use.
+func (a *idAllocator) valid(id int) bool {
+	return id >= 0 && id < len(a.used) && a.used[id]
+}
+
+// limit returns the smallest bound for all IDs in use after n more IDs are allocated.
+func (a *idAllocator) limit(n int) int {
+	return len(a.used) + max(0, n-len(a.free))
+}
+
+// alloc allocates n IDs.
+func (a *idAllocator) alloc(n int) []int {
+	ids := make([]int, n)
+	for i := range ids {
+		if k := len(a.free); k > 0 {
+			ids[i] = a.free[k-1]
+			a.free = a.free[:k-1]
+			a.used[ids[i]] = true
+		} else {
+			ids[i] = len(a.used)
+			a.used = append(a.used, true)
+		}
+	}
+	a.count += n
+	return ids
+}
+
+// release releases the given distinct IDs, which must be in use.
+func (a *idAllocator) release(ids []int) {
+	for _, id := range ids {
+		a.used[id] = false
+	}
+	a.count -= len(ids)
+	a.free = append(a.free, ids...)
+	slices.Sort(a.free)
+	slices.Reverse(a.free)
+}
@@ -4,5 +4,19 @@ GraphBLAS specification, for the Go programming language.
 
 The examples shown below currently do not run in the Go Playground. However, they do run when you copy them locally,
 or when you perform a "go test" from the GrB package.
+
+# Known issues
+
+The JIT kernels of SuiteSparse:GraphBLAS 8.0.2 compute wrong results when an operation writes in
+place into a vector that is full and iso-valued, for example with an accumulator, and may even
+corrupt the C heap. Such vectors arise easily, for example from [VectorAssignConstant] over all
+indices. Before updating such a vector in place, store a different value in one of its entries and
+then the original value again, so that it is no longer iso-valued, or compute the result into a new
+vector instead. The linalg package does the former for all vectors that it updates in place.
+
+SuiteSparse:GraphBLAS 8.0.2 also fails with [InvalidObject] when deserializing some iso-valued
+matrices without entries, which arise for example when extracting an empty tile from a sparse
+iso-valued matrix. Check [Matrix.Nvals] before serializing, and create such matrices with
+[MatrixNew] instead.
 */
 package GrB
@@ -10,28 +10,6 @@ typedef void * (* user_calloc_function  ) (size_t, size_t);
 typedef void * (* user_realloc_function ) (void *, size_t);
 typedef void   (* user_free_function    ) (void *);
 
-void* user_malloc(user_malloc_function umalloc, size_t size) {
-	if (umalloc != 0) {
-		return umalloc(size);
-	}
-	return malloc(size);
-}
-
-void* user_calloc(user_calloc_function ucalloc, size_t num, size_t size) {
-	if (ucalloc != 0) {
-		return ucalloc(num, size);
-	}
-	return calloc(num, size);
-}
-
-void user_free(user_free_function ufree, void* ptr) {
-	if (ufree != 0) {
-		ufree(ptr);
-	} else {
-		free(ptr);
-	}
-}
-
 void assertions() {
 	static_assert(GRB_VERSION==2 && GRB_SUBVERSION==0, "This version of GraphBLAS is not supported.");
 	static_assert(sizeof(bool) == 1, "The size of the C compiler's bool type is not 1.");
@@ -198,35 +176,6 @@ type (
 	UserFreeFunction    C.user_free_function
 )
 
-var (
-	user_malloc UserMallocFunction = nil
-	user_calloc UserCallocFunction = nil
-	user_free   UserFreeFunction   = nil
-)
-
-/*
-func malloc(size int) unsafe.Pointer {
-	return C.user_malloc(user_malloc, C.size_t(size))
-}
-*/
-
-func calloc(size int) unsafe.Pointer {
-	if user_calloc == nil {
-		return C.memset(C.user_malloc(user_malloc, C.size_t(size)), 0, C.size_t(size))
-	}
-	if user_malloc == nil {
-		panic("inconsistent malloc / calloc")
-	}
-	return C.user_calloc(user_calloc, 1, C.size_t(size))
-}
-
-func free(ptr unsafe.Pointer) {
-	if ptr == nil {
-		return
-	}
-	C.user_free(user_free, ptr)
-}
-
 // Init creates and initializes a GraphBLAS API context. The argument to
 // Init defines the mode for the context. The two available modes are:
 //
@@ -251,7 +200,7 @@ func free(ptr unsafe.Pointer) {
 // GraphBLAS execution errors that may cause a panic:
 //   - [Panic]
 func Init(mode Mode) error {
-	info := Info(C.GrB_init(C.GrB_Mode(mode)))
+	info := initMemory(mode, nil, nil, nil, nil, false, 0)
 	if info == success {
 		return nil
 	}
@@ -270,11 +219,34 @@ func InitWithMalloc(
 	realloc UserReallocFunction,
 	free UserFreeFunction,
 ) error {
-	info := Info(C.GxB_init(C.GrB_Mode(mode), malloc, calloc, realloc, free))
+	info := initMemory(mode, malloc, calloc, realloc, free, false, 0)
+	if info == success {
+		return nil
+	}
+	return makeError(info)
+}
+
+// InitWithMemoryAccounting is identical to [Init], except that it also installs a memory allocator
+// that keeps track of the memory allocated by GraphBLAS, which can be queried with [MemoryStats].
+// The allocator enforces a soft memory budget, so that allocations that would exceed the budget
+// fail with [OutOfMemory] instead of exhausting the memory available to the process. A budget of 0
+// means that there is no limit. The budget can be changed later with [SetMemoryBudget].
+//
+// The allocator uses the C standard library functions for the actual memory management,
+// and adds a small header to each allocation to record its size. Memory allocated before,
+// for example by [MakeSystemSlice], has no such header, so InitWithMemoryAccounting fails
+// with [InvalidValue] as long as any such memory has not been freed.
+//
+// GraphBLAS API errors that may be returned:
+//   - [InvalidValue]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [Panic]
+//
+// InitWithMemoryAccounting is a forGraphBLASGo extension.
+func InitWithMemoryAccounting(mode Mode, budget int) error {
+	info := initMemory(mode, nil, nil, nil, nil, true, budget)
 	if info == success {
-		user_malloc = malloc
-		user_calloc = calloc
-		user_free = free
 		return nil
 	}
 	return makeError(info)
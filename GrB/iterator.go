@@ -36,7 +36,7 @@ func (it *iterator[D]) init() {
 		}
 	case int16:
 		it.getter = func(grb C.GxB_Iterator) (result D) {
-			*(*int16)(unsafe.Pointer(&result)) = int16(C.GxB_Iterator_get_INT32(grb))
+			*(*int16)(unsafe.Pointer(&result)) = int16(C.GxB_Iterator_get_INT16(grb))
 			return
 		}
 	case int32:
@@ -68,7 +68,7 @@ func (it *iterator[D]) init() {
 		}
 	case uint16:
 		it.getter = func(grb C.GxB_Iterator) (result D) {
-			*(*uint16)(unsafe.Pointer(&result)) = uint16(C.GxB_Iterator_get_UINT32(grb))
+			*(*uint16)(unsafe.Pointer(&result)) = uint16(C.GxB_Iterator_get_UINT16(grb))
 			return
 		}
 	case uint32:
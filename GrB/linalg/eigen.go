@@ -0,0 +1,267 @@
+package linalg
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"math"
+	"math/rand"
+)
+
+// EigenOptions control the eigenvalue solvers.
+type EigenOptions struct {
+	// Tolerance is the relative residual at which an iteration stops: the solvers stop when
+	// ‖A x - λ x‖₂ <= Tolerance * |λ| for each computed eigenpair (λ, x) with ‖x‖₂ = 1.
+	// If Tolerance <= 0, 1e-8 is used.
+	Tolerance float64
+
+	// MaxIter is the maximum number of iterations. For [Lanczos], this is the maximum dimension
+	// of the Krylov subspace, and is at most the size of A. If MaxIter <= 0, 1000 is used.
+	MaxIter int
+
+	// Shift is subtracted from the diagonal of A: the solvers work on A - Shift * I, which
+	// has the same eigenvectors as A, but eigenvalues shifted by -Shift. The returned eigenvalues
+	// are those of A. For example, [PowerIteration] with a shift close to the largest eigenvalue
+	// of A finds the smallest eigenvalue of A.
+	Shift float64
+
+	// Smallest selects the smallest instead of the largest eigenvalues for [Lanczos].
+	Smallest bool
+
+	// Seed seeds the pseudo-random start vector of [Lanczos].
+	Seed int64
+}
+
+func (opts EigenOptions) tolerance() float64 {
+	if opts.Tolerance <= 0 {
+		return 1e-8
+	}
+	return opts.Tolerance
+}
+
+func (opts EigenOptions) maxIter() int {
+	if opts.MaxIter <= 0 {
+		return 1000
+	}
+	return opts.MaxIter
+}
+
+// shiftedMxV sets w = (A - shift * I) v.
+func shiftedMxV(w GrB.Vector[float64], A GrB.Matrix[float64], v GrB.Vector[float64], shift float64) {
+	GrB.OK(notIso(w))
+	GrB.OK(GrB.MxV(w, nil, nil, GrB.PlusTimesSemiring[float64](), A, v, nil))
+	if shift != 0 {
+		GrB.OK(Axpy(-shift, v, w))
+	}
+}
+
+// scale sets w = u / norm.
+func scale(w, u GrB.Vector[float64], norm float64) {
+	GrB.OK(notIso(w))
+	GrB.OK(GrB.VectorApplyBinaryOp2nd(w, nil, nil, GrB.Div[float64](), u, norm, nil))
+}
+
+// squareSize returns the size of the square matrix A, or an error if A is not square.
+func squareSize(A GrB.Matrix[float64]) (n int, err error) {
+	nrows, ncols, err := A.Size()
+	if err != nil {
+		return
+	}
+	if nrows != ncols {
+		return 0, GrB.DimensionMismatch
+	}
+	return nrows, nil
+}
+
+// PowerIteration computes the eigenvalue λ of the symmetric matrix A - opts.Shift * I with the
+// largest absolute value, and returns λ + opts.Shift. x is used as the start vector, and is
+// overwritten with the corresponding eigenvector, normalized to ‖x‖₂ = 1. If x has no entries, a
+// vector of ones is used instead. The history of the result holds ‖A x - λ x‖₂ / |λ| after
+// each iteration. Power iteration converges slowly if the two largest absolute eigenvalues are
+// close to each other.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch]: A is not square, or x does not match A.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func PowerIteration(A GrB.Matrix[float64], x GrB.Vector[float64], opts EigenOptions) (lambda float64, result Result, err error) {
+	defer GrB.CheckErrors(&err)
+	n, err := squareSize(A)
+	GrB.OK(err)
+	size, err := x.Size()
+	GrB.OK(err)
+	if size != n {
+		GrB.OK(GrB.DimensionMismatch)
+	}
+	if n == 0 {
+		return
+	}
+	norm, err := Norm2(x)
+	GrB.OK(err)
+	if norm == 0 {
+		GrB.OK(x.Clear())
+		GrB.OK(GrB.VectorAssignConstant(x, nil, nil, 1, GrB.All(n), nil))
+		norm = math.Sqrt(float64(n))
+	}
+	scale(x, x, norm)
+
+	y, err := GrB.VectorNew[float64](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(y.Free())
+	}()
+	r, err := GrB.VectorNew[float64](n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(r.Free())
+	}()
+	tolerance := opts.tolerance()
+	for result.Iterations < opts.maxIter() {
+		result.Iterations++
+		// y = (A - shift * I) x, lambda = x' y, r = y - lambda * x
+		shiftedMxV(y, A, x, opts.Shift)
+		lambda, err = Dot(x, y)
+		GrB.OK(err)
+		GrB.OK(r.Clear())
+		GrB.OK(Axpy(1, y, r))
+		GrB.OK(Axpy(-lambda, x, r))
+		residual, err := Norm2(r)
+		GrB.OK(err)
+		if lambda != 0 {
+			residual /= math.Abs(lambda)
+		}
+		result.History = append(result.History, residual)
+		if result.Converged = residual <= tolerance; result.Converged {
+			break
+		}
+		norm, err = Norm2(y)
+		GrB.OK(err)
+		scale(x, y, norm)
+	}
+	return lambda + opts.Shift, result, nil
+}
+
+// Lanczos computes the k largest eigenvalues of the symmetric matrix A - opts.Shift * I, or the
+// k smallest ones if opts.Smallest is set, with the Lanczos method with full reorthogonalization.
+// The eigenvalues of the tridiagonal matrix that represents A in the Krylov subspace are
+// computed in Go with the implicit QL algorithm.
+//
+// Lanczos returns the eigenvalues plus opts.Shift in descending order, or in ascending order
+// if opts.Smallest is set, and the corresponding eigenvectors, normalized to ‖x‖₂ = 1,
+// which must be freed by the caller. If the Krylov subspace becomes invariant, fewer than k
+// eigenpairs may be returned. The history of the result holds the largest estimated relative
+// residual ‖A x - λ x‖₂ / |λ| of the k eigenpairs after each iteration.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch]: A is not square.
+//   - [GrB.InvalidValue]: k is not in the range [1, size of A].
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func Lanczos(A GrB.Matrix[float64], k int, opts EigenOptions) (values []float64, vectors []GrB.Vector[float64], result Result, err error) {
+	var basis []GrB.Vector[float64]
+	defer func() {
+		for _, v := range basis {
+			if ferr := v.Free(); err == nil {
+				err = ferr
+			}
+		}
+		if err != nil {
+			for _, v := range vectors {
+				_ = v.Free()
+			}
+			values, vectors = nil, nil
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+	n, err := squareSize(A)
+	GrB.OK(err)
+	if k < 1 || k > n {
+		GrB.OK(fmt.Errorf("linalg: %v eigenvalues requested for a matrix of size %v: %w", k, n, GrB.InvalidValue))
+	}
+
+	// a normalized pseudo-random start vector
+	rng := rand.New(rand.NewSource(opts.Seed))
+	indices, start := make([]int, n), make([]float64, n)
+	for i := range indices {
+		indices[i] = i
+		start[i] = rng.Float64() - 0.5
+	}
+	v, err := GrB.VectorNew[float64](n)
+	GrB.OK(err)
+	basis = append(basis, v)
+	GrB.OK(v.Build(indices, start, nil))
+	norm, err := Norm2(v)
+	GrB.OK(err)
+	scale(v, v, norm)
+
+	var alpha, beta []float64
+	var ritzValues []float64
+	var ritzVectors [][]float64
+	var wanted []int
+	tolerance := opts.tolerance()
+	maxDim := min(opts.maxIter(), n)
+	for {
+		result.Iterations++
+		j := len(basis) - 1
+		// w = (A - shift * I) v_j, orthogonalized twice against all basis vectors
+		w, err := GrB.VectorNew[float64](n)
+		GrB.OK(err)
+		basis = append(basis, w)
+		shiftedMxV(w, A, basis[j], opts.Shift)
+		a, err := Dot(basis[j], w)
+		GrB.OK(err)
+		alpha = append(alpha, a)
+		for range 2 {
+			for _, u := range basis[:j+1] {
+				c, err := Dot(u, w)
+				GrB.OK(err)
+				GrB.OK(Axpy(-c, u, w))
+			}
+		}
+		b, err := Norm2(w)
+		GrB.OK(err)
+
+		// Ritz pairs, and their residuals |b * s(j, i)|
+		ritzValues, ritzVectors = tridiagonalEigen(alpha, beta)
+		m := len(alpha)
+		wanted = wanted[:0]
+		for i := range min(k, m) {
+			if opts.Smallest {
+				wanted = append(wanted, i)
+			} else {
+				wanted = append(wanted, m-1-i)
+			}
+		}
+		normT := max(math.Abs(ritzValues[0]), math.Abs(ritzValues[m-1]))
+		residual := 0.0
+		for _, i := range wanted {
+			magnitude := max(math.Abs(ritzValues[i]), normT*tolerance)
+			if magnitude == 0 {
+				magnitude = 1
+			}
+			residual = max(residual, math.Abs(b*ritzVectors[m-1][i])/magnitude)
+		}
+		result.History = append(result.History, residual)
+		// if the Krylov subspace is invariant, the Ritz pairs are exact
+		invariant := b <= normT*1e-14
+		result.Converged = invariant || m >= k && residual <= tolerance
+		if result.Converged || m == maxDim {
+			break
+		}
+		beta = append(beta, b)
+		scale(w, w, b)
+	}
+
+	// Ritz vectors V s
+	for _, i := range wanted {
+		x, err := GrB.VectorNew[float64](n)
+		GrB.OK(err)
+		vectors = append(vectors, x)
+		for j, s := range ritzVectors {
+			GrB.OK(Axpy(s[i], basis[j], x))
+		}
+		values = append(values, ritzValues[i]+opts.Shift)
+	}
+	return
+}
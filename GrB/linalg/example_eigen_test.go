@@ -0,0 +1,103 @@
+package linalg_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"github.com/intel/forGraphBLASGo/GrB/linalg"
+	"math"
+	"testing"
+)
+
+func Example_eigenvalues() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	// the adjacency matrix of a star with 5 leaves has the eigenvalues ±√5 and 0,
+	// so power iteration needs a shift to separate √5 from -√5
+	star, err := GrB.MatrixNew[float64](6, 6)
+	OK(err)
+	defer func() {
+		OK(star.Free())
+	}()
+	OK(star.Build([]int{0, 0, 0, 0, 0, 1, 2, 3, 4, 5}, []int{1, 2, 3, 4, 5, 0, 0, 0, 0, 0}, []float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, nil))
+	x, err := GrB.VectorNew[float64](6)
+	OK(err)
+	defer func() {
+		OK(x.Free())
+	}()
+	lambda, result, err := linalg.PowerIteration(star, x, linalg.EigenOptions{Shift: -1})
+	OK(err)
+	center, _, err := x.ExtractElement(0)
+	OK(err)
+	fmt.Printf("power iteration: λ = %.6f (√5 = %.6f), converged %v, x(0) = %.6f\n", lambda, math.Sqrt(5), result.Converged, center)
+
+	// the Laplacian of a path with n nodes has the eigenvalues 2 - 2 cos(πk / n), k = 0, ..., n-1
+	const n = 100
+	var rows, cols []int
+	var values []float64
+	for i := range n {
+		degree := 0.0
+		for _, j := range []int{i - 1, i + 1} {
+			if j >= 0 && j < n {
+				rows = append(rows, i)
+				cols = append(cols, j)
+				values = append(values, -1)
+				degree++
+			}
+		}
+		rows = append(rows, i)
+		cols = append(cols, i)
+		values = append(values, degree)
+	}
+	L, err := GrB.MatrixNew[float64](n, n)
+	OK(err)
+	defer func() {
+		OK(L.Free())
+	}()
+	OK(L.Build(rows, cols, values, nil))
+	for _, smallest := range []bool{false, true} {
+		eigenvalues, eigenvectors, result, err := linalg.Lanczos(L, 3, linalg.EigenOptions{Smallest: smallest, Seed: 1})
+		OK(err)
+		fmt.Printf("Lanczos, smallest %v: converged %v\n", smallest, result.Converged)
+		for i, eigenvalue := range eigenvalues {
+			k := n - 1 - i
+			if smallest {
+				k = i
+			}
+			// residual ‖L x - λ x‖₂
+			y, err := GrB.VectorNew[float64](n)
+			OK(err)
+			OK(GrB.MxV(y, nil, nil, GrB.PlusTimesSemiring[float64](), L, eigenvectors[i], nil))
+			OK(linalg.Axpy(-eigenvalue, eigenvectors[i], y))
+			residual, err := linalg.Norm2(y)
+			OK(err)
+			// L is positive semidefinite, which avoids printing -0.000000 for rounding errors
+			fmt.Printf("  λ = %.6f, expected %.6f, residual below 1e-6 %v\n", max(eigenvalue, 0), 2-2*math.Cos(math.Pi*float64(k)/n), residual < 1e-6)
+			OK(y.Free())
+			OK(eigenvectors[i].Free())
+		}
+	}
+
+	// Output:
+	// power iteration: λ = 2.236068 (√5 = 2.236068), converged true, x(0) = 0.707107
+	// Lanczos, smallest false: converged true
+	//   λ = 3.999013, expected 3.999013, residual below 1e-6 true
+	//   λ = 3.996053, expected 3.996053, residual below 1e-6 true
+	//   λ = 3.991124, expected 3.991124, residual below 1e-6 true
+	// Lanczos, smallest true: converged true
+	//   λ = 0.000000, expected 0.000000, residual below 1e-6 true
+	//   λ = 0.000987, expected 0.000987, residual below 1e-6 true
+	//   λ = 0.003947, expected 0.003947, residual below 1e-6 true
+}
@@ -0,0 +1,102 @@
+package linalg_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"github.com/intel/forGraphBLASGo/GrB/linalg"
+	"testing"
+)
+
+func Example_norms() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	// A = [ 1 -2  . ]
+	//     [ .  3  . ]
+	//     [-4  .  2 ]
+	A, err := GrB.MatrixNew[float64](3, 3)
+	OK(err)
+	defer func() {
+		OK(A.Free())
+	}()
+	OK(A.Build([]int{0, 0, 1, 2, 2}, []int{0, 1, 1, 0, 2}, []float64{1, -2, 3, -4, 2}, nil))
+	norm1, err := linalg.Norm1(A)
+	OK(err)
+	normInf, err := linalg.NormInf(A)
+	OK(err)
+	normFrobenius, err := linalg.NormFrobenius(A)
+	OK(err)
+	normMax, err := linalg.NormMax(A)
+	OK(err)
+	trace, err := linalg.Trace(A)
+	OK(err)
+	fmt.Printf("norm1 %v, normInf %v, normFrobenius %.4f, normMax %v, trace %v\n", norm1, normInf, normFrobenius, normMax, trace)
+
+	// a complex matrix, and the norms of an empty one
+	Z, err := GrB.MatrixNew[complex128](2, 2)
+	OK(err)
+	defer func() {
+		OK(Z.Free())
+	}()
+	OK(Z.Build([]int{0, 1, 1}, []int{0, 0, 1}, []complex128{3 + 4i, 1i, 2}, nil))
+	norm1, err = linalg.Norm1(Z)
+	OK(err)
+	normMax, err = linalg.NormMax(Z)
+	OK(err)
+	ztrace, err := linalg.Trace(Z)
+	OK(err)
+	fmt.Printf("norm1 %v, normMax %v, trace %v\n", norm1, normMax, ztrace)
+	empty, err := GrB.MatrixNew[float32](2, 3)
+	OK(err)
+	defer func() {
+		OK(empty.Free())
+	}()
+	norm1, err = linalg.Norm1(empty)
+	OK(err)
+	normMax, err = linalg.NormMax(empty)
+	OK(err)
+	fmt.Printf("norm1 %v, normMax %v\n", norm1, normMax)
+
+	// vectors
+	u, err := GrB.VectorNew[complex128](3)
+	OK(err)
+	defer func() {
+		OK(u.Free())
+	}()
+	OK(u.Build([]int{0, 2}, []complex128{1i, 2}, nil))
+	v, err := GrB.VectorNew[complex128](3)
+	OK(err)
+	defer func() {
+		OK(v.Free())
+	}()
+	OK(v.Build([]int{0, 1, 2}, []complex128{1i, 5, 1 + 1i}, nil))
+	dot, err := linalg.Dot(u, v)
+	OK(err)
+	norm2, err := linalg.Norm2(u)
+	OK(err)
+	fmt.Printf("dot %v, norm2 %.4f\n", dot, norm2)
+	OK(linalg.Axpy(2i, u, v))
+	var indices []int
+	var values []complex128
+	OK(v.ExtractTuples(&indices, &values))
+	fmt.Println(indices, values)
+
+	// Output:
+	// norm1 5, normInf 6, normFrobenius 5.8310, normMax 4, trace 6
+	// norm1 6, normMax 5, trace (5+4i)
+	// norm1 0, normMax 0
+	// dot (3+2i), norm2 2.2361
+	// [0 1 2] [(-2+1i) (5+0i) (1+5i)]
+}
@@ -0,0 +1,138 @@
+package linalg_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"github.com/intel/forGraphBLASGo/GrB/linalg"
+	"math"
+	"testing"
+)
+
+func Example() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	// A = L + 0.1 I, with L the Laplacian of a 20 x 20 grid graph, and a nonsymmetric
+	// variant N of A, with additional entries below the diagonal
+	const k = 20
+	const n = k * k
+	var rows, cols []int
+	var values, nvalues []float64
+	add := func(i, j int, value, nvalue float64) {
+		rows = append(rows, i)
+		cols = append(cols, j)
+		values = append(values, value)
+		nvalues = append(nvalues, nvalue)
+	}
+	for x := range k {
+		for y := range k {
+			i := x*k + y
+			degree := 0.0
+			for _, neighbor := range [][2]int{{x - 1, y}, {x + 1, y}, {x, y - 1}, {x, y + 1}} {
+				if neighbor[0] >= 0 && neighbor[0] < k && neighbor[1] >= 0 && neighbor[1] < k {
+					j := neighbor[0]*k + neighbor[1]
+					nvalue := -1.0
+					if j < i {
+						nvalue = -1.5
+					}
+					add(i, j, -1, nvalue)
+					degree++
+				}
+			}
+			add(i, i, degree+0.1, degree+2.1)
+		}
+	}
+	A, err := GrB.MatrixNew[float64](n, n)
+	OK(err)
+	defer func() {
+		OK(A.Free())
+	}()
+	OK(A.Build(rows, cols, values, nil))
+	N, err := GrB.MatrixNew[float64](n, n)
+	OK(err)
+	defer func() {
+		OK(N.Free())
+	}()
+	OK(N.Build(rows, cols, nvalues, nil))
+
+	// b = 1 at the corners of the grid
+	b, err := GrB.VectorNew[float64](n)
+	OK(err)
+	defer func() {
+		OK(b.Free())
+	}()
+	OK(b.Build([]int{0, k - 1, n - k, n - 1}, []float64{1, 1, 1, 1}, nil))
+
+	jacobi, err := linalg.DiagonalPreconditionerNew(A)
+	OK(err)
+	defer func() {
+		OK(jacobi.Free())
+	}()
+
+	// reference solutions
+	type solver func(GrB.Matrix[float64], GrB.Vector[float64], GrB.Vector[float64], linalg.Options) (linalg.Result, error)
+	reference := func(solve solver, A GrB.Matrix[float64]) []float64 {
+		x, err := GrB.VectorNew[float64](n)
+		OK(err)
+		defer func() {
+			OK(x.Free())
+		}()
+		_, err = solve(A, x, b, linalg.Options{Tolerance: 1e-12})
+		OK(err)
+		var values []float64
+		OK(x.ExtractTuples(nil, &values))
+		return values
+	}
+	expected := map[GrB.Matrix[float64]][]float64{
+		A: reference(linalg.CG, A),
+		N: reference(linalg.BiCGSTAB, N),
+	}
+
+	solve := func(name string, solve solver, A GrB.Matrix[float64], opts linalg.Options) {
+		x, err := GrB.VectorNew[float64](n)
+		OK(err)
+		defer func() {
+			OK(x.Free())
+		}()
+		result, err := solve(A, x, b, opts)
+		OK(err)
+		var actual []float64
+		OK(x.ExtractTuples(nil, &actual))
+		errorNorm := 0.0
+		for i, value := range expected[A] {
+			errorNorm = max(errorNorm, math.Abs(actual[i]-value))
+		}
+		fmt.Printf("%v: converged %v, %v iterations, residual below tolerance %v, error below 1e-5 %v\n",
+			name, result.Converged, result.Iterations, result.Residual() <= 1e-8, errorNorm < 1e-5)
+	}
+	solve("CG", linalg.CG, A, linalg.Options{})
+	solve("CG with Jacobi preconditioner", linalg.CG, A, linalg.Options{Preconditioner: jacobi})
+	solve("BiCGSTAB", linalg.BiCGSTAB, A, linalg.Options{})
+	solve("BiCGSTAB, nonsymmetric", linalg.BiCGSTAB, N, linalg.Options{Preconditioner: jacobi})
+	solve("Jacobi", linalg.Jacobi, A, linalg.Options{MaxIter: 5000})
+	solve("Gauss-Seidel", linalg.GaussSeidel, A, linalg.Options{MaxIter: 5000})
+	solve("Gauss-Seidel, nonsymmetric", linalg.GaussSeidel, N, linalg.Options{})
+	solve("Jacobi, 10 iterations", linalg.Jacobi, A, linalg.Options{MaxIter: 10})
+
+	// Output:
+	// CG: converged true, 40 iterations, residual below tolerance true, error below 1e-5 true
+	// CG with Jacobi preconditioner: converged true, 39 iterations, residual below tolerance true, error below 1e-5 true
+	// BiCGSTAB: converged true, 31 iterations, residual below tolerance true, error below 1e-5 true
+	// BiCGSTAB, nonsymmetric: converged true, 19 iterations, residual below tolerance true, error below 1e-5 true
+	// Jacobi: converged true, 618 iterations, residual below tolerance true, error below 1e-5 true
+	// Gauss-Seidel: converged true, 316 iterations, residual below tolerance true, error below 1e-5 true
+	// Gauss-Seidel, nonsymmetric: converged true, 38 iterations, residual below tolerance true, error below 1e-5 true
+	// Jacobi, 10 iterations: converged false, 10 iterations, residual below tolerance false, error below 1e-5 false
+}
@@ -0,0 +1,175 @@
+/*
+Package linalg provides numerical linear algebra on sparse matrices, built from GraphBLAS
+operations: iterative solvers for linear systems A x = b, eigenvalue solvers for symmetric
+matrices, and norms, inner products, and other basic reductions of matrices and vectors.
+
+All solvers work on matrices and vectors of type float64. The solution vector x of the linear
+solvers is used as the initial guess, and is overwritten with the computed solution. Entries
+of x and b that are not present are treated as zeros, and x is full after a solver returns.
+
+The norms and reductions are generic over the [GrB.Float] and [GrB.Complex] domains, and
+norms are always returned as float64.
+
+Vectors that are updated in place, such as x of the solvers and y of [Axpy], may stop being
+iso-valued, to work around a bug of SuiteSparse:GraphBLAS 8.0.2 described in [GrB].
+
+Package linalg is a forGraphBLASGo extension, and its API is experimental.
+*/
+package linalg
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"math"
+)
+
+// Options control the iterative solvers for linear systems.
+type Options struct {
+	// Tolerance is the relative residual at which an iteration stops: the solvers stop when
+	// ‖b - A x‖₂ <= Tolerance * ‖b‖₂. If Tolerance <= 0, 1e-8 is used.
+	Tolerance float64
+
+	// MaxIter is the maximum number of iterations. If MaxIter <= 0, 1000 is used.
+	MaxIter int
+
+	// Preconditioner is applied in each iteration of [CG] and [BiCGSTAB]. It is ignored
+	// by the other solvers. If Preconditioner is nil, no preconditioning is performed.
+	Preconditioner Preconditioner
+}
+
+// Result describes the progress of an iterative solver.
+type Result struct {
+	// Iterations is the number of iterations performed.
+	Iterations int
+
+	// Converged reports whether the relative residual dropped to the tolerance.
+	Converged bool
+
+	// History holds the relative residual ‖b - A x‖₂ / ‖b‖₂ before the first iteration,
+	// and after each iteration. If b is zero, the absolute residual is used instead.
+	// For the eigenvalue solvers, see [PowerIteration] and [Lanczos].
+	History []float64
+}
+
+// Residual returns the last relative residual in the history.
+func (result Result) Residual() float64 {
+	if len(result.History) == 0 {
+		return math.NaN()
+	}
+	return result.History[len(result.History)-1]
+}
+
+// A Preconditioner approximates the inverse of a matrix M, which in turn approximates A.
+type Preconditioner interface {
+	// Apply sets z = M⁻¹ r. z and r are full vectors of the same size.
+	Apply(z, r GrB.Vector[float64]) error
+}
+
+// PreconditionerFunc allows the use of an ordinary function as a [Preconditioner].
+type PreconditionerFunc func(z, r GrB.Vector[float64]) error
+
+// Apply calls f(z, r).
+func (f PreconditionerFunc) Apply(z, r GrB.Vector[float64]) error {
+	return f(z, r)
+}
+
+// A DiagonalPreconditioner is the Jacobi preconditioner, with M the diagonal of A.
+type DiagonalPreconditioner struct {
+	inverse GrB.Vector[float64]
+}
+
+// DiagonalPreconditionerNew creates a Jacobi preconditioner for the square matrix A,
+// whose diagonal is extracted with [GrB.Vector.ExtractDiag].
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch]: A is not square.
+//   - [GrB.InvalidValue]: A diagonal entry of A is zero or not present.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func DiagonalPreconditionerNew(A GrB.Matrix[float64]) (p *DiagonalPreconditioner, err error) {
+	inverse, err := diagonal(A)
+	if err != nil {
+		return
+	}
+	if err = GrB.VectorApply(inverse, nil, nil, GrB.Minv[float64](), inverse, nil); err != nil {
+		_ = inverse.Free()
+		return
+	}
+	return &DiagonalPreconditioner{inverse}, nil
+}
+
+// Apply sets z = r ./ diag(A).
+func (p *DiagonalPreconditioner) Apply(z, r GrB.Vector[float64]) error {
+	return GrB.VectorEWiseMultBinaryOp(z, nil, nil, GrB.Times[float64](), r, p.inverse, nil)
+}
+
+// Free frees the preconditioner.
+func (p *DiagonalPreconditioner) Free() error {
+	return p.inverse.Free()
+}
+
+// diagonal returns the diagonal of the square matrix A,
+// or an error if a diagonal entry is zero or not present.
+func diagonal(A GrB.Matrix[float64]) (d GrB.Vector[float64], err error) {
+	defer GrB.CheckErrors(&err)
+	nrows, ncols, err := A.Size()
+	GrB.OK(err)
+	if nrows != ncols {
+		GrB.OK(GrB.DimensionMismatch)
+	}
+	d, err = GrB.VectorNew[float64](nrows)
+	GrB.OK(err)
+	defer func() {
+		if err != nil {
+			_ = d.Free()
+		}
+	}()
+	GrB.OK(d.ExtractDiag(A, 0, nil))
+	nvals, err := d.Nvals()
+	GrB.OK(err)
+	if nvals < nrows {
+		GrB.OK(fmt.Errorf("linalg: %v diagonal entries are not present: %w", nrows-nvals, GrB.InvalidValue))
+	}
+	zeros, err := GrB.VectorNew[float64](nrows)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(zeros.Free())
+	}()
+	GrB.OK(GrB.VectorSelect(zeros, nil, nil, GrB.Valueeq[float64](), d, 0, nil))
+	nzeros, err := zeros.Nvals()
+	GrB.OK(err)
+	if nzeros > 0 {
+		GrB.OK(fmt.Errorf("linalg: %v diagonal entries are zero: %w", nzeros, GrB.InvalidValue))
+	}
+	return
+}
+
+// notIso ensures that v is not iso-valued if it is full, by storing a different value and then
+// the original value again. All vectors that are updated in place are passed through notIso first,
+// to work around the SuiteSparse:GraphBLAS 8.0.2 bug described under "Known issues" in [GrB].
+func notIso[D GrB.Float | GrB.Complex](v GrB.Vector[D]) (err error) {
+	defer GrB.CheckErrors(&err)
+	iso, err := v.Iso()
+	GrB.OK(err)
+	if !iso {
+		return
+	}
+	size, err := v.Size()
+	GrB.OK(err)
+	nvals, err := v.Nvals()
+	GrB.OK(err)
+	if size == 0 || nvals < size {
+		return
+	}
+	value, _, err := v.ExtractElement(0)
+	GrB.OK(err)
+	// value+1 would be equal to value for large values, infinities and NaN
+	var other D
+	if value == 0 {
+		other = 1
+	}
+	GrB.OK(v.SetElement(other, 0))
+	GrB.OK(v.SetElement(value, 0))
+	return
+}
@@ -0,0 +1,210 @@
+package linalg
+
+import (
+	"github.com/intel/forGraphBLASGo/GrB"
+	"math"
+)
+
+// Norm1 returns the 1-norm of A, the maximum over all columns of the sum of the absolute
+// values of their entries. Norm1 returns 0 if A has no entries.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func Norm1[D GrB.Float | GrB.Complex](A GrB.Matrix[D]) (float64, error) {
+	return maxSum(A, true)
+}
+
+// NormInf returns the infinity-norm of A, the maximum over all rows of the sum of the absolute
+// values of their entries. NormInf returns 0 if A has no entries.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func NormInf[D GrB.Float | GrB.Complex](A GrB.Matrix[D]) (float64, error) {
+	return maxSum(A, false)
+}
+
+// maxSum returns the maximum row sum of |A|, or the maximum column sum if transpose is true.
+func maxSum[D GrB.Float | GrB.Complex](A GrB.Matrix[D], transpose bool) (norm float64, err error) {
+	defer GrB.CheckErrors(&err)
+	a, err := absMatrix(A)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(a.Free())
+	}()
+	size, err := a.Nrows()
+	GrB.OK(err)
+	var desc *GrB.Descriptor
+	if transpose {
+		size, err = a.Ncols()
+		GrB.OK(err)
+		desc = GrB.DescT0
+	}
+	sums, err := GrB.VectorNew[float64](size)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(sums.Free())
+	}()
+	GrB.OK(GrB.MatrixReduceMonoid(sums, nil, nil, GrB.PlusMonoid[float64](), a, desc))
+	norm, err = GrB.VectorReduce(GrB.MaxMonoid[float64](), sums, nil)
+	GrB.OK(err)
+	// the maximum of no sums is -Inf
+	return max(norm, 0), nil
+}
+
+// NormFrobenius returns the Frobenius norm of A, the square root of the sum of the squares
+// of the absolute values of its entries.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func NormFrobenius[D GrB.Float | GrB.Complex](A GrB.Matrix[D]) (norm float64, err error) {
+	defer GrB.CheckErrors(&err)
+	a, err := absMatrix(A)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(a.Free())
+	}()
+	nrows, ncols, err := a.Size()
+	GrB.OK(err)
+	squares, err := GrB.MatrixNew[float64](nrows, ncols)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(squares.Free())
+	}()
+	GrB.OK(GrB.MatrixEWiseMultBinaryOp(squares, nil, nil, GrB.Times[float64](), a, a, nil))
+	sum, err := GrB.MatrixReduce(GrB.PlusMonoid[float64](), squares, nil)
+	GrB.OK(err)
+	return math.Sqrt(sum), nil
+}
+
+// NormMax returns the maximum absolute value of the entries of A, or 0 if A has no entries.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func NormMax[D GrB.Float | GrB.Complex](A GrB.Matrix[D]) (norm float64, err error) {
+	defer GrB.CheckErrors(&err)
+	a, err := absMatrix(A)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(a.Free())
+	}()
+	norm, err = GrB.MatrixReduce(GrB.MaxMonoid[float64](), a, nil)
+	GrB.OK(err)
+	return max(norm, 0), nil
+}
+
+// Trace returns the sum of the entries on the main diagonal of A,
+// which is extracted with [GrB.Vector.ExtractDiag]. A need not be square.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func Trace[D GrB.Float | GrB.Complex](A GrB.Matrix[D]) (trace D, err error) {
+	defer GrB.CheckErrors(&err)
+	nrows, ncols, err := A.Size()
+	GrB.OK(err)
+	d, err := GrB.VectorNew[D](min(nrows, ncols))
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(d.Free())
+	}()
+	GrB.OK(d.ExtractDiag(A, 0, nil))
+	return GrB.VectorReduce(GrB.PlusMonoid[D](), d, nil)
+}
+
+// Dot returns the inner product of u and v, the sum of conj(u(i)) * v(i) over all i where
+// both u(i) and v(i) are present. For real domains, conj(u(i)) is u(i).
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch]: u and v have different sizes.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func Dot[D GrB.Float | GrB.Complex](u, v GrB.Vector[D]) (dot D, err error) {
+	defer GrB.CheckErrors(&err)
+	size, err := u.Size()
+	GrB.OK(err)
+	products, err := GrB.VectorNew[D](size)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(products.Free())
+	}()
+	left := u
+	if op, ok := conj[D](); ok {
+		left, err = GrB.VectorNew[D](size)
+		GrB.OK(err)
+		defer func() {
+			GrB.OK(left.Free())
+		}()
+		GrB.OK(GrB.VectorApply(left, nil, nil, op, u, nil))
+	}
+	GrB.OK(GrB.VectorEWiseMultBinaryOp(products, nil, nil, GrB.Times[D](), left, v, nil))
+	return GrB.VectorReduce(GrB.PlusMonoid[D](), products, nil)
+}
+
+// conj returns the complex conjugate operator for D, and reports whether D is a complex domain.
+func conj[D GrB.Float | GrB.Complex]() (op GrB.UnaryOp[D, D], ok bool) {
+	var d D
+	switch any(d).(type) {
+	case complex64:
+		return any(GrB.Conj[complex64]()).(GrB.UnaryOp[D, D]), true
+	case complex128:
+		return any(GrB.Conj[complex128]()).(GrB.UnaryOp[D, D]), true
+	}
+	return
+}
+
+// Norm2 returns the Euclidean norm of v, the square root of the sum of the squares
+// of the absolute values of its entries.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func Norm2[D GrB.Float | GrB.Complex](v GrB.Vector[D]) (norm float64, err error) {
+	defer GrB.CheckErrors(&err)
+	size, err := v.Size()
+	GrB.OK(err)
+	a, err := GrB.VectorNew[float64](size)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(a.Free())
+	}()
+	GrB.OK(GrB.VectorApply(GrB.VectorView[D, float64](a), nil, nil, GrB.Abs[D](), v, nil))
+	squares, err := GrB.VectorNew[float64](size)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(squares.Free())
+	}()
+	GrB.OK(GrB.VectorEWiseMultBinaryOp(squares, nil, nil, GrB.Times[float64](), a, a, nil))
+	sum, err := GrB.VectorReduce(GrB.PlusMonoid[float64](), squares, nil)
+	GrB.OK(err)
+	return math.Sqrt(sum), nil
+}
+
+// Axpy sets y += alpha * x. Where only x(i) is present, y(i) is set to alpha * x(i).
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch]: x and y have different sizes.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func Axpy[D GrB.Float | GrB.Complex](alpha D, x, y GrB.Vector[D]) error {
+	if err := notIso(y); err != nil {
+		return err
+	}
+	plus := GrB.Plus[D]()
+	return GrB.VectorApplyBinaryOp2nd(y, nil, &plus, GrB.Times[D](), x, alpha, nil)
+}
+
+// absMatrix returns |A| as a matrix with domain float64.
+func absMatrix[D GrB.Float | GrB.Complex](A GrB.Matrix[D]) (a GrB.Matrix[float64], err error) {
+	nrows, ncols, err := A.Size()
+	if err != nil {
+		return
+	}
+	if a, err = GrB.MatrixNew[float64](nrows, ncols); err != nil {
+		return
+	}
+	// Abs of a complex number is real, and is typecast to float64 like the real domains
+	if err = GrB.MatrixApply(GrB.MatrixView[D, float64](a), nil, nil, GrB.Abs[D](), A, nil); err != nil {
+		_ = a.Free()
+	}
+	return
+}
@@ -0,0 +1,425 @@
+package linalg
+
+import (
+	"github.com/intel/forGraphBLASGo/GrB"
+	"math"
+)
+
+// A solver holds the state that is common to all solvers.
+type solver struct {
+	n         int
+	A         GrB.Matrix[float64]
+	x, b      GrB.Vector[float64] // full; b is a copy
+	bnorm     float64
+	tolerance float64
+	maxIter   int
+	opts      Options
+	tmp       GrB.Vector[float64]
+	vectors   []GrB.Vector[float64] // freed with the solver
+}
+
+// newSolver checks the dimensions of A, x, and b, and prepares a solver.
+func newSolver(A GrB.Matrix[float64], x, b GrB.Vector[float64], opts Options) (s *solver, err error) {
+	s = &solver{A: A, x: x, opts: opts, tolerance: opts.Tolerance, maxIter: opts.MaxIter}
+	if s.tolerance <= 0 {
+		s.tolerance = 1e-8
+	}
+	if s.maxIter <= 0 {
+		s.maxIter = 1000
+	}
+	defer func() {
+		if err != nil {
+			_ = s.free()
+			s = nil
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+	nrows, ncols, err := A.Size()
+	GrB.OK(err)
+	xsize, err := x.Size()
+	GrB.OK(err)
+	bsize, err := b.Size()
+	GrB.OK(err)
+	if nrows != ncols || xsize != ncols || bsize != nrows {
+		GrB.OK(GrB.DimensionMismatch)
+	}
+	s.n = nrows
+	s.tmp = s.vector()
+	s.b = s.vector()
+	if s.n > 0 {
+		// x<!struct(x)> = 0, b<struct(b)> = b
+		GrB.OK(GrB.VectorAssignConstant(s.output(x), x.AsMask(), nil, 0, GrB.All(s.n), GrB.DescSC))
+		GrB.OK(GrB.VectorAssign(s.b, b.AsMask(), nil, b, GrB.All(s.n), GrB.DescS))
+	}
+	s.bnorm = s.norm(s.b)
+	if s.bnorm == 0 {
+		s.bnorm = 1
+	}
+	return
+}
+
+// free frees the vectors of the solver.
+func (s *solver) free() (err error) {
+	for _, v := range s.vectors {
+		if ferr := v.Free(); err == nil {
+			err = ferr
+		}
+	}
+	return
+}
+
+// The following methods panic on errors, and must be called with [GrB.CheckErrors] deferred.
+
+// vector returns a new full vector of zeros, which is freed with the solver.
+func (s *solver) vector() GrB.Vector[float64] {
+	v, err := GrB.VectorNew[float64](s.n)
+	GrB.OK(err)
+	s.vectors = append(s.vectors, v)
+	if s.n > 0 {
+		GrB.OK(GrB.VectorAssignConstant(v, nil, nil, 0, GrB.All(s.n), nil))
+	}
+	return s.output(v)
+}
+
+// output prepares v for being the output of a GraphBLAS operation, and returns it.
+func (s *solver) output(v GrB.Vector[float64]) GrB.Vector[float64] {
+	GrB.OK(notIso(v))
+	return v
+}
+
+// copy sets w = u.
+func (s *solver) copy(w, u GrB.Vector[float64]) {
+	if s.n > 0 {
+		GrB.OK(GrB.VectorAssign(s.output(w), nil, nil, u, GrB.All(s.n), nil))
+	}
+}
+
+// dot returns u' * v.
+func (s *solver) dot(u, v GrB.Vector[float64]) float64 {
+	GrB.OK(GrB.VectorEWiseMultBinaryOp(s.output(s.tmp), nil, nil, GrB.Times[float64](), u, v, nil))
+	result, err := GrB.VectorReduce(GrB.PlusMonoid[float64](), s.tmp, nil)
+	GrB.OK(err)
+	return result
+}
+
+// norm returns ‖u‖₂.
+func (s *solver) norm(u GrB.Vector[float64]) float64 {
+	return math.Sqrt(s.dot(u, u))
+}
+
+// axpy sets y += alpha * x.
+func (s *solver) axpy(alpha float64, x, y GrB.Vector[float64]) {
+	GrB.OK(Axpy(alpha, x, y))
+}
+
+// residual sets r = b - A x, for a full vector r.
+func (s *solver) residual(r GrB.Vector[float64]) {
+	s.copy(r, s.b)
+	minus := GrB.Minus[float64]()
+	GrB.OK(GrB.MxV(s.output(r), nil, &minus, GrB.PlusTimesSemiring[float64](), s.A, s.x, nil))
+}
+
+// precondition sets z = M⁻¹ r.
+func (s *solver) precondition(z, r GrB.Vector[float64]) {
+	if s.opts.Preconditioner == nil {
+		s.copy(z, r)
+		return
+	}
+	GrB.OK(s.opts.Preconditioner.Apply(s.output(z), r))
+}
+
+// record adds the relative residual of r to the history, and reports whether it
+// has dropped to the tolerance.
+func (s *solver) record(result *Result, r GrB.Vector[float64]) bool {
+	residual := s.norm(r) / s.bnorm
+	result.History = append(result.History, residual)
+	result.Converged = residual <= s.tolerance
+	return result.Converged
+}
+
+// CG solves A x = b with the preconditioned conjugate gradient method.
+// A must be symmetric and positive definite, and so must the preconditioner.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch]: A is not square, or x or b do not match A.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func CG(A GrB.Matrix[float64], x, b GrB.Vector[float64], opts Options) (result Result, err error) {
+	s, err := newSolver(A, x, b, opts)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if ferr := s.free(); err == nil {
+			err = ferr
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+
+	r := s.vector()
+	s.residual(r)
+	if s.record(&result, r) {
+		return
+	}
+	z, p, q := s.vector(), s.vector(), s.vector()
+	s.precondition(z, r)
+	s.copy(p, z)
+	rz := s.dot(r, z)
+	for result.Iterations < s.maxIter {
+		result.Iterations++
+		// q = A p
+		GrB.OK(GrB.MxV(s.output(q), nil, nil, GrB.PlusTimesSemiring[float64](), A, p, nil))
+		pq := s.dot(p, q)
+		if pq == 0 {
+			break
+		}
+		alpha := rz / pq
+		s.axpy(alpha, p, x)
+		s.axpy(-alpha, q, r)
+		if s.record(&result, r) {
+			break
+		}
+		s.precondition(z, r)
+		rzNext := s.dot(r, z)
+		beta := rzNext / rz
+		rz = rzNext
+		// p = z + beta * p
+		GrB.OK(GrB.VectorApplyBinaryOp2nd(s.output(p), nil, nil, GrB.Times[float64](), p, beta, nil))
+		GrB.OK(GrB.VectorEWiseAddBinaryOp(s.output(p), nil, nil, GrB.Plus[float64](), p, z, nil))
+	}
+	return
+}
+
+// BiCGSTAB solves A x = b with the right-preconditioned biconjugate gradient stabilized method
+// of van der Vorst, which does not require A to be symmetric.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch]: A is not square, or x or b do not match A.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func BiCGSTAB(A GrB.Matrix[float64], x, b GrB.Vector[float64], opts Options) (result Result, err error) {
+	s, err := newSolver(A, x, b, opts)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if ferr := s.free(); err == nil {
+			err = ferr
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+
+	r := s.vector()
+	s.residual(r)
+	if s.record(&result, r) {
+		return
+	}
+	r0, p, v, y, h, z, t := s.vector(), s.vector(), s.vector(), s.vector(), s.vector(), s.vector(), s.vector()
+	s.copy(r0, r)
+	rho, alpha, omega := 1.0, 1.0, 1.0
+	for result.Iterations < s.maxIter {
+		result.Iterations++
+		rhoNext := s.dot(r0, r)
+		if rhoNext == 0 {
+			break
+		}
+		beta := (rhoNext / rho) * (alpha / omega)
+		rho = rhoNext
+		// p = r + beta * (p - omega * v)
+		s.axpy(-omega, v, p)
+		GrB.OK(GrB.VectorApplyBinaryOp2nd(s.output(p), nil, nil, GrB.Times[float64](), p, beta, nil))
+		GrB.OK(GrB.VectorEWiseAddBinaryOp(s.output(p), nil, nil, GrB.Plus[float64](), p, r, nil))
+		// v = A M⁻¹ p
+		s.precondition(y, p)
+		GrB.OK(GrB.MxV(s.output(v), nil, nil, GrB.PlusTimesSemiring[float64](), A, y, nil))
+		r0v := s.dot(r0, v)
+		if r0v == 0 {
+			break
+		}
+		alpha = rho / r0v
+		// h = x + alpha * y, r = r - alpha * v
+		s.copy(h, x)
+		s.axpy(alpha, y, h)
+		s.axpy(-alpha, v, r)
+		if s.norm(r)/s.bnorm <= s.tolerance {
+			s.copy(x, h)
+			s.record(&result, r)
+			break
+		}
+		// t = A M⁻¹ r
+		s.precondition(z, r)
+		GrB.OK(GrB.MxV(s.output(t), nil, nil, GrB.PlusTimesSemiring[float64](), A, z, nil))
+		tt := s.dot(t, t)
+		if tt == 0 {
+			break
+		}
+		omega = s.dot(t, r) / tt
+		// x = h + omega * z, r = r - omega * t
+		s.copy(x, h)
+		s.axpy(omega, z, x)
+		s.axpy(-omega, t, r)
+		if s.record(&result, r) || omega == 0 {
+			break
+		}
+	}
+	return
+}
+
+// Jacobi solves A x = b with the Jacobi method, x += (b - A x) ./ diag(A), which converges if A
+// is strictly diagonally dominant, for example.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch]: A is not square, or x or b do not match A.
+//   - [GrB.InvalidValue]: A diagonal entry of A is zero or not present.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func Jacobi(A GrB.Matrix[float64], x, b GrB.Vector[float64], opts Options) (result Result, err error) {
+	s, err := newSolver(A, x, b, opts)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if ferr := s.free(); err == nil {
+			err = ferr
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+
+	d, err := diagonal(A)
+	GrB.OK(err)
+	s.vectors = append(s.vectors, d)
+	r := s.vector()
+	s.residual(r)
+	if s.record(&result, r) {
+		return
+	}
+	plus := GrB.Plus[float64]()
+	for result.Iterations < s.maxIter {
+		result.Iterations++
+		// x += r ./ d
+		GrB.OK(GrB.VectorEWiseMultBinaryOp(s.output(x), nil, &plus, GrB.Div[float64](), r, d, nil))
+		s.residual(r)
+		if s.record(&result, r) {
+			break
+		}
+	}
+	return
+}
+
+// GaussSeidel solves A x = b with multicolor Gauss-Seidel sweeps. The rows of A are colored
+// greedily, such that no two rows of the same color are coupled by an entry of A. Each sweep
+// updates the rows of one color after the other, all rows of a color at once, with
+// x<color> += (b - A x)<color> ./ diag(A). This is the Gauss-Seidel method for a reordering
+// of the rows by color, which converges if A is symmetric and positive definite, or strictly
+// diagonally dominant, for example.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch]: A is not square, or x or b do not match A.
+//   - [GrB.InvalidValue]: A diagonal entry of A is zero or not present.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func GaussSeidel(A GrB.Matrix[float64], x, b GrB.Vector[float64], opts Options) (result Result, err error) {
+	s, err := newSolver(A, x, b, opts)
+	if err != nil {
+		return
+	}
+	var colors []GrB.Vector[bool]
+	defer func() {
+		for _, color := range colors {
+			if ferr := color.Free(); err == nil {
+				err = ferr
+			}
+		}
+		if ferr := s.free(); err == nil {
+			err = ferr
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+
+	d, err := diagonal(A)
+	GrB.OK(err)
+	s.vectors = append(s.vectors, d)
+	colors, err = colorRows(A)
+	GrB.OK(err)
+	r, t := s.vector(), s.vector()
+	s.residual(r)
+	if s.record(&result, r) {
+		return
+	}
+	plus := GrB.Plus[float64]()
+	for result.Iterations < s.maxIter {
+		result.Iterations++
+		for i := range colors {
+			color := &colors[i]
+			// t<color, replace> = A x, r<color, replace> = b - t, x<color> += r ./ d
+			GrB.OK(GrB.MxV(s.output(t), color, nil, GrB.PlusTimesSemiring[float64](), A, x, GrB.DescRS))
+			GrB.OK(GrB.VectorEWiseAddBinaryOp(s.output(r), color, nil, GrB.Minus[float64](), s.b, t, GrB.DescRS))
+			GrB.OK(GrB.VectorEWiseMultBinaryOp(s.output(x), color, &plus, GrB.Div[float64](), r, d, GrB.DescS))
+		}
+		s.residual(r)
+		if s.record(&result, r) {
+			break
+		}
+	}
+	return
+}
+
+// colorRows colors the rows of the square matrix A greedily, such that rows i and j have different
+// colors if A(i, j) or A(j, i) is present, and returns one boolean vector for each color.
+func colorRows(A GrB.Matrix[float64]) (colors []GrB.Vector[bool], err error) {
+	defer func() {
+		if err != nil {
+			for _, color := range colors {
+				_ = color.Free()
+			}
+			colors = nil
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+	n, err := A.Nrows()
+	GrB.OK(err)
+	var rows, cols []int
+	GrB.OK(A.ExtractTuples(&rows, &cols, nil))
+	neighbors := make([][]int, n)
+	for k := range rows {
+		if i, j := rows[k], cols[k]; i != j {
+			neighbors[i] = append(neighbors[i], j)
+			neighbors[j] = append(neighbors[j], i)
+		}
+	}
+	color := make([]int, n)
+	var members [][]int
+	used := make(map[int]bool)
+	for i := range n {
+		clear(used)
+		for _, j := range neighbors[i] {
+			if j < i {
+				used[color[j]] = true
+			}
+		}
+		c := 0
+		for used[c] {
+			c++
+		}
+		color[i] = c
+		if c == len(members) {
+			members = append(members, nil)
+		}
+		members[c] = append(members[c], i)
+	}
+	for _, indices := range members {
+		v, err := GrB.VectorNew[bool](n)
+		GrB.OK(err)
+		colors = append(colors, v)
+		values := make([]bool, len(indices))
+		for k := range values {
+			values[k] = true
+		}
+		GrB.OK(v.Build(indices, values, nil))
+	}
+	return
+}
@@ -0,0 +1,99 @@
+package linalg
+
+import (
+	"math"
+	"sort"
+)
+
+// tridiagonalEigen computes the eigenvalues and eigenvectors of the symmetric tridiagonal matrix T
+// with diagonal alpha and subdiagonal beta, where len(beta) == len(alpha)-1, using the implicit QL
+// algorithm of EISPACK's tql2. The eigenvalues are returned in ascending order, and vectors[i][j] is
+// the i-th component of the eigenvector of values[j].
+func tridiagonalEigen(alpha, beta []float64) (values []float64, vectors [][]float64) {
+	n := len(alpha)
+	d := append([]float64(nil), alpha...)
+	e := make([]float64, n)
+	copy(e, beta)
+	z := make([][]float64, n)
+	for i := range z {
+		z[i] = make([]float64, n)
+		z[i][i] = 1
+	}
+
+	f, tst1 := 0.0, 0.0
+	eps := math.Nextafter(1, 2) - 1
+	for l := range n {
+		// find a small subdiagonal element
+		tst1 = max(tst1, math.Abs(d[l])+math.Abs(e[l]))
+		m := l
+		for m < n-1 && math.Abs(e[m]) > eps*tst1 {
+			m++
+		}
+		// if m == l, d[l] is an eigenvalue; otherwise, iterate
+		for m > l {
+			// implicit shift
+			g := d[l]
+			p := (d[l+1] - g) / (2 * e[l])
+			r := math.Copysign(math.Hypot(p, 1), p)
+			d[l] = e[l] / (p + r)
+			d[l+1] = e[l] * (p + r)
+			dl1 := d[l+1]
+			h := g - d[l]
+			for i := l + 2; i < n; i++ {
+				d[i] -= h
+			}
+			f += h
+
+			// implicit QL transformation
+			p = d[m]
+			c, c2, c3 := 1.0, 1.0, 1.0
+			el1 := e[l+1]
+			s, s2 := 0.0, 0.0
+			for i := m - 1; i >= l; i-- {
+				c3, c2, s2 = c2, c, s
+				g = c * e[i]
+				h = c * p
+				r = math.Hypot(p, e[i])
+				e[i+1] = s * r
+				s = e[i] / r
+				c = p / r
+				p = c*d[i] - s*g
+				d[i+1] = h + s*(c*g+s*d[i])
+				// accumulate the transformation
+				for k := range n {
+					h = z[k][i+1]
+					z[k][i+1] = s*z[k][i] + c*h
+					z[k][i] = c*z[k][i] - s*h
+				}
+			}
+			p = -s * s2 * c3 * el1 * e[l] / dl1
+			e[l] = s * p
+			d[l] = c * p
+			if math.Abs(e[l]) <= eps*tst1 {
+				break
+			}
+		}
+		d[l] += f
+		e[l] = 0
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return d[order[i]] < d[order[j]]
+	})
+	values = make([]float64, n)
+	vectors = make([][]float64, n)
+	for i := range vectors {
+		vectors[i] = make([]float64, n)
+	}
+	for j, k := range order {
+		values[j] = d[k]
+		for i := range n {
+			vectors[i][j] = z[i][k]
+		}
+	}
+	return
+}
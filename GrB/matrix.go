@@ -258,6 +258,9 @@ func (matrix Matrix[D]) Nvals() (nvals int, err error) {
 //     values for the same location are present in the input slices. All three domains
 //     of dup must be D. If dup is nil, then duplicate locations will result in an [InvalidValue] error.
 //
+// If the slices are empty, Build leaves the matrix without entries, but still
+// returns [OutputNotEmpty] if the matrix already has entries.
+//
 // GraphBLAS API errors that may be returned:
 //   - [DomainMismatch], [InvalidValue], [SliceMismatch], [OutputNotEmpty], [UninitializedObject]
 //
@@ -277,6 +280,9 @@ func (matrix Matrix[D]) Build(rowIndices, colIndices []int, values []D, dup *Bin
 			return makeError(IndexOutOfBounds)
 		}
 	}
+	if len(values) == 0 {
+		return buildEmpty(matrix.Nvals())
+	}
 	var cdup C.GrB_BinaryOp
 	if dup == nil {
 		cdup = C.GrB_BinaryOp(C.GrB_NULL)
@@ -407,7 +413,7 @@ func (matrix Matrix[D]) Build(rowIndices, colIndices []int, values []D, dup *Bin
 // BuildScalar is like [Matrix.Build], except that the scalar is the value of all the tuples.
 //
 // Unlike [Matrix.Build], there is no dup operator to handle duplicate entries. Instead, any
-// duplicates are silently ignored.
+// duplicates are silently ignored. As with [Matrix.Build], empty index slices are allowed.
 //
 // BuildScalar is a SuiteSparse:GraphBLAS extension.
 func (matrix Matrix[D]) BuildScalar(rowIndices, colIndices []int, scalar Scalar[D]) error {
@@ -424,6 +430,9 @@ func (matrix Matrix[D]) BuildScalar(rowIndices, colIndices []int, scalar Scalar[
 			return makeError(InvalidIndex)
 		}
 	}
+	if len(rowIndices) == 0 {
+		return buildEmpty(matrix.Nvals())
+	}
 	info := Info(C.GxB_Matrix_build_Scalar(
 		matrix.grb, grbIndices(rowIndices), grbIndices(colIndices),
 		scalar.grb, C.GrB_Index(len(rowIndices)),
@@ -784,7 +793,7 @@ func (matrix Matrix[D]) IsStoredElement(rowIndex, colIndex int) (ok bool, err er
 //     produces the values of the matrix.
 //
 // It is valid to pass pointers to nil slices, and ExtractTuples then produces the
-// corresponding indices or values.
+// corresponding indices or values. If the matrix has no entries, the slices are left unchanged.
 //
 // GraphBLAS API errors that may be returned:
 //   - [DomainMismatch], [UninitializedObject]
@@ -796,6 +805,9 @@ func (matrix Matrix[D]) ExtractTuples(rowIndices, colIndices *[]int, values *[]D
 	if err != nil {
 		return err
 	}
+	if nvals == 0 {
+		return nil
+	}
 	targetRowIndices, finalizeTargetRowIndices := growIndices(rowIndices, nvals)
 	targetColIndices, finalizeTargetColIndices := growIndices(colIndices, nvals)
 	targetValues := growslice(values, nvals)
@@ -1442,6 +1454,34 @@ func (matrix Matrix[D]) Serialize(data []byte) (size int, err error) {
 	return
 }
 
+// SerializeBlob serializes a GraphBLAS matrix object into an opaque slice of bytes, which is
+// allocated by SerializeBlob. Unlike [Matrix.Serialize], SerializeBlob does not need to compute
+// the size of the result upfront, and respects the [Compression] field of the descriptor.
+// The default compression is ZSTD with level 1. The result can be passed to [MatrixDeserialize].
+//
+// Parameters:
+//
+//   - desc (IN): An optional descriptor to select the compression method.
+//
+// GraphBLAS API errors that may be returned:
+//   - [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [InvalidObject], [OutOfMemory], [Panic]
+//
+// SerializeBlob is a SuiteSparse:GraphBLAS extension.
+func (matrix Matrix[D]) SerializeBlob(desc *Descriptor) (data SystemSlice[byte], err error) {
+	var blob unsafe.Pointer
+	var size C.GrB_Index
+	cdesc := processDescriptor(desc)
+	info := Info(C.GxB_Matrix_serialize(&blob, &size, matrix.grb, cdesc))
+	if info == success {
+		return AsSystemSlice[byte](blob, int(size)), nil
+	}
+	err = makeError(info)
+	return
+}
+
 // MatrixDeserialize constructs a new GraphBLAS matrix from a serialized object.
 //
 // Parameters:
@@ -1468,6 +1508,33 @@ func MatrixDeserialize[D any](data []byte) (a Matrix[D], err error) {
 	return
 }
 
+// DeserializeTypeName returns the name of the type of the matrix or vector held in a
+// serialized object. For the predefined types, this is the name of the corresponding
+// C type, for example "bool", "int32_t", "double", or "float complex". For user-defined
+// types, this is the name passed to [NamedTypeNew].
+//
+// Parameters:
+//
+//   - data (IN): A slice that holds a GraphBLAS matrix or vector created with [Matrix.Serialize]
+//     or [Matrix.SerializeBlob].
+//
+// GraphBLAS API errors that may be returned:
+//   - [NullPointer]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [InvalidObject], [Panic]
+//
+// DeserializeTypeName is a SuiteSparse:GraphBLAS extension.
+func DeserializeTypeName(data []byte) (name string, err error) {
+	var cname [C.GxB_MAX_NAME_LEN]C.char
+	info := Info(C.GxB_deserialize_type_name(&cname[0], unsafe.Pointer(unsafe.SliceData(data)), C.GrB_Index(len(data))))
+	if info == success {
+		return C.GoString(&cname[0]), nil
+	}
+	err = makeError(info)
+	return
+}
+
 // RowIteratorNew creates a row iterator and attaches it to the matrix.
 //
 // GraphBLAS API errors that may be returned:
@@ -1798,6 +1865,7 @@ func (matrix Matrix[D]) Valid() bool {
 //   - [Panic]
 func (matrix *Matrix[D]) Free() error {
 	info := Info(C.GrB_Matrix_free(&matrix.grb))
+	reapBorrowed()
 	if info == success {
 		return nil
 	}
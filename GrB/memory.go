@@ -0,0 +1,583 @@
+package GrB
+
+/*
+#include <pthread.h>
+#include <stdatomic.h>
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+#include <string.h>
+#include "GraphBLAS.h"
+
+typedef void * (* user_malloc_function  ) (size_t);
+typedef void * (* user_calloc_function  ) (size_t, size_t);
+typedef void * (* user_realloc_function ) (void *, size_t);
+typedef void   (* user_free_function    ) (void *);
+
+// The memory management functions installed by Init and InitWithMalloc. A NULL
+// function means that the corresponding C standard library function is used.
+static user_malloc_function  grb_user_malloc  = NULL;
+static user_calloc_function  grb_user_calloc  = NULL;
+static user_realloc_function grb_user_realloc = NULL;
+static user_free_function    grb_user_free    = NULL;
+
+// Borrowed memory is Go memory that has been pinned and handed over to GraphBLAS.
+// It must never be passed to the underlying free or realloc functions. Instead,
+// when GraphBLAS releases borrowed memory, it is only marked as released in the
+// following table, and later unpinned on the Go side.
+//
+// The table uses open addressing with linear probing. Entries are never removed
+// individually; grb_borrowed_reap rebuilds the table without the released entries.
+
+typedef struct {
+	void *ptr;
+	size_t size;
+	bool released;
+} grb_borrowed_entry;
+
+static pthread_mutex_t grb_borrowed_mutex = PTHREAD_MUTEX_INITIALIZER;
+static grb_borrowed_entry *grb_borrowed_table = NULL;
+static size_t grb_borrowed_capacity = 0;
+static atomic_size_t grb_borrowed_count = 0;
+static atomic_size_t grb_borrowed_released = 0;
+
+static size_t grb_borrowed_hash(void *ptr) {
+	size_t h = (size_t)ptr;
+	h ^= h >> 17;
+	h *= (size_t)0xed5ad4bb;
+	h ^= h >> 11;
+	return h & (grb_borrowed_capacity - 1);
+}
+
+static grb_borrowed_entry *grb_borrowed_find(void *ptr) {
+	if (grb_borrowed_capacity == 0) {
+		return NULL;
+	}
+	for (size_t i = grb_borrowed_hash(ptr);; i = (i + 1) & (grb_borrowed_capacity - 1)) {
+		grb_borrowed_entry *entry = &grb_borrowed_table[i];
+		if (entry->ptr == ptr) {
+			return entry;
+		}
+		if (entry->ptr == NULL) {
+			return NULL;
+		}
+	}
+}
+
+static void grb_borrowed_put(grb_borrowed_entry entry) {
+	size_t i = grb_borrowed_hash(entry.ptr);
+	while (grb_borrowed_table[i].ptr != NULL) {
+		i = (i + 1) & (grb_borrowed_capacity - 1);
+	}
+	grb_borrowed_table[i] = entry;
+}
+
+// grb_borrowed_rebuild rehashes the entries into a table of the given capacity. If reaped is
+// not NULL, the released entries are stored in reaped instead, which must have room for all of them.
+static bool grb_borrowed_rebuild(size_t capacity, void **reaped) {
+	grb_borrowed_entry *old_table = grb_borrowed_table;
+	size_t old_capacity = grb_borrowed_capacity;
+	grb_borrowed_entry *new_table = calloc(capacity, sizeof(grb_borrowed_entry));
+	if (new_table == NULL) {
+		return false;
+	}
+	grb_borrowed_table = new_table;
+	grb_borrowed_capacity = capacity;
+	for (size_t i = 0; i < old_capacity; i++) {
+		grb_borrowed_entry entry = old_table[i];
+		if (entry.ptr == NULL) {
+			continue;
+		}
+		if (entry.released && reaped != NULL) {
+			*reaped++ = entry.ptr;
+		} else {
+			grb_borrowed_put(entry);
+		}
+	}
+	free(old_table);
+	return true;
+}
+
+static bool grb_borrowed_insert(void *ptr, size_t size) {
+	bool ok = true;
+	pthread_mutex_lock(&grb_borrowed_mutex);
+	size_t count = atomic_load(&grb_borrowed_count);
+	if (2 * (count + 1) > grb_borrowed_capacity) {
+		size_t capacity = grb_borrowed_capacity == 0 ? 64 : 2 * grb_borrowed_capacity;
+		ok = grb_borrowed_rebuild(capacity, NULL);
+	}
+	if (ok) {
+		grb_borrowed_put((grb_borrowed_entry){ptr, size, false});
+		atomic_fetch_add(&grb_borrowed_count, 1);
+	}
+	pthread_mutex_unlock(&grb_borrowed_mutex);
+	return ok;
+}
+
+// grb_borrowed_size reports whether ptr is borrowed memory that has not been released yet,
+// and if so, stores its size in *size.
+static bool grb_borrowed_size(void *ptr, size_t *size) {
+	if (atomic_load(&grb_borrowed_count) == 0) {
+		return false;
+	}
+	pthread_mutex_lock(&grb_borrowed_mutex);
+	grb_borrowed_entry *entry = grb_borrowed_find(ptr);
+	bool found = entry != NULL && !entry->released;
+	if (found) {
+		*size = entry->size;
+	}
+	pthread_mutex_unlock(&grb_borrowed_mutex);
+	return found;
+}
+
+// grb_borrowed_release marks ptr as released if it is borrowed memory,
+// and reports whether this is the case.
+static bool grb_borrowed_release(void *ptr) {
+	if (atomic_load(&grb_borrowed_count) == 0) {
+		return false;
+	}
+	pthread_mutex_lock(&grb_borrowed_mutex);
+	grb_borrowed_entry *entry = grb_borrowed_find(ptr);
+	bool found = entry != NULL;
+	if (found && !entry->released) {
+		entry->released = true;
+		atomic_fetch_add(&grb_borrowed_released, 1);
+	}
+	pthread_mutex_unlock(&grb_borrowed_mutex);
+	return found;
+}
+
+static size_t grb_borrowed_pending(void) {
+	return atomic_load(&grb_borrowed_released);
+}
+
+// grb_borrowed_reap removes all released entries from the table, and stores their
+// pointers in reaped, which must have room for at least grb_borrowed_pending() entries.
+// It returns the number of pointers stored in reaped.
+static size_t grb_borrowed_reap(void **reaped, size_t n) {
+	pthread_mutex_lock(&grb_borrowed_mutex);
+	size_t released = atomic_load(&grb_borrowed_released);
+	if (released == 0 || released > n || !grb_borrowed_rebuild(grb_borrowed_capacity, reaped)) {
+		pthread_mutex_unlock(&grb_borrowed_mutex);
+		return 0;
+	}
+	atomic_fetch_sub(&grb_borrowed_count, released);
+	atomic_store(&grb_borrowed_released, 0);
+	pthread_mutex_unlock(&grb_borrowed_mutex);
+	return released;
+}
+
+static void *grb_raw_malloc(size_t size) {
+	if (grb_user_malloc != NULL) {
+		return grb_user_malloc(size);
+	}
+	return malloc(size);
+}
+
+static void *grb_raw_calloc(size_t num, size_t size) {
+	if (grb_user_calloc != NULL) {
+		return grb_user_calloc(num, size);
+	}
+	if (grb_user_malloc != NULL) {
+		void *ptr = grb_user_malloc(num * size);
+		if (ptr != NULL) {
+			memset(ptr, 0, num * size);
+		}
+		return ptr;
+	}
+	return calloc(num, size);
+}
+
+static void *grb_raw_realloc(void *ptr, size_t size) {
+	if (grb_user_realloc != NULL) {
+		return grb_user_realloc(ptr, size);
+	}
+	return realloc(ptr, size);
+}
+
+static void grb_raw_free(void *ptr) {
+	if (grb_user_free != NULL) {
+		grb_user_free(ptr);
+	} else {
+		free(ptr);
+	}
+}
+
+// With memory accounting, each allocated block is preceded by a header that records
+// its size. The header is as large as max_align_t, so that the memory returned to
+// GraphBLAS is suitably aligned for any type.
+
+typedef union {
+	size_t size;
+	max_align_t align;
+} grb_header;
+
+static bool grb_accounting = false;
+static atomic_size_t grb_unaccounted = 0; // live blocks allocated without a header
+static atomic_size_t grb_budget = 0;
+static atomic_size_t grb_live = 0;
+static atomic_size_t grb_peak = 0;
+static atomic_size_t grb_allocations = 0;
+static atomic_size_t grb_frees = 0;
+
+// grb_reserve accounts for size additional live bytes, unless this exceeds the budget.
+static bool grb_reserve(size_t size) {
+	size_t budget = atomic_load(&grb_budget);
+	size_t live = atomic_fetch_add(&grb_live, size) + size;
+	if (budget != 0 && live > budget) {
+		atomic_fetch_sub(&grb_live, size);
+		return false;
+	}
+	size_t peak = atomic_load(&grb_peak);
+	while (live > peak && !atomic_compare_exchange_weak(&grb_peak, &peak, live)) {
+	}
+	return true;
+}
+
+static void *grb_account(grb_header *header, size_t size) {
+	if (header == NULL) {
+		atomic_fetch_sub(&grb_live, size);
+		return NULL;
+	}
+	header->size = size;
+	atomic_fetch_add(&grb_allocations, 1);
+	return header + 1;
+}
+
+// grb_too_large reports whether a block of size bytes cannot be allocated with a header.
+static bool grb_too_large(size_t size) {
+	return size > SIZE_MAX - sizeof(grb_header);
+}
+
+// grb_unaccounted_add counts a block allocated without a header, which must never be
+// freed by the accounting allocator.
+static void *grb_unaccounted_add(void *ptr) {
+	if (ptr != NULL) {
+		atomic_fetch_add(&grb_unaccounted, 1);
+	}
+	return ptr;
+}
+
+static void *grb_malloc(size_t size) {
+	if (!grb_accounting) {
+		return grb_unaccounted_add(grb_raw_malloc(size));
+	}
+	if (grb_too_large(size) || !grb_reserve(size)) {
+		return NULL;
+	}
+	return grb_account(grb_raw_malloc(sizeof(grb_header) + size), size);
+}
+
+static void *grb_calloc(size_t num, size_t size) {
+	if (!grb_accounting) {
+		return grb_unaccounted_add(grb_raw_calloc(num, size));
+	}
+	if (num != 0 && size > SIZE_MAX / num) {
+		return NULL;
+	}
+	size *= num;
+	if (grb_too_large(size) || !grb_reserve(size)) {
+		return NULL;
+	}
+	return grb_account(grb_raw_calloc(1, sizeof(grb_header) + size), size);
+}
+
+static void grb_free(void *ptr) {
+	if (ptr == NULL || grb_borrowed_release(ptr)) {
+		return;
+	}
+	if (!grb_accounting) {
+		atomic_fetch_sub(&grb_unaccounted, 1);
+		grb_raw_free(ptr);
+		return;
+	}
+	grb_header *header = (grb_header *)ptr - 1;
+	atomic_fetch_sub(&grb_live, header->size);
+	atomic_fetch_add(&grb_frees, 1);
+	grb_raw_free(header);
+}
+
+static void *grb_realloc(void *ptr, size_t size) {
+	size_t old_size;
+	if (ptr != NULL && grb_borrowed_size(ptr, &old_size)) {
+		void *new_ptr = grb_malloc(size);
+		if (new_ptr != NULL) {
+			memcpy(new_ptr, ptr, old_size < size ? old_size : size);
+			grb_borrowed_release(ptr);
+		}
+		return new_ptr;
+	}
+	if (!grb_accounting) {
+		if (ptr == NULL) {
+			return grb_unaccounted_add(grb_raw_realloc(ptr, size));
+		}
+		return grb_raw_realloc(ptr, size);
+	}
+	if (ptr == NULL) {
+		return grb_malloc(size);
+	}
+	if (grb_too_large(size)) {
+		return NULL;
+	}
+	grb_header *header = (grb_header *)ptr - 1;
+	old_size = header->size;
+	if (size > old_size && !grb_reserve(size - old_size)) {
+		return NULL;
+	}
+	grb_header *new_header = grb_raw_realloc(header, sizeof(grb_header) + size);
+	if (new_header == NULL) {
+		if (size > old_size) {
+			atomic_fetch_sub(&grb_live, size - old_size);
+		}
+		return NULL;
+	}
+	if (size < old_size) {
+		atomic_fetch_sub(&grb_live, old_size - size);
+	}
+	new_header->size = size;
+	return new_header + 1;
+}
+
+static GrB_Info grb_init(
+	GrB_Mode mode,
+	user_malloc_function umalloc,
+	user_calloc_function ucalloc,
+	user_realloc_function urealloc,
+	user_free_function ufree,
+	bool accounting,
+	size_t budget
+) {
+	// Memory allocated without accounting, for example by MakeSystemSlice before
+	// initialization, has no header, and could not be freed by the accounting allocator.
+	if (accounting && atomic_load(&grb_unaccounted) != 0) {
+		return GrB_INVALID_VALUE;
+	}
+	user_malloc_function old_malloc = grb_user_malloc;
+	user_calloc_function old_calloc = grb_user_calloc;
+	user_realloc_function old_realloc = grb_user_realloc;
+	user_free_function old_free = grb_user_free;
+	bool old_accounting = grb_accounting;
+	grb_user_malloc = umalloc;
+	grb_user_calloc = ucalloc;
+	grb_user_realloc = urealloc;
+	grb_user_free = ufree;
+	grb_accounting = accounting;
+	atomic_store(&grb_budget, budget);
+	// If a user-defined malloc function is given without a realloc function,
+	// GraphBLAS must be told that there is no realloc function, so that it
+	// falls back to malloc, memcpy and free.
+	bool has_realloc = urealloc != NULL || umalloc == NULL;
+	GrB_Info info = GxB_init(mode, grb_malloc, grb_calloc, has_realloc ? grb_realloc : NULL, grb_free);
+	if (info != GrB_SUCCESS) {
+		// for example if GraphBLAS has already been initialized, keep the previous allocator
+		grb_user_malloc = old_malloc;
+		grb_user_calloc = old_calloc;
+		grb_user_realloc = old_realloc;
+		grb_user_free = old_free;
+		grb_accounting = old_accounting;
+	}
+	return info;
+}
+
+static void grb_set_budget(size_t budget) {
+	atomic_store(&grb_budget, budget);
+}
+
+static void grb_reset_peak(void) {
+	atomic_store(&grb_peak, atomic_load(&grb_live));
+}
+
+typedef struct {
+	size_t live, peak, allocations, frees, budget;
+} grb_memory_stats;
+
+static grb_memory_stats grb_get_memory_stats(void) {
+	grb_memory_stats stats = {
+		atomic_load(&grb_live),
+		atomic_load(&grb_peak),
+		atomic_load(&grb_allocations),
+		atomic_load(&grb_frees),
+		atomic_load(&grb_budget),
+	};
+	return stats;
+}
+*/
+import "C"
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+func initMemory(
+	mode Mode,
+	malloc UserMallocFunction,
+	calloc UserCallocFunction,
+	realloc UserReallocFunction,
+	free UserFreeFunction,
+	accounting bool,
+	budget int,
+) Info {
+	if budget < 0 {
+		return InvalidValue
+	}
+	return Info(C.grb_init(
+		C.GrB_Mode(mode),
+		C.user_malloc_function(malloc),
+		C.user_calloc_function(calloc),
+		C.user_realloc_function(realloc),
+		C.user_free_function(free),
+		C.bool(accounting),
+		C.size_t(budget),
+	))
+}
+
+func calloc(size int) unsafe.Pointer {
+	ptr := C.grb_calloc(1, C.size_t(size))
+	if ptr == nil && size > 0 {
+		panic(OutOfMemory.Error())
+	}
+	return ptr
+}
+
+func free(ptr unsafe.Pointer) {
+	C.grb_free(ptr)
+	reapBorrowed()
+}
+
+var borrowed struct {
+	sync.Mutex
+	pinners map[unsafe.Pointer]*runtime.Pinner
+}
+
+// BorrowSlice returns a [SystemSlice] that shares its memory with the given Go slice,
+// without copying it. The resulting system slice can be passed to any of the Pack
+// methods, so that large arrays produced by Go code can be moved into GraphBLAS
+// objects without doubling peak memory usage.
+//
+// The memory of s is pinned with [runtime.Pinner], so that it stays valid while
+// GraphBLAS holds on to it. When GraphBLAS or [SystemSlice.Free] eventually releases
+// the memory, it is not passed to the memory management functions registered with
+// [Init] or [InitWithMalloc]. Instead, it is only unpinned on the next call to
+// [SystemSlice.Free], [Matrix.Free], [Vector.Free], or BorrowSlice, after which the
+// Go garbage collector is in charge of it again. If GraphBLAS needs to resize borrowed
+// memory, it moves the content to newly allocated memory instead.
+//
+// As with [SystemSlice.UnsafeSlice], s and the resulting system slice share the same
+// memory, so the content of s must not be modified while it is borrowed. Conversely,
+// GraphBLAS may modify the content of s when the GraphBLAS object that owns it is
+// modified. The type T must not directly or indirectly contain any Go pointers.
+// This is not checked.
+//
+// If s is empty, an empty system slice is returned instead. If the memory of s is
+// already borrowed, BorrowSlice panics.
+//
+// BorrowSlice is a forGraphBLASGo extension.
+func BorrowSlice[T any](s []T) SystemSlice[T] {
+	if len(s) == 0 {
+		return MakeSystemSlice[T](0)
+	}
+	reapBorrowed()
+	var x T
+	ptr := unsafe.Pointer(unsafe.SliceData(s))
+	size := len(s) * int(unsafe.Sizeof(x))
+	borrowed.Lock()
+	defer borrowed.Unlock()
+	if _, ok := borrowed.pinners[ptr]; ok {
+		panic("memory is already borrowed")
+	}
+	pinner := new(runtime.Pinner)
+	pinner.Pin(ptr)
+	if !C.grb_borrowed_insert(ptr, C.size_t(size)) {
+		pinner.Unpin()
+		panic(OutOfMemory.Error())
+	}
+	if borrowed.pinners == nil {
+		borrowed.pinners = make(map[unsafe.Pointer]*runtime.Pinner)
+	}
+	borrowed.pinners[ptr] = pinner
+	return SystemSlice[T]{ptr: ptr, size: size}
+}
+
+// reapBorrowed unpins all borrowed memory that has been released by GraphBLAS.
+func reapBorrowed() {
+	if C.grb_borrowed_pending() == 0 {
+		return
+	}
+	borrowed.Lock()
+	defer borrowed.Unlock()
+	n := C.grb_borrowed_pending()
+	if n == 0 {
+		return
+	}
+	buf := C.malloc(n * C.size_t(unsafe.Sizeof(unsafe.Pointer(nil))))
+	if buf == nil {
+		return
+	}
+	defer C.free(buf)
+	reaped := unsafe.Slice((*unsafe.Pointer)(buf), n)
+	n = C.grb_borrowed_reap(&reaped[0], n)
+	for _, ptr := range reaped[:n] {
+		borrowed.pinners[ptr].Unpin()
+		delete(borrowed.pinners, ptr)
+	}
+}
+
+// MemoryUsage describes the memory allocated by GraphBLAS, as reported by [MemoryStats].
+// All sizes are in bytes.
+type MemoryUsage struct {
+	Live        int // the memory currently allocated
+	Peak        int // the highest value of Live since initialization or the last call of [ResetMemoryPeak]
+	Allocations int // the number of allocations since initialization
+	Frees       int // the number of deallocations since initialization
+	Budget      int // the current memory budget, or 0 if there is none
+}
+
+// MemoryStats returns the current memory usage of GraphBLAS. This includes all memory
+// allocated for GraphBLAS objects, their internal workspaces, and [SystemSlice] instances,
+// but not memory borrowed with [BorrowSlice].
+//
+// The memory usage is only tracked if GraphBLAS has been initialized with
+// [InitWithMemoryAccounting]. Otherwise, all fields except Budget are 0.
+//
+// MemoryStats is a forGraphBLASGo extension.
+func MemoryStats() MemoryUsage {
+	stats := C.grb_get_memory_stats()
+	return MemoryUsage{
+		Live:        int(stats.live),
+		Peak:        int(stats.peak),
+		Allocations: int(stats.allocations),
+		Frees:       int(stats.frees),
+		Budget:      int(stats.budget),
+	}
+}
+
+// SetMemoryBudget sets a soft limit on the memory that GraphBLAS may allocate. Once an
+// allocation would exceed the budget, it fails, and the operation that requested it
+// fails with [OutOfMemory]. Since OutOfMemory is an execution error, this causes a panic
+// that can be handled by recover. A budget of 0 removes the limit.
+//
+// The budget is only enforced if GraphBLAS has been initialized with [InitWithMemoryAccounting].
+// Lowering the budget below the current memory usage does not free any memory, but lets all
+// further allocations fail until enough memory has been freed.
+//
+// GraphBLAS API errors that may be returned:
+//   - [InvalidValue]
+//
+// SetMemoryBudget is a forGraphBLASGo extension.
+func SetMemoryBudget(budget int) error {
+	if budget < 0 {
+		return makeError(InvalidValue)
+	}
+	C.grb_set_budget(C.size_t(budget))
+	return nil
+}
+
+// ResetMemoryPeak sets the peak memory usage reported by [MemoryStats] to the current
+// memory usage. This can be used to measure the peak memory usage of individual operations.
+//
+// ResetMemoryPeak is a forGraphBLASGo extension.
+func ResetMemoryPeak() {
+	C.grb_reset_peak()
+}
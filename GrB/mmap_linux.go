@@ -0,0 +1,40 @@
+//go:build linux
+
+package GrB
+
+import (
+	"os"
+	"syscall"
+)
+
+// mapFile maps the named file into memory for reading. The returned function
+// must be called to unmap the file when the data is not needed anymore.
+func mapFile(name string) (data []byte, unmap func() error, err error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+	info, err := f.Stat()
+	if err != nil {
+		return
+	}
+	size := info.Size()
+	if size == 0 {
+		// mmap does not accept empty mappings
+		return nil, func() error { return nil }, nil
+	}
+	if int64(int(size)) != size {
+		return nil, nil, &os.PathError{Op: "mmap", Path: name, Err: syscall.EFBIG}
+	}
+	if data, err = syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_PRIVATE); err != nil {
+		return nil, nil, &os.PathError{Op: "mmap", Path: name, Err: err}
+	}
+	return data, func() error {
+		return syscall.Munmap(data)
+	}, nil
+}
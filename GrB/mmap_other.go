@@ -0,0 +1,14 @@
+//go:build !linux
+
+package GrB
+
+import "os"
+
+// mapFile reads the named file into memory. The returned function does nothing,
+// but must be called when the data is not needed anymore, as on Linux.
+func mapFile(name string) (data []byte, unmap func() error, err error) {
+	if data, err = os.ReadFile(name); err != nil {
+		return
+	}
+	return data, func() error { return nil }, nil
+}
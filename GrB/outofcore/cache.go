@@ -0,0 +1,75 @@
+package outofcore
+
+import (
+	"container/list"
+	"github.com/intel/forGraphBLASGo/GrB"
+)
+
+// cache holds the most recently used tiles of a matrix, up to a total memory usage of capacity bytes.
+type cache[D any] struct {
+	capacity, size int
+	entries        map[int]*list.Element
+	lru            list.List // of *entry[D], the most recently used at the front
+}
+
+type entry[D any] struct {
+	key, size int
+	tile      GrB.Matrix[D]
+}
+
+func newCache[D any](capacity int) cache[D] {
+	return cache[D]{capacity: capacity, entries: make(map[int]*list.Element)}
+}
+
+func (c *cache[D]) get(key int) (tile GrB.Matrix[D], ok bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		return
+	}
+	c.lru.MoveToFront(element)
+	return element.Value.(*entry[D]).tile, true
+}
+
+// put adds a tile to the cache, and evicts the least recently used tiles while the cache holds
+// more than one tile and exceeds its capacity. The cache takes ownership of the tile even if put
+// fails: the tile is freed if it cannot be added, and stays in the cache if an eviction fails.
+func (c *cache[D]) put(key int, tile GrB.Matrix[D]) (err error) {
+	size, err := tile.MemoryUsage()
+	if err == nil {
+		err = c.remove(key)
+	}
+	if err != nil {
+		_ = tile.Free()
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&entry[D]{key: key, size: size, tile: tile})
+	c.size += size
+	for c.size > c.capacity && c.lru.Len() > 1 {
+		if err = c.remove(c.lru.Back().Value.(*entry[D]).key); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// remove frees the tile with the given key, if it is in the cache.
+func (c *cache[D]) remove(key int) error {
+	element, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	e := c.lru.Remove(element).(*entry[D])
+	delete(c.entries, key)
+	c.size -= e.size
+	return e.tile.Free()
+}
+
+// clear frees all tiles in the cache.
+func (c *cache[D]) clear() (err error) {
+	for key := range c.entries {
+		if rerr := c.remove(key); err == nil {
+			err = rerr
+		}
+	}
+	return
+}
@@ -0,0 +1,177 @@
+package outofcore_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"github.com/intel/forGraphBLASGo/GrB/outofcore"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func Example() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	dir, err := os.MkdirTemp("", "outofcore")
+	OK(err)
+	defer func() {
+		OK(os.RemoveAll(dir))
+	}()
+
+	// the undirected graph with 15 vertices used in the examples of the GrB package,
+	// with edge weights
+	A, err := GrB.MatrixNew[int](15, 15)
+	OK(err)
+	defer func() {
+		OK(A.Free())
+	}()
+	edges := [][2]int{
+		{0, 1}, {0, 2}, {0, 3}, {0, 4}, {1, 3}, {1, 5}, {1, 6}, {2, 4}, {2, 7},
+		{2, 8}, {3, 9}, {3, 10}, {4, 11}, {4, 12}, {6, 13}, {7, 13}, {10, 14}, {11, 14},
+	}
+	for _, e := range edges {
+		OK(A.SetElement(e[0]+e[1], e[0], e[1]))
+		OK(A.SetElement(e[0]-e[1], e[1], e[0]))
+	}
+
+	// store A in 4 x 4 tiles, and keep only a single tile in memory
+	opts := outofcore.Options{TileNrows: 4, TileNcols: 4, Compression: GrB.CompressionZSTD}
+	D, err := outofcore.FromMatrix(filepath.Join(dir, "A"), A, opts)
+	OK(err)
+	defer func() {
+		OK(D.Close())
+	}()
+	fmt.Println(D.Tiles())
+
+	// the matrix can be reopened, and loaded into memory as a whole
+	R, err := outofcore.Open[int](filepath.Join(dir, "A"), opts)
+	OK(err)
+	defer func() {
+		OK(R.Close())
+	}()
+	B, err := R.ToMatrix()
+	OK(err)
+	defer func() {
+		OK(B.Free())
+	}()
+	fmt.Println(equal(A, B))
+
+	u, err := GrB.VectorNew[int](15)
+	OK(err)
+	defer func() {
+		OK(u.Free())
+	}()
+	for i := range 15 {
+		OK(u.SetElement(i%4-1, i))
+	}
+	w, err := GrB.VectorNew[int](15)
+	OK(err)
+	defer func() {
+		OK(w.Free())
+	}()
+	expected, err := GrB.VectorNew[int](15)
+	OK(err)
+	defer func() {
+		OK(expected.Free())
+	}()
+
+	OK(outofcore.MxV(w, nil, nil, GrB.PlusTimes[int](), D, u, nil))
+	OK(GrB.MxV(expected, nil, nil, GrB.PlusTimes[int](), A, u, nil))
+	fmt.Println("MxV:", equalVectors(w, expected))
+
+	OK(outofcore.VxM(w, nil, nil, GrB.PlusTimes[int](), u, D, nil))
+	OK(GrB.VxM(expected, nil, nil, GrB.PlusTimes[int](), u, A, nil))
+	fmt.Println("VxM:", equalVectors(w, expected))
+
+	OK(outofcore.MatrixReduceRows(w, nil, nil, GrB.MaxMonoid[int](), D, nil))
+	OK(GrB.MatrixReduceMonoid(expected, nil, nil, GrB.MaxMonoid[int](), A, nil))
+	fmt.Println("MatrixReduceRows:", equalVectors(w, expected))
+
+	// C = A + A, stored in a new matrix with the same tiles
+	C, err := outofcore.Create[int](filepath.Join(dir, "C"), 15, 15, opts)
+	OK(err)
+	defer func() {
+		OK(C.Close())
+	}()
+	OK(outofcore.MatrixEWiseAdd(C, GrB.Plus[int](), D, D))
+	sumA, err := outofcore.MatrixReduce(GrB.PlusMonoid[int](), D)
+	OK(err)
+	sumC, err := outofcore.MatrixReduce(GrB.PlusMonoid[int](), C)
+	OK(err)
+	absA, err := outofcore.MatrixReduce(GrB.MaxMonoid[int](), D)
+	OK(err)
+	fmt.Println("MatrixReduce:", sumA, sumC, absA)
+
+	// breadth-first search from vertex 0 on the structure of A
+	pattern, err := GrB.MatrixNew[bool](15, 15)
+	OK(err)
+	defer func() {
+		OK(pattern.Free())
+	}()
+	OK(GrB.MatrixAssignConstant(pattern, A.AsMask(), nil, true, GrB.All(15), GrB.All(15), GrB.DescS))
+	S, err := outofcore.FromMatrix(filepath.Join(dir, "S"), pattern, opts)
+	OK(err)
+	defer func() {
+		OK(S.Close())
+	}()
+	level, err := GrB.VectorNew[int](15)
+	OK(err)
+	defer func() {
+		OK(level.Free())
+	}()
+	q, err := GrB.VectorNew[bool](15)
+	OK(err)
+	defer func() {
+		OK(q.Free())
+	}()
+	OK(q.SetElement(true, 0))
+	for depth := 0; ; depth++ {
+		OK(GrB.VectorAssignConstant(level, q.AsMask(), nil, depth, GrB.All(15), GrB.DescS))
+		OK(outofcore.VxM(q, level.AsMask(), nil, GrB.AnyOneb[bool](), q, S, GrB.DescRSC))
+		if nvals, err := q.Nvals(); err != nil || nvals == 0 {
+			OK(err)
+			break
+		}
+	}
+	levels, err := level.ToDense(-1)
+	OK(err)
+	fmt.Println("BFS:", levels)
+	// Output:
+	// 4 4
+	// true
+	// MxV: true
+	// VxM: true
+	// MatrixReduceRows: true
+	// MatrixReduce: 114 228 25
+	// BFS: [0 1 1 1 1 2 2 2 2 2 2 2 2 3 3]
+}
+
+func equal(A, B GrB.Matrix[int]) bool {
+	var ai, aj, ax, bi, bj, bx []int
+	if A.ExtractTuples(&ai, &aj, &ax) != nil || B.ExtractTuples(&bi, &bj, &bx) != nil {
+		return false
+	}
+	return slices.Equal(ai, bi) && slices.Equal(aj, bj) && slices.Equal(ax, bx)
+}
+
+func equalVectors(u, v GrB.Vector[int]) bool {
+	var ui, ux, vi, vx []int
+	if u.ExtractTuples(&ui, &ux) != nil || v.ExtractTuples(&vi, &vx) != nil {
+		return false
+	}
+	return slices.Equal(ui, vi) && slices.Equal(ux, vx)
+}
@@ -0,0 +1,381 @@
+package outofcore
+
+import (
+	"github.com/intel/forGraphBLASGo/GrB"
+)
+
+// addOperator returns the binary operator of the additive monoid of op.
+func addOperator[DC, DA, DB any](op GrB.Semiring[DC, DA, DB]) (add GrB.BinaryOp[DC, DC, DC], err error) {
+	monoid, err := op.Add()
+	if err != nil {
+		return
+	}
+	return monoid.Operator()
+}
+
+// rowSizes returns the number of rows of the tiles in each row of the grid of tiles.
+func (matrix *Matrix[D]) rowSizes() []int {
+	m, _ := matrix.Tiles()
+	sizes := make([]int, m)
+	for i := range sizes {
+		rowBegin, rowEnd, _, _ := matrix.TileRange(i, 0)
+		sizes[i] = rowEnd - rowBegin
+	}
+	return sizes
+}
+
+// colSizes returns the number of columns of the tiles in each column of the grid of tiles.
+func (matrix *Matrix[D]) colSizes() []int {
+	_, n := matrix.Tiles()
+	sizes := make([]int, n)
+	for j := range sizes {
+		_, _, colBegin, colEnd := matrix.TileRange(0, j)
+		sizes[j] = colEnd - colBegin
+	}
+	return sizes
+}
+
+// splitVector splits u into blocks of the given sizes, as column matrices.
+func splitVector[D any](u GrB.Vector[D], sizes []int) (blocks []GrB.Matrix[D], err error) {
+	defer GrB.CheckErrors(&err)
+	n, err := u.Size()
+	GrB.OK(err)
+	column, err := GrB.MatrixNew[D](n, 1)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(column.Free())
+	}()
+	GrB.OK(GrB.MatrixColAssign(column, nil, nil, u, GrB.All(n), 0, nil))
+	return column.Split(sizes, []int{1}, nil)
+}
+
+// concatVector concatenates the blocks into a vector t of size n, which are column matrices
+// if desc is nil, or row matrices if desc is [GrB.DescT0].
+func concatVector[D any](blocks []GrB.Matrix[D], n int, desc *GrB.Descriptor) (t GrB.Vector[D], err error) {
+	defer GrB.CheckErrors(&err)
+	nrows, ncols, m, k := n, 1, len(blocks), 1
+	if desc != nil {
+		nrows, ncols, m, k = 1, n, 1, len(blocks)
+	}
+	matrix, err := GrB.MatrixNew[D](nrows, ncols)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(matrix.Free())
+	}()
+	GrB.OK(matrix.Concat(blocks, m, k, nil))
+	t, err = GrB.VectorNew[D](n)
+	GrB.OK(err)
+	GrB.OK(GrB.MatrixColExtract(t, nil, nil, matrix, GrB.All(n), 0, desc))
+	return
+}
+
+func freeAll[D any](matrices []GrB.Matrix[D], err *error) {
+	for k := range matrices {
+		if ferr := matrices[k].Free(); *err == nil {
+			*err = ferr
+		}
+	}
+}
+
+// MxV computes w<mask> = accum(w, a * u) on a semiring, like [GrB.MxV], where a is stored on disk.
+// u is split into blocks matching the columns of the tiles of a, and each block of the result is
+// accumulated from the products of the tiles in a row of the grid of tiles with the blocks of u,
+// using the additive monoid of op. Each tile of a is loaded once.
+//
+// Only the mask and output fields of desc are used, as in [GrB.VectorAssign].
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch], [GrB.DomainMismatch], [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func MxV[Dw, DA, Du any](
+	w GrB.Vector[Dw],
+	mask *GrB.Vector[bool],
+	accum *GrB.BinaryOp[Dw, Dw, Dw],
+	op GrB.Semiring[Dw, DA, Du],
+	a *Matrix[DA],
+	u GrB.Vector[Du],
+	desc *GrB.Descriptor,
+) (err error) {
+	defer GrB.CheckErrors(&err)
+	nrows, ncols := a.Size()
+	wsize, err := w.Size()
+	GrB.OK(err)
+	usize, err := u.Size()
+	GrB.OK(err)
+	if wsize != nrows || usize != ncols {
+		return GrB.DimensionMismatch
+	}
+	if nrows == 0 {
+		return
+	}
+	add, err := addOperator(op)
+	GrB.OK(err)
+
+	t, err := GrB.VectorNew[Dw](nrows)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(t.Free())
+	}()
+	if ncols > 0 {
+		m, n := a.Tiles()
+		var uBlocks []GrB.Matrix[Du]
+		tBlocks := make([]GrB.Matrix[Dw], m)
+		defer func() {
+			freeAll(uBlocks, &err)
+			freeAll(tBlocks, &err)
+		}()
+		uBlocks, err = splitVector(u, a.colSizes())
+		GrB.OK(err)
+		for i, size := range a.rowSizes() {
+			tBlocks[i], err = GrB.MatrixNew[Dw](size, 1)
+			GrB.OK(err)
+			for j := range n {
+				tile, err := a.Tile(i, j)
+				GrB.OK(err)
+				var acc *GrB.BinaryOp[Dw, Dw, Dw]
+				if j > 0 {
+					acc = &add
+				}
+				GrB.OK(GrB.MxM(tBlocks[i], nil, acc, op, tile, uBlocks[j], nil))
+			}
+		}
+		GrB.OK(t.Free())
+		t, err = concatVector(tBlocks, nrows, nil)
+		GrB.OK(err)
+	}
+	return GrB.VectorAssign(w, mask, accum, t, GrB.All(nrows), desc)
+}
+
+// VxM computes w<mask> = accum(w, u * a) on a semiring, like [GrB.VxM], where a is stored on disk.
+// u is split into blocks matching the rows of the tiles of a, and each block of the result is
+// accumulated from the products of the blocks of u with the tiles in a column of the grid of tiles,
+// using the additive monoid of op. Each tile of a is loaded once.
+//
+// Only the mask and output fields of desc are used, as in [GrB.VectorAssign].
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch], [GrB.DomainMismatch], [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func VxM[Dw, Du, DA any](
+	w GrB.Vector[Dw],
+	mask *GrB.Vector[bool],
+	accum *GrB.BinaryOp[Dw, Dw, Dw],
+	op GrB.Semiring[Dw, Du, DA],
+	u GrB.Vector[Du],
+	a *Matrix[DA],
+	desc *GrB.Descriptor,
+) (err error) {
+	defer GrB.CheckErrors(&err)
+	nrows, ncols := a.Size()
+	wsize, err := w.Size()
+	GrB.OK(err)
+	usize, err := u.Size()
+	GrB.OK(err)
+	if wsize != ncols || usize != nrows {
+		return GrB.DimensionMismatch
+	}
+	if ncols == 0 {
+		return
+	}
+	add, err := addOperator(op)
+	GrB.OK(err)
+
+	t, err := GrB.VectorNew[Dw](ncols)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(t.Free())
+	}()
+	if nrows > 0 {
+		m, n := a.Tiles()
+		var uBlocks []GrB.Matrix[Du]
+		// the blocks of the result are row matrices, computed as transpose(u(i)) * a(i, j)
+		tBlocks := make([]GrB.Matrix[Dw], n)
+		defer func() {
+			freeAll(uBlocks, &err)
+			freeAll(tBlocks, &err)
+		}()
+		uBlocks, err = splitVector(u, a.rowSizes())
+		GrB.OK(err)
+		for j, size := range a.colSizes() {
+			tBlocks[j], err = GrB.MatrixNew[Dw](1, size)
+			GrB.OK(err)
+		}
+		// the tiles are visited row by row, which is the order in which they are stored
+		for i := range m {
+			for j := range n {
+				tile, err := a.Tile(i, j)
+				GrB.OK(err)
+				var acc *GrB.BinaryOp[Dw, Dw, Dw]
+				if i > 0 {
+					acc = &add
+				}
+				GrB.OK(GrB.MxM(tBlocks[j], nil, acc, op, uBlocks[i], tile, GrB.DescT0))
+			}
+		}
+		GrB.OK(t.Free())
+		t, err = concatVector(tBlocks, ncols, GrB.DescT0)
+		GrB.OK(err)
+	}
+	return GrB.VectorAssign(w, mask, accum, t, GrB.All(ncols), desc)
+}
+
+// checkTiles returns [GrB.DimensionMismatch] unless a and b have the same dimensions and tiles.
+func checkTiles[DA, DB any](a *Matrix[DA], b *Matrix[DB]) error {
+	if a.nrows != b.nrows || a.ncols != b.ncols || a.tileNrows != b.tileNrows || a.tileNcols != b.tileNcols {
+		return GrB.DimensionMismatch
+	}
+	return nil
+}
+
+// eWise computes each tile of c from the corresponding tiles of a and b with the given operation.
+func eWise[DC, DA, DB any](
+	c *Matrix[DC],
+	a *Matrix[DA],
+	b *Matrix[DB],
+	operation func(c GrB.Matrix[DC], a GrB.Matrix[DA], b GrB.Matrix[DB]) error,
+) (err error) {
+	defer GrB.CheckErrors(&err)
+	GrB.OK(checkTiles(c, a))
+	GrB.OK(checkTiles(c, b))
+	m, n := c.Tiles()
+	for i := range m {
+		for j := range n {
+			rowBegin, rowEnd, colBegin, colEnd := c.TileRange(i, j)
+			tile, err := GrB.MatrixNew[DC](rowEnd-rowBegin, colEnd-colBegin)
+			GrB.OK(err)
+			err = func() (err error) {
+				defer GrB.CheckErrors(&err)
+				aTile, err := a.Tile(i, j)
+				GrB.OK(err)
+				bTile, err := b.Tile(i, j)
+				GrB.OK(err)
+				GrB.OK(operation(tile, aTile, bTile))
+				return c.StoreTile(i, j, tile)
+			}()
+			GrB.OK(tile.Free())
+			GrB.OK(err)
+		}
+	}
+	return
+}
+
+// MatrixEWiseAdd computes the element-wise union c = a ⊕ b of two matrices stored on disk,
+// tile by tile. a, b, and c must have the same dimensions and tiles, and c may be the same
+// matrix as a or b. The previous content of c is replaced.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch], [GrB.DomainMismatch]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func MatrixEWiseAdd[DC, DA, DB any](c *Matrix[DC], op GrB.BinaryOp[DC, DA, DB], a *Matrix[DA], b *Matrix[DB]) error {
+	return eWise(c, a, b, func(c GrB.Matrix[DC], a GrB.Matrix[DA], b GrB.Matrix[DB]) error {
+		return GrB.MatrixEWiseAddBinaryOp(c, nil, nil, op, a, b, nil)
+	})
+}
+
+// MatrixEWiseMult is like [MatrixEWiseAdd], except that it computes the element-wise
+// intersection c = a ⊗ b.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch], [GrB.DomainMismatch]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func MatrixEWiseMult[DC, DA, DB any](c *Matrix[DC], op GrB.BinaryOp[DC, DA, DB], a *Matrix[DA], b *Matrix[DB]) error {
+	return eWise(c, a, b, func(c GrB.Matrix[DC], a GrB.Matrix[DA], b GrB.Matrix[DB]) error {
+		return GrB.MatrixEWiseMultBinaryOp(c, nil, nil, op, a, b, nil)
+	})
+}
+
+// MatrixReduce reduces all entries of a matrix stored on disk to a scalar with a monoid.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DomainMismatch]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func MatrixReduce[D any](op GrB.Monoid[D], a *Matrix[D]) (result D, err error) {
+	defer GrB.CheckErrors(&err)
+	m, n := a.Tiles()
+	partials, err := GrB.VectorNew[D](m * n)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(partials.Free())
+	}()
+	for k := range m * n {
+		tile, err := a.Tile(k/n, k%n)
+		GrB.OK(err)
+		partial, err := GrB.MatrixReduce(op, tile, nil)
+		GrB.OK(err)
+		GrB.OK(partials.SetElement(partial, k))
+	}
+	return GrB.VectorReduce(op, partials, nil)
+}
+
+// MatrixReduceRows computes w<mask> = accum(w, reduce(a)), where each row of a matrix stored
+// on disk is reduced to a scalar with a monoid, like [GrB.MatrixReduceMonoid].
+//
+// Only the mask and output fields of desc are used, as in [GrB.VectorAssign].
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch], [GrB.DomainMismatch], [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func MatrixReduceRows[D any](
+	w GrB.Vector[D],
+	mask *GrB.Vector[bool],
+	accum *GrB.BinaryOp[D, D, D],
+	op GrB.Monoid[D],
+	a *Matrix[D],
+	desc *GrB.Descriptor,
+) (err error) {
+	defer GrB.CheckErrors(&err)
+	nrows, _ := a.Size()
+	wsize, err := w.Size()
+	GrB.OK(err)
+	if wsize != nrows {
+		return GrB.DimensionMismatch
+	}
+	if nrows == 0 {
+		return
+	}
+	add, err := op.Operator()
+	GrB.OK(err)
+
+	m, n := a.Tiles()
+	tBlocks := make([]GrB.Matrix[D], m)
+	defer freeAll(tBlocks, &err)
+	for i, size := range a.rowSizes() {
+		tBlocks[i], err = GrB.MatrixNew[D](size, 1)
+		GrB.OK(err)
+		partial, err := GrB.VectorNew[D](size)
+		GrB.OK(err)
+		err = func() (err error) {
+			defer GrB.CheckErrors(&err)
+			for j := range n {
+				tile, err := a.Tile(i, j)
+				GrB.OK(err)
+				var acc *GrB.BinaryOp[D, D, D]
+				if j > 0 {
+					acc = &add
+				}
+				GrB.OK(GrB.MatrixReduceMonoid(partial, nil, acc, op, tile, nil))
+			}
+			return GrB.MatrixColAssign(tBlocks[i], nil, nil, partial, GrB.All(size), 0, nil)
+		}()
+		GrB.OK(partial.Free())
+		GrB.OK(err)
+	}
+	t, err := concatVector(tBlocks, nrows, nil)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(t.Free())
+	}()
+	return GrB.VectorAssign(w, mask, accum, t, GrB.All(nrows), desc)
+}
@@ -0,0 +1,356 @@
+/*
+Package outofcore provides matrices that are stored on disk as a grid of tiles, so that operations
+on them only hold a few tiles in memory at any time. This allows algorithms like PageRank and
+breadth-first search to run on graphs whose adjacency matrices are larger than the main memory
+of a single machine, as long as the vectors of the algorithms fit into memory.
+
+Each tile is stored in a separate file, serialized with [GrB.Matrix.SerializeBlob] using the
+compression method selected in the [Options], and loaded with [GrB.MatrixDeserialize]. Tiles
+without any entries need not be stored at all. Loaded tiles are kept in a cache of configurable
+size. A [Matrix] can be created from a matrix in memory, which is split into tiles with
+[GrB.Matrix.Split], or tile by tile with [Matrix.StoreTile], for matrices that do not fit into
+memory as a whole.
+
+A Matrix must not be used by several goroutines at the same time.
+
+Package outofcore is a forGraphBLASGo extension, and its API is experimental.
+*/
+package outofcore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Options control how a [Matrix] is stored and cached.
+type Options struct {
+	// TileNrows and TileNcols are the dimensions of the tiles. The tiles in the last row
+	// and column of the grid of tiles may be smaller. The dimensions of the tiles are
+	// fixed when a matrix is created, so they are ignored by [Open].
+	TileNrows, TileNcols int
+
+	// Compression is the compression method for the tile files, for example
+	// [GrB.CompressionLZ4]. If Compression is 0, the default method of
+	// SuiteSparse:GraphBLAS is used, which is ZSTD with level 1.
+	Compression GrB.DescValue
+
+	// CacheSize is the maximum amount of memory in bytes for the tiles in the cache, as
+	// reported by [GrB.Matrix.MemoryUsage]. The most recently used tile is always kept,
+	// even if it is larger than CacheSize.
+	CacheSize int
+}
+
+// A Matrix is stored in a directory, which holds the dimensions of the matrix and its tiles
+// in a metadata file, and one file for each tile with at least one entry.
+type Matrix[D any] struct {
+	dir                  string
+	nrows, ncols         int
+	tileNrows, tileNcols int
+	desc                 GrB.Descriptor
+	cache                cache[D]
+}
+
+// metadata is stored in the metadata file of a matrix.
+type metadata struct {
+	Nrows     int `json:"nrows"`
+	Ncols     int `json:"ncols"`
+	TileNrows int `json:"tileNrows"`
+	TileNcols int `json:"tileNcols"`
+}
+
+const metadataFile = "matrix.json"
+
+// Create creates a new nrows x ncols matrix without any entries in the directory dir,
+// which is created if necessary, and must not already hold a matrix.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidValue]: A dimension is negative, or a tile dimension is not positive.
+func Create[D any](dir string, nrows, ncols int, opts Options) (matrix *Matrix[D], err error) {
+	if nrows < 0 || ncols < 0 || opts.TileNrows <= 0 || opts.TileNcols <= 0 {
+		return nil, GrB.InvalidValue
+	}
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return
+	}
+	data, err := json.Marshal(metadata{nrows, ncols, opts.TileNrows, opts.TileNcols})
+	if err != nil {
+		return
+	}
+	f, err := os.OpenFile(filepath.Join(dir, metadataFile), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return
+	}
+	return open[D](dir, nrows, ncols, opts.TileNrows, opts.TileNcols, opts)
+}
+
+// Open opens a matrix that has been created in the directory dir. The tile dimensions
+// in opts are ignored.
+func Open[D any](dir string, opts Options) (matrix *Matrix[D], err error) {
+	data, err := os.ReadFile(filepath.Join(dir, metadataFile))
+	if err != nil {
+		return
+	}
+	var meta metadata
+	if err = json.Unmarshal(data, &meta); err != nil {
+		return
+	}
+	if meta.Nrows < 0 || meta.Ncols < 0 || meta.TileNrows <= 0 || meta.TileNcols <= 0 {
+		return nil, fmt.Errorf("%v: invalid metadata", dir)
+	}
+	return open[D](dir, meta.Nrows, meta.Ncols, meta.TileNrows, meta.TileNcols, opts)
+}
+
+func open[D any](dir string, nrows, ncols, tileNrows, tileNcols int, opts Options) (matrix *Matrix[D], err error) {
+	desc, err := GrB.DescriptorNew()
+	if err != nil {
+		return
+	}
+	if err = desc.Set(GrB.Compression, opts.Compression); err != nil {
+		_ = desc.Free()
+		return
+	}
+	return &Matrix[D]{
+		dir:       dir,
+		nrows:     nrows,
+		ncols:     ncols,
+		tileNrows: tileNrows,
+		tileNcols: tileNcols,
+		desc:      desc,
+		cache:     newCache[D](opts.CacheSize),
+	}, nil
+}
+
+// FromMatrix creates a new matrix in the directory dir with the same content as a,
+// by splitting a into tiles.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidValue], [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func FromMatrix[D any](dir string, a GrB.Matrix[D], opts Options) (matrix *Matrix[D], err error) {
+	nrows, ncols, err := a.Size()
+	if err != nil {
+		return
+	}
+	if matrix, err = Create[D](dir, nrows, ncols, opts); err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = matrix.Close()
+			matrix = nil
+		}
+	}()
+	m, n := matrix.Tiles()
+	if m == 0 || n == 0 {
+		return
+	}
+	rowSizes, colSizes := make([]int, m), make([]int, n)
+	for i := range rowSizes {
+		rowBegin, rowEnd, _, _ := matrix.TileRange(i, 0)
+		rowSizes[i] = rowEnd - rowBegin
+	}
+	for j := range colSizes {
+		_, _, colBegin, colEnd := matrix.TileRange(0, j)
+		colSizes[j] = colEnd - colBegin
+	}
+	tiles, err := a.Split(rowSizes, colSizes, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		for k := range tiles {
+			if ferr := tiles[k].Free(); err == nil {
+				err = ferr
+			}
+		}
+	}()
+	for k, tile := range tiles {
+		if err = matrix.StoreTile(k/n, k%n, tile); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Close releases the tiles in the cache. The files of the matrix remain on disk.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.Panic]
+func (matrix *Matrix[D]) Close() error {
+	err := matrix.cache.clear()
+	if ferr := matrix.desc.Free(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+// Size returns the dimensions of the matrix.
+func (matrix *Matrix[D]) Size() (nrows, ncols int) {
+	return matrix.nrows, matrix.ncols
+}
+
+// Tiles returns the number of rows and columns of the grid of tiles.
+func (matrix *Matrix[D]) Tiles() (m, n int) {
+	return (matrix.nrows + matrix.tileNrows - 1) / matrix.tileNrows, (matrix.ncols + matrix.tileNcols - 1) / matrix.tileNcols
+}
+
+// TileRange returns the rows rowBegin..rowEnd-1 and columns colBegin..colEnd-1 of the
+// matrix that are held by tile (i, j).
+func (matrix *Matrix[D]) TileRange(i, j int) (rowBegin, rowEnd, colBegin, colEnd int) {
+	rowBegin, colBegin = i*matrix.tileNrows, j*matrix.tileNcols
+	return rowBegin, min(rowBegin+matrix.tileNrows, matrix.nrows), colBegin, min(colBegin+matrix.tileNcols, matrix.ncols)
+}
+
+func (matrix *Matrix[D]) tileFile(i, j int) string {
+	return filepath.Join(matrix.dir, fmt.Sprintf("tile-%v-%v.grb", i, j))
+}
+
+// Tile returns tile (i, j) of the matrix, which is loaded from disk unless it is in the cache.
+// The tile belongs to the cache: It must neither be modified nor freed, and it is only valid
+// until the next operation on the matrix.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidIndex], [GrB.DomainMismatch]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func (matrix *Matrix[D]) Tile(i, j int) (tile GrB.Matrix[D], err error) {
+	m, n := matrix.Tiles()
+	if i < 0 || i >= m || j < 0 || j >= n {
+		err = GrB.InvalidIndex
+		return
+	}
+	key := i*n + j
+	if tile, ok := matrix.cache.get(key); ok {
+		return tile, nil
+	}
+	data, err := os.ReadFile(matrix.tileFile(i, j))
+	if errors.Is(err, fs.ErrNotExist) {
+		rowBegin, rowEnd, colBegin, colEnd := matrix.TileRange(i, j)
+		tile, err = GrB.MatrixNew[D](rowEnd-rowBegin, colEnd-colBegin)
+	} else if err == nil {
+		tile, err = GrB.MatrixDeserialize[D](data)
+	}
+	if err != nil {
+		return
+	}
+	if err = matrix.cache.put(key, tile); err != nil {
+		// the cache owns the tile, even if put fails
+		tile = GrB.Matrix[D]{}
+	}
+	return
+}
+
+// StoreTile replaces tile (i, j) of the matrix with the given tile, which must have the dimensions
+// returned by [Matrix.TileRange]. The tile is written to disk, and not retained by the matrix.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch], [GrB.InvalidIndex], [GrB.UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func (matrix *Matrix[D]) StoreTile(i, j int, tile GrB.Matrix[D]) (err error) {
+	m, n := matrix.Tiles()
+	if i < 0 || i >= m || j < 0 || j >= n {
+		return GrB.InvalidIndex
+	}
+	nrows, ncols, err := tile.Size()
+	if err != nil {
+		return
+	}
+	rowBegin, rowEnd, colBegin, colEnd := matrix.TileRange(i, j)
+	if nrows != rowEnd-rowBegin || ncols != colEnd-colBegin {
+		return GrB.DimensionMismatch
+	}
+	nvals, err := tile.Nvals()
+	if err != nil {
+		return
+	}
+	if err = matrix.cache.remove(i*n + j); err != nil {
+		return
+	}
+	name := matrix.tileFile(i, j)
+	if nvals == 0 {
+		// Empty tiles are not stored, which also avoids that SuiteSparse:GraphBLAS
+		// fails to deserialize some of them, as described under "Known issues" in [GrB].
+		if err = os.Remove(name); errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+		return
+	}
+	blob, err := tile.SerializeBlob(&matrix.desc)
+	if err != nil {
+		return
+	}
+	defer blob.Free()
+	// write to a temporary file first, so that the tile file is never partially written
+	f, err := os.CreateTemp(matrix.dir, filepath.Base(name)+".*")
+	if err != nil {
+		return
+	}
+	_, err = f.Write(blob.UnsafeSlice())
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), name)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
+	return
+}
+
+// ToMatrix loads all tiles, and concatenates them into a single matrix in memory.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DomainMismatch]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.InvalidObject], [GrB.OutOfMemory], [GrB.Panic]
+func (matrix *Matrix[D]) ToMatrix() (a GrB.Matrix[D], err error) {
+	if a, err = GrB.MatrixNew[D](matrix.nrows, matrix.ncols); err != nil {
+		return
+	}
+	m, n := matrix.Tiles()
+	if m == 0 || n == 0 {
+		return
+	}
+	// the tiles are copied, since they may be evicted from the cache while loading the others
+	tiles := make([]GrB.Matrix[D], m*n)
+	defer func() {
+		for k := range tiles {
+			if ferr := tiles[k].Free(); err == nil {
+				err = ferr
+			}
+		}
+		if err != nil {
+			_ = a.Free()
+		}
+	}()
+	for k := range tiles {
+		tile, err := matrix.Tile(k/n, k%n)
+		if err != nil {
+			return a, err
+		}
+		if tiles[k], err = tile.Dup(); err != nil {
+			return a, err
+		}
+	}
+	err = a.Concat(tiles, m, n, nil)
+	return
+}
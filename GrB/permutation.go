@@ -0,0 +1,265 @@
+package GrB
+
+// A Permutation of the indices 0..n-1, represented by a full vector of size n, where p(k) = i
+// means that row or column i of the original matrix or vector becomes row or column k
+// of the permuted matrix or vector. In terms of the permutation matrix P, with P(k, p(k)) = 1,
+// permuting the rows of A yields P*A, permuting the columns of A yields A*Pᵀ, and permuting
+// both yields P*A*Pᵀ.
+//
+// A Permutation must be freed with [Vector.Free] when it is not needed anymore.
+//
+// Permutation is a forGraphBLASGo extension.
+type Permutation struct {
+	Vector[int]
+}
+
+// PermutationNew creates the identity permutation of the indices 0..n-1.
+//
+// GraphBLAS API errors that may be returned:
+//   - [InvalidValue]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [OutOfMemory], [Panic]
+//
+// PermutationNew is a forGraphBLASGo extension.
+func PermutationNew(n int) (perm Permutation, err error) {
+	if perm.Vector, err = VectorNew[int](n); err != nil {
+		return
+	}
+	if err = VectorAssignConstant(perm.Vector, nil, nil, 0, All(n), nil); err == nil {
+		err = VectorApplyIndexOp(perm.Vector, nil, nil, RowIndex[int, int](), perm.Vector, 0, nil)
+	}
+	if err != nil {
+		_ = perm.Free()
+	}
+	return
+}
+
+// PermutationFromSlice creates a permutation from a slice that holds each of the
+// indices 0..len(p)-1 exactly once.
+//
+// GraphBLAS API errors that may be returned:
+//   - [InvalidValue]: p is not a permutation.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [OutOfMemory], [Panic]
+//
+// PermutationFromSlice is a forGraphBLASGo extension.
+func PermutationFromSlice(p []int) (perm Permutation, err error) {
+	if err = checkPermutation(p); err != nil {
+		return
+	}
+	perm.Vector, err = VectorFromDense(p)
+	return
+}
+
+// checkPermutation returns [InvalidValue] unless p holds each of the indices 0..len(p)-1 exactly once.
+func checkPermutation(p []int) error {
+	seen := make([]bool, len(p))
+	for _, i := range p {
+		if i < 0 || i >= len(p) || seen[i] {
+			return makeError(InvalidValue)
+		}
+		seen[i] = true
+	}
+	return nil
+}
+
+// Indices returns the permutation as a slice, where the value at position k is p(k).
+//
+// GraphBLAS API errors that may be returned:
+//   - [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [InvalidObject], [OutOfMemory], [Panic]
+//
+// Indices is a forGraphBLASGo extension.
+func (perm Permutation) Indices() (p []int, err error) {
+	err = perm.ExtractTuples(nil, &p)
+	return
+}
+
+// Inverse returns the inverse permutation q, with q(p(k)) = k. Permuting with q undoes
+// the effect of permuting with p. In terms of permutation matrices, the permutation matrix
+// of q is Pᵀ.
+//
+// GraphBLAS API errors that may be returned:
+//   - [InvalidValue]: perm is not a permutation.
+//   - [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [InvalidObject], [OutOfMemory], [Panic]
+//
+// Inverse is a forGraphBLASGo extension.
+func (perm Permutation) Inverse() (inverse Permutation, err error) {
+	n, err := perm.Size()
+	if err != nil {
+		return
+	}
+	var indices, values []int
+	if err = perm.ExtractTuples(&indices, &values); err != nil {
+		return
+	}
+	if len(values) != n {
+		err = makeError(InvalidValue)
+		return
+	}
+	if err = checkPermutation(values); err != nil {
+		return
+	}
+	if inverse.Vector, err = VectorNew[int](n); err != nil {
+		return
+	}
+	if err = inverse.Build(values, indices, nil); err != nil {
+		_ = inverse.Free()
+	}
+	return
+}
+
+// Matrix returns the n x n permutation matrix P, with P(k, p(k)) = true.
+//
+// GraphBLAS API errors that may be returned:
+//   - [InvalidValue]: perm is not a permutation.
+//   - [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [InvalidObject], [OutOfMemory], [Panic]
+//
+// Matrix is a forGraphBLASGo extension.
+func (perm Permutation) Matrix() (p Matrix[bool], err error) {
+	n, err := perm.Size()
+	if err != nil {
+		return
+	}
+	var rows, cols []int
+	if err = perm.ExtractTuples(&rows, &cols); err != nil {
+		return
+	}
+	if len(rows) != n {
+		err = makeError(InvalidValue)
+		return
+	}
+	if err = checkPermutation(cols); err != nil {
+		return
+	}
+	if p, err = MatrixNew[bool](n, n); err != nil {
+		return
+	}
+	values := make([]bool, n)
+	for k := range values {
+		values[k] = true
+	}
+	if err = p.Build(rows, cols, values, nil); err != nil {
+		_ = p.Free()
+	}
+	return
+}
+
+// permutationIndices returns the indices of perm for use with [MatrixExtract] and [VectorExtract],
+// or [All] if perm is nil. Since the vector of a Permutation can be modified freely, the indices
+// are checked to be a permutation, so that rows or columns are not silently duplicated or lost.
+func permutationIndices(perm *Permutation, n int) ([]int, error) {
+	if perm == nil {
+		return All(n), nil
+	}
+	size, err := perm.Size()
+	if err != nil {
+		return nil, err
+	}
+	if size != n {
+		return nil, makeError(DimensionMismatch)
+	}
+	p, err := perm.Indices()
+	if err != nil {
+		return nil, err
+	}
+	if len(p) != n {
+		return nil, makeError(InvalidValue)
+	}
+	if err = checkPermutation(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Permute returns a new matrix C = A(rowPerm, colPerm), that is, C(i, j) = A(rowPerm(i), colPerm(j)),
+// or P*A*Qᵀ in terms of the permutation matrices P and Q of rowPerm and colPerm. If rowPerm
+// or colPerm is nil, the rows or columns are not permuted, respectively.
+//
+// GraphBLAS API errors that may be returned:
+//   - [DimensionMismatch]: The size of rowPerm or colPerm does not match the dimensions of the matrix.
+//   - [InvalidValue]: rowPerm or colPerm is not a permutation.
+//   - [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [InvalidObject], [OutOfMemory], [Panic]
+//
+// Permute is a forGraphBLASGo extension.
+func (matrix Matrix[D]) Permute(rowPerm, colPerm *Permutation) (permuted Matrix[D], err error) {
+	nrows, ncols, err := matrix.Size()
+	if err != nil {
+		return
+	}
+	rows, err := permutationIndices(rowPerm, nrows)
+	if err != nil {
+		return
+	}
+	cols, err := permutationIndices(colPerm, ncols)
+	if err != nil {
+		return
+	}
+	if permuted, err = MatrixNew[D](nrows, ncols); err != nil {
+		return
+	}
+	if err = MatrixExtract(permuted, nil, nil, matrix, rows, cols, nil); err != nil {
+		_ = permuted.Free()
+	}
+	return
+}
+
+// PermuteSymmetric returns a new matrix C = P*A*Pᵀ = A(perm, perm), permuting the rows and
+// columns of a square matrix in the same way. If A is the adjacency matrix of a graph, C is
+// the adjacency matrix of the same graph, where vertex perm(k) of A is renamed to vertex k.
+//
+// GraphBLAS API errors that may be returned:
+//   - [DimensionMismatch]: The matrix is not square, or its size does not match the size of perm.
+//   - [InvalidValue]: perm is not a permutation.
+//   - [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [InvalidObject], [OutOfMemory], [Panic]
+//
+// PermuteSymmetric is a forGraphBLASGo extension.
+func (matrix Matrix[D]) PermuteSymmetric(perm Permutation) (Matrix[D], error) {
+	return matrix.Permute(&perm, &perm)
+}
+
+// Permute returns a new vector w = u(perm), that is, w(k) = u(perm(k)), or P*u in terms
+// of the permutation matrix P of perm.
+//
+// GraphBLAS API errors that may be returned:
+//   - [DimensionMismatch]: The size of perm does not match the size of the vector.
+//   - [InvalidValue]: perm is not a permutation.
+//   - [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [InvalidObject], [OutOfMemory], [Panic]
+//
+// Permute is a forGraphBLASGo extension.
+func (vector Vector[D]) Permute(perm Permutation) (permuted Vector[D], err error) {
+	n, err := vector.Size()
+	if err != nil {
+		return
+	}
+	indices, err := permutationIndices(&perm, n)
+	if err != nil {
+		return
+	}
+	if permuted, err = VectorNew[D](n); err != nil {
+		return
+	}
+	if err = VectorExtract(permuted, nil, nil, vector, indices, nil); err != nil {
+		_ = permuted.Free()
+	}
+	return
+}
@@ -0,0 +1,108 @@
+package query_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"github.com/intel/forGraphBLASGo/GrB/graphstore"
+	"github.com/intel/forGraphBLASGo/GrB/query"
+	"testing"
+)
+
+func Example_rpq() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	// a provenance graph of files 0..4 and processes 5..7
+	g, err := graphstore.New(8)
+	OK(err)
+	defer func() {
+		OK(g.Free())
+	}()
+	files, err := g.CreateNodes(5, "File")
+	OK(err)
+	processes, err := g.CreateNodes(3, "Process")
+	OK(err)
+	edges := func(pairs ...[2]int) (edges []graphstore.Edge) {
+		for _, pair := range pairs {
+			edges = append(edges, graphstore.Edge{Src: pair[0], Dst: pair[1]})
+		}
+		return
+	}
+	_, err = g.CreateEdges("READ", edges(
+		[2]int{processes[0], files[0]}, [2]int{processes[1], files[1]},
+		[2]int{processes[2], files[0]}, [2]int{processes[2], files[4]},
+	))
+	OK(err)
+	_, err = g.CreateEdges("WROTE", edges(
+		[2]int{processes[0], files[1]}, [2]int{processes[1], files[2]}, [2]int{processes[2], files[3]},
+	))
+	OK(err)
+
+	indices := func(v GrB.Vector[bool]) (indices []int) {
+		OK(v.ExtractTuples(&indices, nil))
+		return
+	}
+
+	// the files derived from a file
+	derived, err := query.CompileRegex("(^READ / WROTE)+")
+	OK(err)
+	fmt.Println(derived)
+	for _, method := range []query.RPQMethod{query.RPQProduct, query.RPQKronecker} {
+		var results [][]int
+		for _, file := range files[:2] {
+			reach, err := derived.ReachFrom(g, file, method)
+			OK(err)
+			results = append(results, indices(reach))
+			OK(reach.Free())
+		}
+		fmt.Println(results)
+	}
+
+	// all pairs of a file and a file or process that it depends on
+	dependsOn, err := query.CompileRegex("(^WROTE/READ)*/^WROTE?")
+	OK(err)
+	product, err := dependsOn.Reach(g, query.RPQProduct)
+	OK(err)
+	defer func() {
+		OK(product.Free())
+	}()
+	kronecker, err := dependsOn.Reach(g, query.RPQKronecker)
+	OK(err)
+	defer func() {
+		OK(kronecker.Free())
+	}()
+	var rows, cols []int
+	OK(product.ExtractTuples(&rows, &cols, nil))
+	fmt.Println(rows)
+	fmt.Println(cols)
+	var krows, kcols []int
+	OK(kronecker.ExtractTuples(&krows, &kcols, nil))
+	fmt.Println(fmt.Sprint(rows, cols) == fmt.Sprint(krows, kcols))
+
+	_, err = query.CompileRegex("READ/(WROTE|)")
+	fmt.Println(err)
+
+	// Output:
+	// 0 -^READ-> 1
+	// 1 -WROTE-> 2
+	// 2 -^READ-> 1
+	// final [2]
+	// [[1 2 3] [2]]
+	// [[1 2 3] [2]]
+	// [0 1 1 1 2 2 2 2 2 3 3 3 3 4 5 6 7]
+	// [0 0 1 5 0 1 2 5 6 0 3 4 7 4 5 6 7]
+	// true
+	// query: expected relationship type at offset 12 in "READ/(WROTE|)": GraphBLAS API error: invalid value
+}
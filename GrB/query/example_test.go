@@ -0,0 +1,116 @@
+package query_test
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"github.com/intel/forGraphBLASGo/GrB/graphstore"
+	"github.com/intel/forGraphBLASGo/GrB/query"
+	"testing"
+)
+
+func Example() {
+	OK := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if !testing.Testing() {
+		// When run by "go test", this initialization of
+		// GraphBLAS is done elsewhere in TestMain.
+		OK(GrB.Init(GrB.NonBlocking))
+		defer func() {
+			OK(GrB.Finalize())
+		}()
+	}
+
+	g, err := graphstore.New(16)
+	OK(err)
+	defer func() {
+		OK(g.Free())
+	}()
+
+	// people 0..5, companies 6..7, and a city 8
+	people, err := g.CreateNodes(6, "Person")
+	OK(err)
+	companies, err := g.CreateNodes(2, "Company")
+	OK(err)
+	city, err := g.CreateNodes(1, "City")
+	OK(err)
+	_, err = g.CreateEdges("KNOWS", []graphstore.Edge{
+		{Src: people[0], Dst: people[1]}, {Src: people[0], Dst: people[2]}, {Src: people[1], Dst: people[3]},
+		{Src: people[4], Dst: people[5]}, {Src: people[5], Dst: people[0]},
+	})
+	OK(err)
+	_, err = g.CreateEdges("WORKS_AT", []graphstore.Edge{
+		{Src: people[1], Dst: companies[0]}, {Src: people[2], Dst: city[0]}, {Src: people[3], Dst: companies[1]},
+		{Src: people[5], Dst: companies[1]},
+	})
+	OK(err)
+
+	tuples := func(m GrB.Matrix[bool]) [][2]int {
+		var is, js []int
+		OK(m.ExtractTuples(&is, &js, nil))
+		pairs := make([][2]int, len(is))
+		for k := range is {
+			pairs[k] = [2]int{is[k], js[k]}
+		}
+		return pairs
+	}
+
+	p, err := query.Parse("(a:Person) -[:KNOWS]-> (b) -[:WORKS_AT]-> (c:Company)")
+	OK(err)
+	fmt.Println(p)
+	r, err := query.Match(g, p)
+	OK(err)
+	defer func() {
+		OK(r.Free())
+	}()
+	for _, variable := range r.Variables() {
+		v, err := r.Vector(variable)
+		OK(err)
+		var ids []int
+		OK(v.ExtractTuples(&ids, nil))
+		fmt.Println(variable, ids)
+	}
+	ac, err := r.Matrix("a", "c")
+	OK(err)
+	defer func() {
+		OK(ac.Free())
+	}()
+	fmt.Println(tuples(ac))
+	plan, err := r.Explain("a", "c")
+	OK(err)
+	fmt.Println(plan)
+
+	// pairs (y, x) of people, such that y works at the same company as somebody x knows
+	p, err = query.Parse("(x)-[:KNOWS]->(:Person)-[:WORKS_AT]->(:Company)<-[:WORKS_AT]-(y:Person)")
+	OK(err)
+	r2, err := query.Match(g, p)
+	OK(err)
+	defer func() {
+		OK(r2.Free())
+	}()
+	yx, err := r2.Matrix("y", "x")
+	OK(err)
+	defer func() {
+		OK(yx.Free())
+	}()
+	fmt.Println(tuples(yx))
+
+	_, err = query.Parse("(a)-[:KNOWS]-(b)")
+	fmt.Println(err)
+	_, err = query.Parse("(a)-[:KNOWS]->(a)")
+	fmt.Println(err)
+
+	// Output:
+	// (a:Person)-[:KNOWS]->(b)-[:WORKS_AT]->(c:Company)
+	// a [0 1 4]
+	// b [1 3 5]
+	// c [6 7]
+	// [[0 6] [1 7] [4 7]]
+	// (a (KNOWS (b (WORKS_AT c))))
+	// [[1 0] [3 1] [3 4] [5 1] [5 4]]
+	// query: expected "->" at offset 12 in "(a)-[:KNOWS]-(b)": GraphBLAS API error: invalid value
+	// query: variable a occurs more than once in "(a)-[:KNOWS]->(a)": GraphBLAS API error: invalid value
+}
@@ -0,0 +1,176 @@
+package query
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Parse parses a path pattern. A path pattern is a sequence of node patterns, connected by
+// edge patterns:
+//
+//	(a:Person)-[:KNOWS]->(b)-[:WORKS_AT]->(c:Company)<-[:OWNS]-(:Person:Investor)
+//
+// A node pattern consists of an optional variable and any number of labels, each preceded
+// by a colon, in parentheses. An edge pattern consists of a relationship type, preceded by a
+// colon, in square brackets, and it is either directed from left to right, as in -[:KNOWS]->,
+// or from right to left, as in <-[:OWNS]-. Variables, labels, and relationship types are
+// identifiers made of letters, digits, and underscores. A variable must not occur more than
+// once in a pattern. Whitespace between the elements of a pattern is ignored.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidValue]: The pattern has a syntax error.
+func Parse(pattern string) (p *Pattern, err error) {
+	s := &scanner{input: pattern}
+	p = new(Pattern)
+	node, err := s.node()
+	if err != nil {
+		return nil, err
+	}
+	p.Nodes = append(p.Nodes, node)
+	for {
+		s.skipSpace()
+		if s.pos == len(s.input) {
+			break
+		}
+		edge, err := s.edge()
+		if err != nil {
+			return nil, err
+		}
+		node, err := s.node()
+		if err != nil {
+			return nil, err
+		}
+		p.Edges = append(p.Edges, edge)
+		p.Nodes = append(p.Nodes, node)
+	}
+	seen := make(map[string]bool)
+	for _, node := range p.Nodes {
+		if node.Variable == "" {
+			continue
+		}
+		if seen[node.Variable] {
+			return nil, fmt.Errorf("query: variable %v occurs more than once in %q: %w", node.Variable, pattern, GrB.InvalidValue)
+		}
+		seen[node.Variable] = true
+	}
+	return
+}
+
+// String returns the pattern in the syntax accepted by [Parse].
+func (p *Pattern) String() string {
+	var b strings.Builder
+	for i, node := range p.Nodes {
+		if i > 0 {
+			edge := p.Edges[i-1]
+			if edge.Reverse {
+				fmt.Fprintf(&b, "<-[:%v]-", edge.Relation)
+			} else {
+				fmt.Fprintf(&b, "-[:%v]->", edge.Relation)
+			}
+		}
+		b.WriteString("(")
+		b.WriteString(node.Variable)
+		for _, label := range node.Labels {
+			b.WriteString(":")
+			b.WriteString(label)
+		}
+		b.WriteString(")")
+	}
+	return b.String()
+}
+
+// scanner splits a pattern into tokens.
+type scanner struct {
+	input string
+	pos   int
+}
+
+func (s *scanner) errorf(format string, args ...any) error {
+	return fmt.Errorf("query: %v at offset %v in %q: %w", fmt.Sprintf(format, args...), s.pos, s.input, GrB.InvalidValue)
+}
+
+func (s *scanner) skipSpace() {
+	for s.pos < len(s.input) {
+		r, size := utf8.DecodeRuneInString(s.input[s.pos:])
+		if !unicode.IsSpace(r) {
+			return
+		}
+		s.pos += size
+	}
+}
+
+// accept skips the token if it comes next, and reports whether it did.
+func (s *scanner) accept(token string) bool {
+	s.skipSpace()
+	if strings.HasPrefix(s.input[s.pos:], token) {
+		s.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (s *scanner) expect(token string) error {
+	if s.accept(token) {
+		return nil
+	}
+	return s.errorf("expected %q", token)
+}
+
+// ident returns the next identifier, which is empty if there is none.
+func (s *scanner) ident() string {
+	s.skipSpace()
+	start := s.pos
+	for s.pos < len(s.input) {
+		r, size := utf8.DecodeRuneInString(s.input[s.pos:])
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+		s.pos += size
+	}
+	return s.input[start:s.pos]
+}
+
+func (s *scanner) node() (node NodePattern, err error) {
+	if err = s.expect("("); err != nil {
+		return
+	}
+	node.Variable = s.ident()
+	for s.accept(":") {
+		label := s.ident()
+		if label == "" {
+			return node, s.errorf("expected label")
+		}
+		node.Labels = append(node.Labels, label)
+	}
+	err = s.expect(")")
+	return
+}
+
+func (s *scanner) edge() (edge EdgePattern, err error) {
+	if s.accept("<-") {
+		edge.Reverse = true
+	} else if err = s.expect("-"); err != nil {
+		return
+	}
+	if err = s.expect("["); err != nil {
+		return
+	}
+	if err = s.expect(":"); err != nil {
+		return
+	}
+	if edge.Relation = s.ident(); edge.Relation == "" {
+		return edge, s.errorf("expected relationship type")
+	}
+	if err = s.expect("]"); err != nil {
+		return
+	}
+	if edge.Reverse {
+		err = s.expect("-")
+	} else {
+		err = s.expect("->")
+	}
+	return
+}
@@ -0,0 +1,172 @@
+package query
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"math"
+)
+
+// A chain is a product of square boolean matrices along the path between two nodes of a
+// pattern, together with the order in which the product is computed.
+type chain struct {
+	size    int
+	factors []factor
+	split   [][]int // the product of factors[lo:hi+1] is split after factors[split[lo][hi]]
+}
+
+type factor struct {
+	name   string
+	matrix GrB.Matrix[bool]
+	owned  bool // whether the matrix is freed with the chain
+}
+
+// chain returns the chain for the path from node pattern i to node pattern j, with i <= j. It
+// consists of the diagonal matrices of the bindings of the nodes, alternating with the adjacency
+// matrices of the edges, transposed if necessary.
+func (result *Result) chain(i, j int) (c *chain, err error) {
+	c = &chain{size: result.size}
+	defer func() {
+		if err != nil {
+			_ = c.free()
+			c = nil
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+	for t := i; t <= j; t++ {
+		name := result.pattern.Nodes[t].Variable
+		if name == "" {
+			name = "_"
+		}
+		diag, err := result.bindings[t].Diag(0)
+		GrB.OK(err)
+		c.factors = append(c.factors, factor{name, diag, true})
+		if t == j {
+			break
+		}
+		edge := result.pattern.Edges[t]
+		relation, ok := result.graph.Relation(edge.Relation)
+		switch {
+		case !ok:
+			empty, err := GrB.MatrixNew[bool](c.size, c.size)
+			GrB.OK(err)
+			c.factors = append(c.factors, factor{edge.Relation, empty, true})
+		case edge.Reverse:
+			transposed, err := GrB.MatrixNew[bool](c.size, c.size)
+			GrB.OK(err)
+			c.factors = append(c.factors, factor{edge.Relation + "'", transposed, true})
+			GrB.OK(GrB.Transpose(transposed, nil, nil, relation, nil))
+		default:
+			c.factors = append(c.factors, factor{edge.Relation, relation, false})
+		}
+	}
+	GrB.OK(c.plan())
+	return
+}
+
+// plan chooses the order of the multiplications that minimizes the estimated number of
+// multiply-adds. The product of a matrix with x entries and a matrix with y entries is
+// estimated to take x*y/n multiply-adds, assuming uniformly distributed entries, which
+// is also the estimated number of entries of the result, up to n*n.
+func (c *chain) plan() error {
+	m := len(c.factors)
+	n := float64(c.size)
+	nvals := make([][]float64, m)
+	cost := make([][]float64, m)
+	c.split = make([][]int, m)
+	for i := range m {
+		nvals[i] = make([]float64, m)
+		cost[i] = make([]float64, m)
+		c.split[i] = make([]int, m)
+		k, err := c.factors[i].matrix.Nvals()
+		if err != nil {
+			return err
+		}
+		nvals[i][i] = float64(k)
+	}
+	for length := 2; length <= m; length++ {
+		for lo := 0; lo+length <= m; lo++ {
+			hi := lo + length - 1
+			cost[lo][hi] = math.Inf(1)
+			for s := lo; s < hi; s++ {
+				flops := nvals[lo][s] * nvals[s+1][hi] / n
+				if total := cost[lo][s] + cost[s+1][hi] + flops; total < cost[lo][hi] {
+					cost[lo][hi] = total
+					nvals[lo][hi] = min(n*n, flops)
+					c.split[lo][hi] = s
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// multiply computes the product of the chain in the planned order. The result is a new matrix.
+func (c *chain) multiply() (product GrB.Matrix[bool], err error) {
+	if len(c.factors) == 1 {
+		return c.factors[0].matrix.Dup()
+	}
+	return c.product(0, len(c.factors)-1)
+}
+
+// product returns the product of factors[lo:hi+1] with lo < hi, which is a new matrix.
+func (c *chain) product(lo, hi int) (product GrB.Matrix[bool], err error) {
+	operand := func(lo, hi int) (GrB.Matrix[bool], bool, error) {
+		if lo == hi {
+			return c.factors[lo].matrix, false, nil
+		}
+		product, err := c.product(lo, hi)
+		return product, true, err
+	}
+	s := c.split[lo][hi]
+	left, freeLeft, err := operand(lo, s)
+	if err != nil {
+		return
+	}
+	if freeLeft {
+		defer func() {
+			if ferr := left.Free(); err == nil {
+				err = ferr
+			}
+		}()
+	}
+	right, freeRight, err := operand(s+1, hi)
+	if err != nil {
+		return
+	}
+	if freeRight {
+		defer func() {
+			if ferr := right.Free(); err == nil {
+				err = ferr
+			}
+		}()
+	}
+	if product, err = GrB.MatrixNew[bool](c.size, c.size); err != nil {
+		return
+	}
+	if err = GrB.MxM(product, nil, nil, GrB.AnyOneb[bool](), left, right, nil); err != nil {
+		_ = product.Free()
+	}
+	return
+}
+
+// explain returns the planned product of factors[lo:hi+1] as a string.
+func (c *chain) explain(lo, hi int) string {
+	if lo == hi {
+		return c.factors[lo].name
+	}
+	s := c.split[lo][hi]
+	return fmt.Sprintf("(%v %v)", c.explain(lo, s), c.explain(s+1, hi))
+}
+
+// free frees the matrices owned by the chain.
+func (c *chain) free() (err error) {
+	for _, f := range c.factors {
+		if !f.owned {
+			continue
+		}
+		if ferr := f.matrix.Free(); err == nil {
+			err = ferr
+		}
+	}
+	return
+}
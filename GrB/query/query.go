@@ -0,0 +1,346 @@
+/*
+Package query matches path patterns against graphs that are stored as one boolean adjacency
+matrix per relationship type, and one boolean vector per node label, like the graphs of package
+[github.com/intel/forGraphBLASGo/GrB/graphstore].
+
+A pattern like
+
+	(a:Person)-[:KNOWS]->(b)-[:WORKS_AT]->(c:Company)
+
+is parsed with [Parse], and matched with [Match], which compiles the pattern into GraphBLAS
+operations instead of enumerating paths. Match first computes, for each node of the pattern,
+the set of graph nodes to which it can be bound in at least one complete match. This is done by
+propagating candidate vectors along the path in both directions, with [GrB.VxM] and [GrB.MxV] on
+the [GrB.LorLandSemiringBool] semiring, masked with the labels of the nodes. The first pass starts
+at the end of the path with fewer candidates. The sets are returned by [Result.Vector].
+
+The pairs of graph nodes to which two variables can be bound together are returned by
+[Result.Matrix] as a boolean matrix, which is the product of the adjacency matrices along the
+path between the two variables, restricted to the candidates of the nodes in between. The order
+of the multiplications, which are performed with [GrB.MxM] on the [GrB.AnyOneb] semiring, is
+chosen with dynamic programming, based on estimates derived from the numbers of entries of the
+matrices. The chosen order is returned by [Result.Explain].
+
+Regular path queries are compiled with [CompileRegex] into an [Automaton], whose product with
+the adjacency matrices of the graph is searched by [Automaton.ReachFrom] for a single source, or
+by [Automaton.Reach] for all sources.
+
+Package query is a forGraphBLASGo extension, and its API is experimental.
+*/
+package query
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+)
+
+// A Graph provides the vectors and matrices against which patterns are matched. All vectors
+// and matrices must have the same size. [github.com/intel/forGraphBLASGo/GrB/graphstore.Graph]
+// implements Graph.
+type Graph interface {
+	// Nodes returns a vector with an entry for each node of the graph.
+	Nodes() GrB.Vector[bool]
+
+	// Label returns the vector with an entry for each node with the given label, and
+	// whether the label exists. The values of the entries are ignored.
+	Label(label string) (GrB.Vector[bool], bool)
+
+	// Relation returns the adjacency matrix of the given relationship type, and whether
+	// the relationship type exists. The values of the entries are ignored.
+	Relation(relation string) (GrB.Matrix[bool], bool)
+}
+
+// A Pattern is a path of node patterns, connected by edge patterns.
+// Edges[i] connects Nodes[i] and Nodes[i+1].
+type Pattern struct {
+	Nodes []NodePattern
+	Edges []EdgePattern
+}
+
+// A NodePattern matches the nodes that have all of the given labels.
+// The Variable may be empty.
+type NodePattern struct {
+	Variable string
+	Labels   []string
+}
+
+// An EdgePattern matches the edges of the given relationship type. If Reverse is false,
+// the edges are directed from the node before the edge pattern in the path to the node after it,
+// otherwise in the opposite direction.
+type EdgePattern struct {
+	Relation string
+	Reverse  bool
+}
+
+// A Result holds the bindings of the nodes of a pattern in a graph. It refers to the vectors
+// and matrices of the graph, and must not be used after the graph is modified.
+type Result struct {
+	graph    Graph
+	pattern  *Pattern
+	size     int
+	bindings []GrB.Vector[bool] // indexed like pattern.Nodes
+}
+
+// Match matches the pattern against the graph, and computes the bindings of the nodes of the
+// pattern. A missing label or relationship type matches nothing.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.DimensionMismatch]: The vectors and matrices of the graph do not have the same size.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func Match(graph Graph, pattern *Pattern) (result *Result, err error) {
+	if len(pattern.Nodes) != len(pattern.Edges)+1 {
+		return nil, fmt.Errorf("query: %v node patterns and %v edge patterns: %w", len(pattern.Nodes), len(pattern.Edges), GrB.InvalidValue)
+	}
+	k := len(pattern.Nodes)
+	var filters, candidates []GrB.Vector[bool]
+	result = &Result{graph: graph, pattern: pattern}
+	defer func() {
+		freeVectors(filters, &err)
+		freeVectors(candidates, &err)
+		if err != nil {
+			_ = result.Free()
+			result = nil
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+
+	nodes := graph.Nodes()
+	result.size, err = nodes.Size()
+	GrB.OK(err)
+
+	filters = make([]GrB.Vector[bool], k)
+	for i, node := range pattern.Nodes {
+		filters[i], err = result.filter(node)
+		GrB.OK(err)
+	}
+	nfirst, err := filters[0].Nvals()
+	GrB.OK(err)
+	nlast, err := filters[k-1].Nvals()
+	GrB.OK(err)
+
+	// The first pass leaves the candidates of each node that can be reached from all
+	// preceding nodes, and the second pass restricts them to the candidates that can
+	// also be reached from all following nodes.
+	forward := nfirst <= nlast
+	candidates, err = result.sweep(filters, forward)
+	GrB.OK(err)
+	result.bindings, err = result.sweep(candidates, !forward)
+	GrB.OK(err)
+	return
+}
+
+// filter returns the nodes of the graph that have all labels of the node pattern.
+func (result *Result) filter(node NodePattern) (filter GrB.Vector[bool], err error) {
+	if filter, err = result.graph.Nodes().Dup(); err != nil {
+		return
+	}
+	for _, name := range node.Labels {
+		label, ok := result.graph.Label(name)
+		if !ok {
+			err = filter.Clear()
+			return
+		}
+		if err = checkVectorSize(label, result.size); err != nil {
+			return
+		}
+		if err = GrB.VectorEWiseMultBinaryOp(filter, nil, nil, GrB.LandBool, filter, label, nil); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// sweep propagates candidates along the path, from the first node to the last one if forward is
+// true, or from the last node to the first one otherwise. The candidates of each node are the
+// nodes reached from the candidates of the previous node, masked with masks.
+func (result *Result) sweep(masks []GrB.Vector[bool], forward bool) (candidates []GrB.Vector[bool], err error) {
+	k := len(masks)
+	candidates = make([]GrB.Vector[bool], k)
+	defer func() {
+		if err != nil {
+			freeVectors(candidates, &err)
+			candidates = nil
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+	first, last, inc := 0, k-1, 1
+	if !forward {
+		first, last, inc = k-1, 0, -1
+	}
+	candidates[first], err = masks[first].Dup()
+	GrB.OK(err)
+	for i := first; i != last; i += inc {
+		next := i + inc
+		edge := result.pattern.Edges[min(i, next)]
+		candidates[next], err = GrB.VectorNew[bool](result.size)
+		GrB.OK(err)
+		relation, ok := result.graph.Relation(edge.Relation)
+		if !ok {
+			continue
+		}
+		GrB.OK(checkMatrixSize(relation, result.size))
+		// traversing the edges in their direction: next = candidates[i]' * relation
+		if forward != edge.Reverse {
+			GrB.OK(GrB.VxM(candidates[next], &masks[next], nil, GrB.LorLandSemiringBool, candidates[i], relation, GrB.DescRS))
+		} else {
+			GrB.OK(GrB.MxV(candidates[next], &masks[next], nil, GrB.LorLandSemiringBool, relation, candidates[i], GrB.DescRS))
+		}
+	}
+	return
+}
+
+// Free frees the vectors of the result.
+func (result *Result) Free() (err error) {
+	freeVectors(result.bindings, &err)
+	result.bindings = nil
+	return
+}
+
+// Pattern returns the pattern of the result.
+func (result *Result) Pattern() *Pattern {
+	return result.pattern
+}
+
+// Variables returns the variables of the pattern, in the order in which they occur.
+func (result *Result) Variables() (variables []string) {
+	for _, node := range result.pattern.Nodes {
+		if node.Variable != "" {
+			variables = append(variables, node.Variable)
+		}
+	}
+	return
+}
+
+// position returns the index of the node pattern with the given variable.
+func (result *Result) position(variable string) (int, error) {
+	if variable != "" {
+		for i, node := range result.pattern.Nodes {
+			if node.Variable == variable {
+				return i, nil
+			}
+		}
+	}
+	return -1, fmt.Errorf("query: unknown variable %q: %w", variable, GrB.InvalidValue)
+}
+
+// Vector returns the vector with an entry true for each graph node to which the variable is
+// bound in at least one match of the pattern. The vector is owned by the result, and must not
+// be modified or freed.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidValue]: The variable does not occur in the pattern.
+func (result *Result) Vector(variable string) (vector GrB.Vector[bool], err error) {
+	i, err := result.position(variable)
+	if err != nil {
+		return
+	}
+	return result.bindings[i], nil
+}
+
+// Matrix returns a new matrix with an entry true at (i, j) for each pair of graph nodes i and j
+// to which the variables from and to are bound together in at least one match of the pattern.
+// If from and to are the same variable, the matrix is diagonal.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidValue]: One of the variables does not occur in the pattern.
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func (result *Result) Matrix(from, to string) (matrix GrB.Matrix[bool], err error) {
+	i, err := result.position(from)
+	if err != nil {
+		return
+	}
+	j, err := result.position(to)
+	if err != nil {
+		return
+	}
+	c, err := result.chain(min(i, j), max(i, j))
+	if err != nil {
+		return
+	}
+	defer func() {
+		if ferr := c.free(); err == nil {
+			err = ferr
+		}
+	}()
+	product, err := c.multiply()
+	if err != nil || i <= j {
+		return product, err
+	}
+	defer func() {
+		if ferr := product.Free(); err == nil {
+			err = ferr
+		}
+	}()
+	if matrix, err = GrB.MatrixNew[bool](result.size, result.size); err != nil {
+		return
+	}
+	if err = GrB.Transpose(matrix, nil, nil, product, nil); err != nil {
+		_ = matrix.Free()
+	}
+	return
+}
+
+// Explain describes the order in which [Result.Matrix] multiplies the matrices along the path
+// between the variables from and to, as a fully parenthesized product. Diagonal matrices that
+// restrict the bindings of the nodes are written as the variables of the nodes, or _ for nodes
+// without a variable, and adjacency matrices are written as their relationship types, followed
+// by ' if they are transposed.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidValue]: One of the variables does not occur in the pattern.
+func (result *Result) Explain(from, to string) (explanation string, err error) {
+	i, err := result.position(from)
+	if err != nil {
+		return
+	}
+	j, err := result.position(to)
+	if err != nil {
+		return
+	}
+	c, err := result.chain(min(i, j), max(i, j))
+	if err != nil {
+		return
+	}
+	defer func() {
+		if ferr := c.free(); err == nil {
+			err = ferr
+		}
+	}()
+	explanation = c.explain(0, len(c.factors)-1)
+	if i > j {
+		explanation = "(" + explanation + ")'"
+	}
+	return
+}
+
+func checkVectorSize(vector GrB.Vector[bool], size int) error {
+	n, err := vector.Size()
+	if err == nil && n != size {
+		err = GrB.DimensionMismatch
+	}
+	return err
+}
+
+func checkMatrixSize(matrix GrB.Matrix[bool], size int) error {
+	nrows, ncols, err := matrix.Size()
+	if err == nil && (nrows != size || ncols != size) {
+		err = GrB.DimensionMismatch
+	}
+	return err
+}
+
+// freeVectors frees all valid vectors, and records the first error in err if it is nil.
+func freeVectors(vectors []GrB.Vector[bool], err *error) {
+	for i := range vectors {
+		if !vectors[i].Valid() {
+			continue
+		}
+		if ferr := vectors[i].Free(); *err == nil {
+			*err = ferr
+		}
+	}
+}
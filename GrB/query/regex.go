@@ -0,0 +1,203 @@
+package query
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// An Automaton is a nondeterministic finite automaton without epsilon transitions, whose
+// transitions are labeled with edge patterns. State 0 is the initial state.
+type Automaton struct {
+	States      int
+	Final       []bool // indexed by states
+	Transitions []Transition
+}
+
+// A Transition leads from state From to state To, along an edge that matches Edge.
+type Transition struct {
+	From, To int
+	Edge     EdgePattern
+}
+
+// CompileRegex compiles a regular expression over relationship types into an [Automaton].
+// The syntax of regular expressions follows the property paths of SPARQL:
+//
+//	KNOWS                 an edge of type KNOWS
+//	^KNOWS                an edge of type KNOWS, traversed in the opposite direction
+//	(r)                   grouping
+//	r*, r+, r?            zero or more, one or more, and zero or one repetitions of r
+//	r/s                   r followed by s
+//	r|s                   r or s
+//
+// The operators are listed in decreasing order of precedence. Relationship types are
+// identifiers made of letters, digits, and underscores. Whitespace is ignored.
+//
+// The automaton is constructed with the position automaton construction of Glushkov,
+// which yields one state for each occurrence of a relationship type in the regular
+// expression, plus the initial state.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidValue]: The regular expression has a syntax error.
+func CompileRegex(expr string) (automaton *Automaton, err error) {
+	p := &regexParser{scanner: scanner{input: expr}}
+	r, err := p.alternation()
+	if err != nil {
+		return
+	}
+	if p.skipSpace(); p.pos != len(p.input) {
+		return nil, p.errorf("unexpected %q", p.input[p.pos:])
+	}
+
+	// positions are numbered from 1, and are the states of the automaton besides 0
+	automaton = &Automaton{
+		States: len(p.symbols) + 1,
+		Final:  make([]bool, len(p.symbols)+1),
+	}
+	follow := make([][]int, automaton.States)
+	nullable, first, last := r.glushkov(follow)
+	follow[0] = first
+	automaton.Final[0] = nullable
+	for _, position := range last {
+		automaton.Final[position] = true
+	}
+	for from, tos := range follow {
+		slices.Sort(tos)
+		for _, to := range slices.Compact(tos) {
+			automaton.Transitions = append(automaton.Transitions, Transition{from, to, p.symbols[to-1]})
+		}
+	}
+	return
+}
+
+// String returns a description of the automaton, with one line for each transition,
+// followed by a line with the final states.
+func (automaton *Automaton) String() string {
+	var b strings.Builder
+	for _, t := range automaton.Transitions {
+		edge := t.Edge.Relation
+		if t.Edge.Reverse {
+			edge = "^" + edge
+		}
+		fmt.Fprintf(&b, "%v -%v-> %v\n", t.From, edge, t.To)
+	}
+	var final []int
+	for state, isFinal := range automaton.Final {
+		if isFinal {
+			final = append(final, state)
+		}
+	}
+	fmt.Fprintf(&b, "final %v", final)
+	return b.String()
+}
+
+// A regex is a node of the syntax tree of a regular expression.
+type regex struct {
+	op          byte // 0 for a position, or one of | / * + ?
+	position    int
+	left, right *regex
+}
+
+// glushkov returns whether r matches the empty word, and the positions that can occur first
+// and last in the words matched by r. It adds the positions that can follow a position p in
+// the words matched by r to follow[p].
+func (r *regex) glushkov(follow [][]int) (nullable bool, first, last []int) {
+	switch r.op {
+	case 0:
+		return false, []int{r.position}, []int{r.position}
+	case '|':
+		lnullable, lfirst, llast := r.left.glushkov(follow)
+		rnullable, rfirst, rlast := r.right.glushkov(follow)
+		return lnullable || rnullable, slices.Concat(lfirst, rfirst), slices.Concat(llast, rlast)
+	case '/':
+		lnullable, lfirst, llast := r.left.glushkov(follow)
+		rnullable, rfirst, rlast := r.right.glushkov(follow)
+		for _, p := range llast {
+			follow[p] = append(follow[p], rfirst...)
+		}
+		first, last = lfirst, rlast
+		if lnullable {
+			first = slices.Concat(lfirst, rfirst)
+		}
+		if rnullable {
+			last = slices.Concat(rlast, llast)
+		}
+		return lnullable && rnullable, first, last
+	default:
+		nullable, first, last = r.left.glushkov(follow)
+		if r.op != '?' {
+			for _, p := range last {
+				follow[p] = append(follow[p], first...)
+			}
+		}
+		return nullable || r.op != '+', first, last
+	}
+}
+
+// regexParser parses regular expressions, and collects the edge patterns of their positions.
+type regexParser struct {
+	scanner
+	symbols []EdgePattern
+}
+
+func (p *regexParser) alternation() (r *regex, err error) {
+	if r, err = p.concatenation(); err != nil {
+		return
+	}
+	for p.accept("|") {
+		right, err := p.concatenation()
+		if err != nil {
+			return nil, err
+		}
+		r = &regex{op: '|', left: r, right: right}
+	}
+	return
+}
+
+func (p *regexParser) concatenation() (r *regex, err error) {
+	if r, err = p.repetition(); err != nil {
+		return
+	}
+	for p.accept("/") {
+		right, err := p.repetition()
+		if err != nil {
+			return nil, err
+		}
+		r = &regex{op: '/', left: r, right: right}
+	}
+	return
+}
+
+func (p *regexParser) repetition() (r *regex, err error) {
+	if r, err = p.atom(); err != nil {
+		return
+	}
+	for {
+		switch {
+		case p.accept("*"):
+			r = &regex{op: '*', left: r}
+		case p.accept("+"):
+			r = &regex{op: '+', left: r}
+		case p.accept("?"):
+			r = &regex{op: '?', left: r}
+		default:
+			return
+		}
+	}
+}
+
+func (p *regexParser) atom() (r *regex, err error) {
+	if p.accept("(") {
+		if r, err = p.alternation(); err != nil {
+			return
+		}
+		return r, p.expect(")")
+	}
+	var edge EdgePattern
+	edge.Reverse = p.accept("^")
+	if edge.Relation = p.ident(); edge.Relation == "" {
+		return nil, p.errorf("expected relationship type")
+	}
+	p.symbols = append(p.symbols, edge)
+	return &regex{position: len(p.symbols)}, nil
+}
@@ -0,0 +1,354 @@
+package query
+
+import (
+	"github.com/intel/forGraphBLASGo/GrB"
+)
+
+// RPQMethod selects how regular path queries are evaluated.
+type RPQMethod int
+
+const (
+	// RPQProduct evaluates a regular path query with a breadth-first search over pairs of
+	// states of the automaton and nodes of the graph, without constructing the product of
+	// the automaton and the graph. The nodes reached in each state are kept in a separate
+	// vector, which is advanced along each transition with [GrB.MxV] on the adjacency
+	// matrix of the transition.
+	RPQProduct RPQMethod = iota
+
+	// RPQKronecker evaluates a regular path query by first constructing the adjacency matrix
+	// of the product of the automaton and the graph with [GrB.KroneckerBinaryOp], and then
+	// performing a breadth-first search in the product with [GrB.MxV]. Pair (s, v) of state s
+	// and node v is represented by index s*n+v of the product, where n is the number of nodes.
+	// This requires more memory than RPQProduct, but fewer operations per step.
+	RPQKronecker
+)
+
+// ReachFrom returns a new vector with an entry true for each node that can be reached from the
+// node source along a path whose sequence of edges is accepted by the automaton. If the automaton
+// accepts the empty sequence, source is reachable from itself.
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidIndex]: source is not smaller than the size of the graph.
+//   - [GrB.InvalidValue]: method is not a valid [RPQMethod].
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func (automaton *Automaton) ReachFrom(graph Graph, source int, method RPQMethod) (reach GrB.Vector[bool], err error) {
+	n, err := graph.Nodes().Size()
+	if err != nil {
+		return
+	}
+	if source < 0 || source >= n {
+		return reach, GrB.InvalidIndex
+	}
+	switch method {
+	case RPQProduct:
+		return automaton.reachFromProduct(graph, n, source)
+	case RPQKronecker:
+		return automaton.reachFromKronecker(graph, n, source)
+	}
+	return reach, GrB.InvalidValue
+}
+
+// Reach returns a new matrix with an entry true at (u, v) for each pair of nodes u and v of the
+// graph, such that v can be reached from u along a path whose sequence of edges is accepted by
+// the automaton. The sources u are the nodes of the graph, as given by [Graph.Nodes].
+//
+// GraphBLAS API errors that may be returned:
+//   - [GrB.InvalidValue]: method is not a valid [RPQMethod].
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [GrB.OutOfMemory], [GrB.Panic]
+func (automaton *Automaton) Reach(graph Graph, method RPQMethod) (reach GrB.Matrix[bool], err error) {
+	n, err := graph.Nodes().Size()
+	if err != nil {
+		return
+	}
+	switch method {
+	case RPQProduct:
+		return automaton.reachProduct(graph, n)
+	case RPQKronecker:
+		return automaton.reachKronecker(graph, n)
+	}
+	return reach, GrB.InvalidValue
+}
+
+func (automaton *Automaton) reachFromProduct(graph Graph, n, source int) (reach GrB.Vector[bool], err error) {
+	q := automaton.States
+	visited := make([]GrB.Vector[bool], q)
+	frontier := make([]GrB.Vector[bool], q)
+	next := make([]GrB.Vector[bool], q)
+	defer func() {
+		freeVectors(visited, &err)
+		freeVectors(frontier, &err)
+		freeVectors(next, &err)
+	}()
+	defer GrB.CheckErrors(&err)
+	for s := range q {
+		visited[s], err = GrB.VectorNew[bool](n)
+		GrB.OK(err)
+		frontier[s], err = GrB.VectorNew[bool](n)
+		GrB.OK(err)
+		next[s], err = GrB.VectorNew[bool](n)
+		GrB.OK(err)
+	}
+	GrB.OK(visited[0].SetElement(true, source))
+	GrB.OK(frontier[0].SetElement(true, source))
+	for {
+		for _, t := range automaton.Transitions {
+			relation, ok := graph.Relation(t.Edge.Relation)
+			if !ok {
+				continue
+			}
+			// next[t.To]<!visited[t.To]> |= relation' * frontier[t.From]
+			desc := GrB.DescSCT0
+			if t.Edge.Reverse {
+				desc = GrB.DescSC
+			}
+			GrB.OK(GrB.MxV(next[t.To], &visited[t.To], &GrB.LorBool, GrB.LorLandSemiringBool, relation, frontier[t.From], desc))
+		}
+		active := false
+		for s := range q {
+			nvals, err := next[s].Nvals()
+			GrB.OK(err)
+			if nvals > 0 {
+				active = true
+				GrB.OK(GrB.VectorEWiseAddBinaryOp(visited[s], nil, nil, GrB.LorBool, visited[s], next[s], nil))
+			}
+			frontier[s], next[s] = next[s], frontier[s]
+			GrB.OK(next[s].Clear())
+		}
+		if !active {
+			break
+		}
+	}
+	reach, err = GrB.VectorNew[bool](n)
+	GrB.OK(err)
+	for s, final := range automaton.Final {
+		if final {
+			GrB.OK(GrB.VectorEWiseAddBinaryOp(reach, nil, nil, GrB.LorBool, reach, visited[s], nil))
+		}
+	}
+	return
+}
+
+func (automaton *Automaton) reachProduct(graph Graph, n int) (reach GrB.Matrix[bool], err error) {
+	q := automaton.States
+	visited := make([]GrB.Matrix[bool], q)
+	frontier := make([]GrB.Matrix[bool], q)
+	next := make([]GrB.Matrix[bool], q)
+	defer func() {
+		freeMatrices(visited, &err)
+		freeMatrices(frontier, &err)
+		freeMatrices(next, &err)
+	}()
+	defer GrB.CheckErrors(&err)
+	for s := range q {
+		visited[s], err = GrB.MatrixNew[bool](n, n)
+		GrB.OK(err)
+		frontier[s], err = GrB.MatrixNew[bool](n, n)
+		GrB.OK(err)
+		next[s], err = GrB.MatrixNew[bool](n, n)
+		GrB.OK(err)
+	}
+	// row u of the matrices holds the nodes reached from u
+	GrB.OK(visited[0].BuildDiag(graph.Nodes(), 0, nil))
+	GrB.OK(frontier[0].BuildDiag(graph.Nodes(), 0, nil))
+	for {
+		for _, t := range automaton.Transitions {
+			relation, ok := graph.Relation(t.Edge.Relation)
+			if !ok {
+				continue
+			}
+			// next[t.To]<!visited[t.To]> |= frontier[t.From] * relation
+			desc := GrB.DescSC
+			if t.Edge.Reverse {
+				desc = GrB.DescSCT1
+			}
+			GrB.OK(GrB.MxM(next[t.To], &visited[t.To], &GrB.LorBool, GrB.LorLandSemiringBool, frontier[t.From], relation, desc))
+		}
+		active := false
+		for s := range q {
+			nvals, err := next[s].Nvals()
+			GrB.OK(err)
+			if nvals > 0 {
+				active = true
+				GrB.OK(GrB.MatrixEWiseAddBinaryOp(visited[s], nil, nil, GrB.LorBool, visited[s], next[s], nil))
+			}
+			frontier[s], next[s] = next[s], frontier[s]
+			GrB.OK(next[s].Clear())
+		}
+		if !active {
+			break
+		}
+	}
+	reach, err = GrB.MatrixNew[bool](n, n)
+	GrB.OK(err)
+	for s, final := range automaton.Final {
+		if final {
+			GrB.OK(GrB.MatrixEWiseAddBinaryOp(reach, nil, nil, GrB.LorBool, reach, visited[s], nil))
+		}
+	}
+	return
+}
+
+// product returns the adjacency matrix of the product of the automaton and the graph, which has
+// an entry at (s*n+v, t*n+w) if there is a transition from state s to state t that matches the
+// edge from node v to node w.
+func (automaton *Automaton) product(graph Graph, n int) (product GrB.Matrix[bool], err error) {
+	q := automaton.States
+	defer func() {
+		if err != nil && product.Valid() {
+			_ = product.Free()
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+	product, err = GrB.MatrixNew[bool](q*n, q*n)
+	GrB.OK(err)
+
+	// one transition matrix for each edge pattern
+	var edges []EdgePattern
+	froms, tos := make(map[EdgePattern][]int), make(map[EdgePattern][]int)
+	for _, t := range automaton.Transitions {
+		if _, ok := froms[t.Edge]; !ok {
+			edges = append(edges, t.Edge)
+		}
+		froms[t.Edge] = append(froms[t.Edge], t.From)
+		tos[t.Edge] = append(tos[t.Edge], t.To)
+	}
+	transitions, err := GrB.MatrixNew[bool](q, q)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(transitions.Free())
+	}()
+	for _, edge := range edges {
+		relation, ok := graph.Relation(edge.Relation)
+		if !ok {
+			continue
+		}
+		GrB.OK(transitions.Clear())
+		GrB.OK(transitions.Build(froms[edge], tos[edge], trues(len(froms[edge])), nil))
+		var desc *GrB.Descriptor
+		if edge.Reverse {
+			desc = GrB.DescT1
+		}
+		GrB.OK(GrB.KroneckerBinaryOp(product, nil, &GrB.LorBool, GrB.LandBool, transitions, relation, desc))
+	}
+	return
+}
+
+func (automaton *Automaton) reachFromKronecker(graph Graph, n, source int) (reach GrB.Vector[bool], err error) {
+	var product GrB.Matrix[bool]
+	var vectors [2]GrB.Vector[bool]
+	defer func() {
+		freeVectors(vectors[:], &err)
+		if product.Valid() {
+			if ferr := product.Free(); err == nil {
+				err = ferr
+			}
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+	product, err = automaton.product(graph, n)
+	GrB.OK(err)
+	size := automaton.States * n
+	for i := range vectors {
+		vectors[i], err = GrB.VectorNew[bool](size)
+		GrB.OK(err)
+	}
+	visited, frontier := vectors[0], vectors[1]
+	GrB.OK(visited.SetElement(true, source))
+	GrB.OK(frontier.SetElement(true, source))
+	for {
+		// frontier<!visited, replace> = product' * frontier
+		GrB.OK(GrB.MxV(frontier, &visited, nil, GrB.LorLandSemiringBool, product, frontier, GrB.DescRSCT0))
+		nvals, err := frontier.Nvals()
+		GrB.OK(err)
+		if nvals == 0 {
+			break
+		}
+		GrB.OK(GrB.VectorEWiseAddBinaryOp(visited, nil, nil, GrB.LorBool, visited, frontier, nil))
+	}
+	reach, err = GrB.VectorNew[bool](n)
+	GrB.OK(err)
+	for s, final := range automaton.Final {
+		if final {
+			GrB.OK(GrB.VectorExtract(reach, nil, &GrB.LorBool, visited, indexRange(s*n, n), nil))
+		}
+	}
+	return
+}
+
+func (automaton *Automaton) reachKronecker(graph Graph, n int) (reach GrB.Matrix[bool], err error) {
+	var product GrB.Matrix[bool]
+	matrices := make([]GrB.Matrix[bool], 2)
+	defer func() {
+		freeMatrices(matrices, &err)
+		if product.Valid() {
+			if ferr := product.Free(); err == nil {
+				err = ferr
+			}
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+	product, err = automaton.product(graph, n)
+	GrB.OK(err)
+	size := automaton.States * n
+	for i := range matrices {
+		matrices[i], err = GrB.MatrixNew[bool](n, size)
+		GrB.OK(err)
+	}
+	// row u of the matrices holds the pairs (s, v) reached from u
+	visited, frontier := matrices[0], matrices[1]
+	var sources []int
+	GrB.OK(graph.Nodes().ExtractTuples(&sources, nil))
+	GrB.OK(visited.Build(sources, sources, trues(len(sources)), nil))
+	GrB.OK(frontier.Build(sources, sources, trues(len(sources)), nil))
+	for {
+		// frontier<!visited, replace> = frontier * product
+		GrB.OK(GrB.MxM(frontier, &visited, nil, GrB.LorLandSemiringBool, frontier, product, GrB.DescRSC))
+		nvals, err := frontier.Nvals()
+		GrB.OK(err)
+		if nvals == 0 {
+			break
+		}
+		GrB.OK(GrB.MatrixEWiseAddBinaryOp(visited, nil, nil, GrB.LorBool, visited, frontier, nil))
+	}
+	reach, err = GrB.MatrixNew[bool](n, n)
+	GrB.OK(err)
+	for s, final := range automaton.Final {
+		if final && n > 0 {
+			GrB.OK(GrB.MatrixExtract(reach, nil, &GrB.LorBool, visited, GrB.All(n), indexRange(s*n, n), nil))
+		}
+	}
+	return
+}
+
+// indexRange returns the indices begin, begin+1, ..., begin+n-1.
+func indexRange(begin, n int) []int {
+	indices := make([]int, n)
+	for k := range indices {
+		indices[k] = begin + k
+	}
+	return indices
+}
+
+func trues(n int) []bool {
+	values := make([]bool, n)
+	for i := range values {
+		values[i] = true
+	}
+	return values
+}
+
+// freeMatrices frees all valid matrices, and records the first error in err if it is nil.
+func freeMatrices(matrices []GrB.Matrix[bool], err *error) {
+	for i := range matrices {
+		if !matrices[i].Valid() {
+			continue
+		}
+		if ferr := matrices[i].Free(); *err == nil {
+			*err = ferr
+		}
+	}
+}
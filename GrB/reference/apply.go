@@ -0,0 +1,139 @@
+package reference
+
+import "github.com/intel/forGraphBLASGo/GrB"
+
+// VectorApply computes the transformation of the values of the elements of a vector
+// using a unary function: w<mask> = accum(w, op(u)).
+func VectorApply[Dw, Du any](
+	w Vector[Dw],
+	mask *Vector[bool],
+	accum BinaryOp[Dw, Dw, Dw],
+	op UnaryOp[Dw, Du],
+	u Vector[Du],
+	desc *Descriptor,
+) {
+	VectorApplyIndexOp(w, mask, accum, func(x Du, _, _ int, _ struct{}) Dw {
+		return op(x)
+	}, u, struct{}{}, desc)
+}
+
+// MatrixApply computes the transformation of the values of the elements of a matrix
+// using a unary function: c<mask> = accum(c, op(a)).
+//
+// The [Descriptor] field Tran0 transposes a.
+func MatrixApply[DC, DA any](
+	c Matrix[DC],
+	mask *Matrix[bool],
+	accum BinaryOp[DC, DC, DC],
+	op UnaryOp[DC, DA],
+	a Matrix[DA],
+	desc *Descriptor,
+) {
+	MatrixApplyIndexOp(c, mask, accum, func(x DA, _, _ int, _ struct{}) DC {
+		return op(x)
+	}, a, struct{}{}, desc)
+}
+
+// VectorApplyBinaryOp1st is like [VectorApply], except that op(val, u(i)) is computed.
+func VectorApplyBinaryOp1st[Dw, D, Du any](
+	w Vector[Dw],
+	mask *Vector[bool],
+	accum BinaryOp[Dw, Dw, Dw],
+	op BinaryOp[Dw, D, Du],
+	val D,
+	u Vector[Du],
+	desc *Descriptor,
+) {
+	VectorApply(w, mask, accum, func(x Du) Dw {
+		return op(val, x)
+	}, u, desc)
+}
+
+// VectorApplyBinaryOp2nd is like [VectorApply], except that op(u(i), val) is computed.
+func VectorApplyBinaryOp2nd[Dw, Du, D any](
+	w Vector[Dw],
+	mask *Vector[bool],
+	accum BinaryOp[Dw, Dw, Dw],
+	op BinaryOp[Dw, Du, D],
+	u Vector[Du],
+	val D,
+	desc *Descriptor,
+) {
+	VectorApply(w, mask, accum, func(x Du) Dw {
+		return op(x, val)
+	}, u, desc)
+}
+
+// MatrixApplyBinaryOp1st is like [MatrixApply], except that op(val, a(i, j)) is computed.
+func MatrixApplyBinaryOp1st[DC, D, DA any](
+	c Matrix[DC],
+	mask *Matrix[bool],
+	accum BinaryOp[DC, DC, DC],
+	op BinaryOp[DC, D, DA],
+	val D,
+	a Matrix[DA],
+	desc *Descriptor,
+) {
+	MatrixApply(c, mask, accum, func(x DA) DC {
+		return op(val, x)
+	}, a, desc)
+}
+
+// MatrixApplyBinaryOp2nd is like [MatrixApply], except that op(a(i, j), val) is computed.
+func MatrixApplyBinaryOp2nd[DC, DA, D any](
+	c Matrix[DC],
+	mask *Matrix[bool],
+	accum BinaryOp[DC, DC, DC],
+	op BinaryOp[DC, DA, D],
+	a Matrix[DA],
+	val D,
+	desc *Descriptor,
+) {
+	MatrixApply(c, mask, accum, func(x DA) DC {
+		return op(x, val)
+	}, a, desc)
+}
+
+// VectorApplyIndexOp is like [VectorApply], except that op(u(i), i, 0, val) is computed.
+func VectorApplyIndexOp[Dw, Du, D any](
+	w Vector[Dw],
+	mask *Vector[bool],
+	accum BinaryOp[Dw, Dw, Dw],
+	op IndexUnaryOp[Dw, Du, D],
+	u Vector[Du],
+	val D,
+	desc *Descriptor,
+) {
+	if u.N != w.N {
+		panic(GrB.DimensionMismatch)
+	}
+	t := VectorNew[Dw](w.N)
+	for i, x := range u.Entries {
+		t.Entries[i] = op(x, i, 0, val)
+	}
+	vectorWriteBack(w, mask, accum, t, desc)
+}
+
+// MatrixApplyIndexOp is like [MatrixApply], except that op(a(i, j), i, j, val) is computed.
+//
+// The [Descriptor] field Tran0 transposes a. In that case, i and j refer to
+// positions in the transposed matrix.
+func MatrixApplyIndexOp[DC, DA, D any](
+	c Matrix[DC],
+	mask *Matrix[bool],
+	accum BinaryOp[DC, DC, DC],
+	op IndexUnaryOp[DC, DA, D],
+	a Matrix[DA],
+	val D,
+	desc *Descriptor,
+) {
+	a = transposeIf(a, desc.tran0())
+	if a.Nrows != c.Nrows || a.Ncols != c.Ncols {
+		panic(GrB.DimensionMismatch)
+	}
+	t := MatrixNew[DC](c.Nrows, c.Ncols)
+	for k, x := range a.Entries {
+		t.Entries[k] = op(x, k.Row, k.Col, val)
+	}
+	matrixWriteBack(c, mask, accum, t, desc)
+}
@@ -0,0 +1,259 @@
+package reference
+
+import "github.com/intel/forGraphBLASGo/GrB"
+
+// The assign and subassign operations differ in how the mask and the replace
+// flag are applied:
+//
+//   - assign: c<mask>(I, J) = accum(c(I, J), a). The mask has the dimensions of c.
+//     First z is computed as a copy of c with z(I, J) = accum(c(I, J), a), and then
+//     c<mask> = z is applied to all of c, so that with replace set, entries of c
+//     outside of I x J where the mask is false are deleted as well.
+//
+//   - subassign: c(I, J)<mask> = accum(c(I, J), a). The mask has the dimensions of a.
+//     The operation c(I, J)<mask> = accum(c(I, J), a) only ever modifies c(I, J), and
+//     entries of c outside of I x J are never affected.
+//
+// Duplicate indices in I or J lead to undefined results, as in [GrB].
+
+// region computes the positions of I x J in c, keyed by their position in the
+// submatrix. If a is transposed, it must be transposed before calling region.
+func region(rowIndices, colIndices []int, nrows, ncols int) map[Coord]Coord {
+	r := make(map[Coord]Coord, len(rowIndices)*len(colIndices))
+	for a, i := range rowIndices {
+		checkIndex(i, nrows)
+		for b, j := range colIndices {
+			checkIndex(j, ncols)
+			r[Coord{a, b}] = Coord{i, j}
+		}
+	}
+	return r
+}
+
+// assignRegion computes z = c with z(I, J) = accum(c(I, J), a) and then c<mask> = z.
+func assignRegion[D any](
+	c Matrix[D],
+	mask *Matrix[bool],
+	accum BinaryOp[D, D, D],
+	a Matrix[D],
+	reg map[Coord]Coord,
+	desc *Descriptor,
+) {
+	z := c.Dup()
+	for sub, pos := range reg {
+		x, inA := a.Entries[sub]
+		old, inC := c.Entries[pos]
+		switch {
+		case inA && inC && accum != nil:
+			z.Entries[pos] = accum(old, x)
+		case inA:
+			z.Entries[pos] = x
+		case accum == nil:
+			delete(z.Entries, pos)
+		}
+	}
+	matrixWriteBack(c, mask, nil, z, desc)
+}
+
+// subassignRegion computes c(I, J)<mask> = accum(c(I, J), a).
+func subassignRegion[D any](
+	c Matrix[D],
+	mask *Matrix[bool],
+	accum BinaryOp[D, D, D],
+	a Matrix[D],
+	reg map[Coord]Coord,
+	desc *Descriptor,
+) {
+	s := MatrixNew[D](a.Nrows, a.Ncols)
+	for sub, pos := range reg {
+		if x, ok := c.Entries[pos]; ok {
+			s.Entries[sub] = x
+		}
+	}
+	matrixWriteBack(s, mask, accum, a, desc)
+	for sub, pos := range reg {
+		if x, ok := s.Entries[sub]; ok {
+			c.Entries[pos] = x
+		} else {
+			delete(c.Entries, pos)
+		}
+	}
+}
+
+// constantMatrix returns a matrix of the given dimensions where every entry is val.
+func constantMatrix[D any](nrows, ncols int, val D) Matrix[D] {
+	m := MatrixNew[D](nrows, ncols)
+	for i := 0; i < nrows; i++ {
+		for j := 0; j < ncols; j++ {
+			m.Entries[Coord{i, j}] = val
+		}
+	}
+	return m
+}
+
+func checkMaskSize(mask *Matrix[bool], nrows, ncols int) {
+	if mask != nil && (mask.Nrows != nrows || mask.Ncols != ncols) {
+		panic(GrB.DimensionMismatch)
+	}
+}
+
+func checkVectorMaskSize(mask *Vector[bool], size int) {
+	if mask != nil && mask.N != size {
+		panic(GrB.DimensionMismatch)
+	}
+}
+
+// MatrixAssign computes c<mask>(rowIndices, colIndices) = accum(c(rowIndices, colIndices), a).
+//
+// The [Descriptor] field Tran0 transposes a.
+func MatrixAssign[D any](
+	c Matrix[D],
+	mask *Matrix[bool],
+	accum BinaryOp[D, D, D],
+	a Matrix[D],
+	rowIndices, colIndices []int,
+	desc *Descriptor,
+) {
+	a = transposeIf(a, desc.tran0())
+	if a.Nrows != len(rowIndices) || a.Ncols != len(colIndices) {
+		panic(GrB.DimensionMismatch)
+	}
+	checkMaskSize(mask, c.Nrows, c.Ncols)
+	assignRegion(c, mask, accum, a.Dup(), region(rowIndices, colIndices, c.Nrows, c.Ncols), desc)
+}
+
+// MatrixAssignConstant is like [MatrixAssign], except that every position of
+// c(rowIndices, colIndices) is assigned the scalar val.
+func MatrixAssignConstant[D any](
+	c Matrix[D],
+	mask *Matrix[bool],
+	accum BinaryOp[D, D, D],
+	val D,
+	rowIndices, colIndices []int,
+	desc *Descriptor,
+) {
+	checkMaskSize(mask, c.Nrows, c.Ncols)
+	a := constantMatrix(len(rowIndices), len(colIndices), val)
+	assignRegion(c, mask, accum, a, region(rowIndices, colIndices, c.Nrows, c.Ncols), desc)
+}
+
+// MatrixSubassign computes c(rowIndices, colIndices)<mask> = accum(c(rowIndices, colIndices), a).
+//
+// The [Descriptor] field Tran0 transposes a.
+func MatrixSubassign[D any](
+	c Matrix[D],
+	mask *Matrix[bool],
+	accum BinaryOp[D, D, D],
+	a Matrix[D],
+	rowIndices, colIndices []int,
+	desc *Descriptor,
+) {
+	a = transposeIf(a, desc.tran0())
+	if a.Nrows != len(rowIndices) || a.Ncols != len(colIndices) {
+		panic(GrB.DimensionMismatch)
+	}
+	checkMaskSize(mask, a.Nrows, a.Ncols)
+	subassignRegion(c, mask, accum, a.Dup(), region(rowIndices, colIndices, c.Nrows, c.Ncols), desc)
+}
+
+// MatrixSubassignConstant is like [MatrixSubassign], except that every position of
+// c(rowIndices, colIndices) is assigned the scalar val.
+func MatrixSubassignConstant[D any](
+	c Matrix[D],
+	mask *Matrix[bool],
+	accum BinaryOp[D, D, D],
+	val D,
+	rowIndices, colIndices []int,
+	desc *Descriptor,
+) {
+	checkMaskSize(mask, len(rowIndices), len(colIndices))
+	a := constantMatrix(len(rowIndices), len(colIndices), val)
+	subassignRegion(c, mask, accum, a, region(rowIndices, colIndices, c.Nrows, c.Ncols), desc)
+}
+
+// vectorAsMatrixMask converts a vector mask to an n x 1 matrix mask.
+func vectorAsMatrixMask(mask *Vector[bool]) *Matrix[bool] {
+	if mask == nil {
+		return nil
+	}
+	m := mask.asColumn()
+	return &m
+}
+
+// vectorAssignVia runs a matrix assign or subassign function on w viewed as a column.
+func vectorAssignVia[D any](
+	w Vector[D],
+	mask *Vector[bool],
+	accum BinaryOp[D, D, D],
+	u Matrix[D],
+	indices []int,
+	desc *Descriptor,
+	f func(Matrix[D], *Matrix[bool], BinaryOp[D, D, D], Matrix[D], map[Coord]Coord, *Descriptor),
+) {
+	c := w.asColumn()
+	f(c, vectorAsMatrixMask(mask), accum, u, region(indices, []int{0}, w.N, 1), desc)
+	clear(w.Entries)
+	for k, x := range c.Entries {
+		w.Entries[k.Row] = x
+	}
+}
+
+// VectorAssign computes w<mask>(indices) = accum(w(indices), u).
+func VectorAssign[D any](
+	w Vector[D],
+	mask *Vector[bool],
+	accum BinaryOp[D, D, D],
+	u Vector[D],
+	indices []int,
+	desc *Descriptor,
+) {
+	if u.N != len(indices) {
+		panic(GrB.DimensionMismatch)
+	}
+	checkVectorMaskSize(mask, w.N)
+	vectorAssignVia(w, mask, accum, u.asColumn(), indices, desc, assignRegion[D])
+}
+
+// VectorAssignConstant is like [VectorAssign], except that every position of
+// w(indices) is assigned the scalar val.
+func VectorAssignConstant[D any](
+	w Vector[D],
+	mask *Vector[bool],
+	accum BinaryOp[D, D, D],
+	val D,
+	indices []int,
+	desc *Descriptor,
+) {
+	checkVectorMaskSize(mask, w.N)
+	vectorAssignVia(w, mask, accum, constantMatrix(len(indices), 1, val), indices, desc, assignRegion[D])
+}
+
+// VectorSubassign computes w(indices)<mask> = accum(w(indices), u).
+func VectorSubassign[D any](
+	w Vector[D],
+	mask *Vector[bool],
+	accum BinaryOp[D, D, D],
+	u Vector[D],
+	indices []int,
+	desc *Descriptor,
+) {
+	if u.N != len(indices) {
+		panic(GrB.DimensionMismatch)
+	}
+	checkVectorMaskSize(mask, u.N)
+	vectorAssignVia(w, mask, accum, u.asColumn(), indices, desc, subassignRegion[D])
+}
+
+// VectorSubassignConstant is like [VectorSubassign], except that every position of
+// w(indices) is assigned the scalar val.
+func VectorSubassignConstant[D any](
+	w Vector[D],
+	mask *Vector[bool],
+	accum BinaryOp[D, D, D],
+	val D,
+	indices []int,
+	desc *Descriptor,
+) {
+	checkVectorMaskSize(mask, len(indices))
+	vectorAssignVia(w, mask, accum, constantMatrix(len(indices), 1, val), indices, desc, subassignRegion[D])
+}
@@ -0,0 +1,207 @@
+package reference
+
+import "github.com/intel/forGraphBLASGo/GrB"
+
+// ewiseAdd computes the set union of a and b, applying op where both are present.
+func ewiseAdd[K comparable, D any](t, a, b map[K]D, op BinaryOp[D, D, D]) {
+	for k, x := range a {
+		if y, ok := b[k]; ok {
+			t[k] = op(x, y)
+		} else {
+			t[k] = x
+		}
+	}
+	for k, y := range b {
+		if _, ok := a[k]; !ok {
+			t[k] = y
+		}
+	}
+}
+
+// ewiseMult computes the set intersection of a and b, applying op to each pair.
+func ewiseMult[K comparable, DC, DA, DB any](t map[K]DC, a map[K]DA, b map[K]DB, op BinaryOp[DC, DA, DB]) {
+	for k, x := range a {
+		if y, ok := b[k]; ok {
+			t[k] = op(x, y)
+		}
+	}
+}
+
+// ewiseUnion computes the set union of a and b, applying op to each pair, where
+// alpha and beta stand in for entries that are missing in a and b, respectively.
+func ewiseUnion[K comparable, DC, DA, DB any](t map[K]DC, a map[K]DA, alpha DA, b map[K]DB, beta DB, op BinaryOp[DC, DA, DB]) {
+	for k, x := range a {
+		if y, ok := b[k]; ok {
+			t[k] = op(x, y)
+		} else {
+			t[k] = op(x, beta)
+		}
+	}
+	for k, y := range b {
+		if _, ok := a[k]; !ok {
+			t[k] = op(alpha, y)
+		}
+	}
+}
+
+// VectorEWiseAddBinaryOp performs element-wise addition on the elements of two vectors:
+// w<mask> = accum(w, u + v), where + is op for positions present in both u and v.
+// Entries present in only one of u and v are copied to the result.
+//
+// Unlike [GrB.VectorEWiseAddBinaryOp], all domains must be the same, since entries
+// that are copied cannot be typecast.
+func VectorEWiseAddBinaryOp[D any](
+	w Vector[D],
+	mask *Vector[bool],
+	accum BinaryOp[D, D, D],
+	op BinaryOp[D, D, D],
+	u, v Vector[D],
+	desc *Descriptor,
+) {
+	if u.N != w.N || v.N != w.N {
+		panic(GrB.DimensionMismatch)
+	}
+	t := VectorNew[D](w.N)
+	ewiseAdd(t.Entries, u.Entries, v.Entries, op)
+	vectorWriteBack(w, mask, accum, t, desc)
+}
+
+// VectorEWiseAddMonoid is like [VectorEWiseAddBinaryOp], except that the operator of a [Monoid] is used.
+func VectorEWiseAddMonoid[D any](
+	w Vector[D],
+	mask *Vector[bool],
+	accum BinaryOp[D, D, D],
+	op Monoid[D],
+	u, v Vector[D],
+	desc *Descriptor,
+) {
+	VectorEWiseAddBinaryOp(w, mask, accum, op.Op, u, v, desc)
+}
+
+// MatrixEWiseAddBinaryOp performs element-wise addition on the elements of two matrices:
+// c<mask> = accum(c, a + b), where + is op for positions present in both a and b.
+// Entries present in only one of a and b are copied to the result.
+//
+// Unlike [GrB.MatrixEWiseAddBinaryOp], all domains must be the same, since entries
+// that are copied cannot be typecast.
+//
+// The [Descriptor] fields Tran0 and Tran1 transpose a and b, respectively.
+func MatrixEWiseAddBinaryOp[D any](
+	c Matrix[D],
+	mask *Matrix[bool],
+	accum BinaryOp[D, D, D],
+	op BinaryOp[D, D, D],
+	a, b Matrix[D],
+	desc *Descriptor,
+) {
+	a = transposeIf(a, desc.tran0())
+	b = transposeIf(b, desc.tran1())
+	if a.Nrows != c.Nrows || a.Ncols != c.Ncols || b.Nrows != c.Nrows || b.Ncols != c.Ncols {
+		panic(GrB.DimensionMismatch)
+	}
+	t := MatrixNew[D](c.Nrows, c.Ncols)
+	ewiseAdd(t.Entries, a.Entries, b.Entries, op)
+	matrixWriteBack(c, mask, accum, t, desc)
+}
+
+// MatrixEWiseAddMonoid is like [MatrixEWiseAddBinaryOp], except that the operator of a [Monoid] is used.
+func MatrixEWiseAddMonoid[D any](
+	c Matrix[D],
+	mask *Matrix[bool],
+	accum BinaryOp[D, D, D],
+	op Monoid[D],
+	a, b Matrix[D],
+	desc *Descriptor,
+) {
+	MatrixEWiseAddBinaryOp(c, mask, accum, op.Op, a, b, desc)
+}
+
+// VectorEWiseMultBinaryOp performs element-wise multiplication on the elements of two vectors:
+// w<mask> = accum(w, u .* v), where .* is op, and the result only has entries at positions
+// present in both u and v.
+func VectorEWiseMultBinaryOp[Dw, Du, Dv any](
+	w Vector[Dw],
+	mask *Vector[bool],
+	accum BinaryOp[Dw, Dw, Dw],
+	op BinaryOp[Dw, Du, Dv],
+	u Vector[Du],
+	v Vector[Dv],
+	desc *Descriptor,
+) {
+	if u.N != w.N || v.N != w.N {
+		panic(GrB.DimensionMismatch)
+	}
+	t := VectorNew[Dw](w.N)
+	ewiseMult(t.Entries, u.Entries, v.Entries, op)
+	vectorWriteBack(w, mask, accum, t, desc)
+}
+
+// MatrixEWiseMultBinaryOp performs element-wise multiplication on the elements of two matrices:
+// c<mask> = accum(c, a .* b), where .* is op, and the result only has entries at positions
+// present in both a and b.
+//
+// The [Descriptor] fields Tran0 and Tran1 transpose a and b, respectively.
+func MatrixEWiseMultBinaryOp[DC, DA, DB any](
+	c Matrix[DC],
+	mask *Matrix[bool],
+	accum BinaryOp[DC, DC, DC],
+	op BinaryOp[DC, DA, DB],
+	a Matrix[DA],
+	b Matrix[DB],
+	desc *Descriptor,
+) {
+	a = transposeIf(a, desc.tran0())
+	b = transposeIf(b, desc.tran1())
+	if a.Nrows != c.Nrows || a.Ncols != c.Ncols || b.Nrows != c.Nrows || b.Ncols != c.Ncols {
+		panic(GrB.DimensionMismatch)
+	}
+	t := MatrixNew[DC](c.Nrows, c.Ncols)
+	ewiseMult(t.Entries, a.Entries, b.Entries, op)
+	matrixWriteBack(c, mask, accum, t, desc)
+}
+
+// VectorEWiseUnion is like [VectorEWiseAddBinaryOp], except that op is used for all entries
+// of the result: alpha replaces missing entries of u, and beta replaces missing entries of v.
+func VectorEWiseUnion[Dw, Du, Dv any](
+	w Vector[Dw],
+	mask *Vector[bool],
+	accum BinaryOp[Dw, Dw, Dw],
+	op BinaryOp[Dw, Du, Dv],
+	u Vector[Du],
+	alpha Du,
+	v Vector[Dv],
+	beta Dv,
+	desc *Descriptor,
+) {
+	if u.N != w.N || v.N != w.N {
+		panic(GrB.DimensionMismatch)
+	}
+	t := VectorNew[Dw](w.N)
+	ewiseUnion(t.Entries, u.Entries, alpha, v.Entries, beta, op)
+	vectorWriteBack(w, mask, accum, t, desc)
+}
+
+// MatrixEWiseUnion is like [MatrixEWiseAddBinaryOp], except that op is used for all entries
+// of the result: alpha replaces missing entries of a, and beta replaces missing entries of b.
+//
+// The [Descriptor] fields Tran0 and Tran1 transpose a and b, respectively.
+func MatrixEWiseUnion[DC, DA, DB any](
+	c Matrix[DC],
+	mask *Matrix[bool],
+	accum BinaryOp[DC, DC, DC],
+	op BinaryOp[DC, DA, DB],
+	a Matrix[DA],
+	alpha DA,
+	b Matrix[DB],
+	beta DB,
+	desc *Descriptor,
+) {
+	a = transposeIf(a, desc.tran0())
+	b = transposeIf(b, desc.tran1())
+	if a.Nrows != c.Nrows || a.Ncols != c.Ncols || b.Nrows != c.Nrows || b.Ncols != c.Ncols {
+		panic(GrB.DimensionMismatch)
+	}
+	t := MatrixNew[DC](c.Nrows, c.Ncols)
+	ewiseUnion(t.Entries, a.Entries, alpha, b.Entries, beta, op)
+	matrixWriteBack(c, mask, accum, t, desc)
+}
@@ -0,0 +1,87 @@
+package reference
+
+import "github.com/intel/forGraphBLASGo/GrB"
+
+// VectorExtract extracts a sub-vector: w<mask> = accum(w, u(indices)).
+func VectorExtract[D any](
+	w Vector[D],
+	mask *Vector[bool],
+	accum BinaryOp[D, D, D],
+	u Vector[D],
+	indices []int,
+	desc *Descriptor,
+) {
+	if len(indices) != w.N {
+		panic(GrB.DimensionMismatch)
+	}
+	t := VectorNew[D](w.N)
+	for k, i := range indices {
+		checkIndex(i, u.N)
+		if x, ok := u.Entries[i]; ok {
+			t.Entries[k] = x
+		}
+	}
+	vectorWriteBack(w, mask, accum, t, desc)
+}
+
+// MatrixExtract extracts a sub-matrix: c<mask> = accum(c, a(rowIndices, colIndices)).
+//
+// The [Descriptor] field Tran0 transposes a before the sub-matrix is extracted.
+func MatrixExtract[D any](
+	c Matrix[D],
+	mask *Matrix[bool],
+	accum BinaryOp[D, D, D],
+	a Matrix[D],
+	rowIndices, colIndices []int,
+	desc *Descriptor,
+) {
+	a = transposeIf(a, desc.tran0())
+	if len(rowIndices) != c.Nrows || len(colIndices) != c.Ncols {
+		panic(GrB.DimensionMismatch)
+	}
+	t := MatrixNew[D](c.Nrows, c.Ncols)
+	for r, i := range rowIndices {
+		checkIndex(i, a.Nrows)
+		for s, j := range colIndices {
+			checkIndex(j, a.Ncols)
+			if x, ok := a.Entries[Coord{i, j}]; ok {
+				t.Entries[Coord{r, s}] = x
+			}
+		}
+	}
+	matrixWriteBack(c, mask, accum, t, desc)
+}
+
+// MatrixColExtract extracts a sub-vector from a column of a matrix:
+// w<mask> = accum(w, a(rowIndices, col)).
+//
+// The [Descriptor] field Tran0 transposes a, so that a row is extracted instead.
+func MatrixColExtract[D any](
+	w Vector[D],
+	mask *Vector[bool],
+	accum BinaryOp[D, D, D],
+	a Matrix[D],
+	rowIndices []int,
+	col int,
+	desc *Descriptor,
+) {
+	a = transposeIf(a, desc.tran0())
+	if len(rowIndices) != w.N {
+		panic(GrB.DimensionMismatch)
+	}
+	checkIndex(col, a.Ncols)
+	t := VectorNew[D](w.N)
+	for r, i := range rowIndices {
+		checkIndex(i, a.Nrows)
+		if x, ok := a.Entries[Coord{i, col}]; ok {
+			t.Entries[r] = x
+		}
+	}
+	vectorWriteBack(w, mask, accum, t, desc)
+}
+
+func checkIndex(index, size int) {
+	if index < 0 || index >= size {
+		panic(GrB.IndexOutOfBounds)
+	}
+}
@@ -0,0 +1,269 @@
+package reference
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+
+	"github.com/intel/forGraphBLASGo/GrB"
+)
+
+// MatrixFromGrB returns a reference copy of a [GrB.Matrix].
+func MatrixFromGrB[D any](a GrB.Matrix[D]) (m Matrix[D], err error) {
+	nrows, ncols, err := a.Size()
+	if err != nil {
+		return
+	}
+	var rows, cols []int
+	var vals []D
+	if err = a.ExtractTuples(&rows, &cols, &vals); err != nil {
+		return
+	}
+	return MatrixFromTuples(nrows, ncols, rows, cols, vals), nil
+}
+
+// VectorFromGrB returns a reference copy of a [GrB.Vector].
+func VectorFromGrB[D any](u GrB.Vector[D]) (v Vector[D], err error) {
+	size, err := u.Size()
+	if err != nil {
+		return
+	}
+	var indices []int
+	var vals []D
+	if err = u.ExtractTuples(&indices, &vals); err != nil {
+		return
+	}
+	return VectorFromTuples(size, indices, vals), nil
+}
+
+// ToGrB returns a new [GrB.Matrix] with the same dimensions and entries as m.
+// The caller is responsible for freeing the result.
+func (m Matrix[D]) ToGrB() (a GrB.Matrix[D], err error) {
+	a, err = GrB.MatrixNew[D](m.Nrows, m.Ncols)
+	if err != nil {
+		return
+	}
+	rows, cols, vals := m.ExtractTuples()
+	if err = a.Build(rows, cols, vals, nil); err != nil {
+		_ = a.Free()
+	}
+	return
+}
+
+// ToGrB returns a new [GrB.Vector] with the same size and entries as v.
+// The caller is responsible for freeing the result.
+func (v Vector[D]) ToGrB() (u GrB.Vector[D], err error) {
+	u, err = GrB.VectorNew[D](v.N)
+	if err != nil {
+		return
+	}
+	indices, vals := v.ExtractTuples()
+	if err = u.Build(indices, vals, nil); err != nil {
+		_ = u.Free()
+	}
+	return
+}
+
+// A Mismatch describes the first difference found between the result computed by
+// [GrB] and the result computed by the reference implementation.
+//
+// For dimension mismatches, Got and Want hold the dimensions as [2]int for matrices,
+// or as int for vectors. Otherwise, Row and Col identify the position of the
+// first entry that differs in row-major order (Col is always 0 for vectors), and
+// Got and Want hold the respective entries, or nil if the entry is not present.
+type Mismatch struct {
+	Kind      MismatchKind
+	Row, Col  int
+	Got, Want any
+}
+
+// MismatchKind classifies a [Mismatch].
+type MismatchKind int
+
+const (
+	// DimensionsDiffer indicates that the results have different dimensions.
+	DimensionsDiffer MismatchKind = iota
+	// MissingEntry indicates that the GrB result lacks an entry that the reference result has.
+	MissingEntry
+	// ExtraEntry indicates that the GrB result has an entry that the reference result lacks.
+	ExtraEntry
+	// ValuesDiffer indicates that both results have an entry with different values.
+	ValuesDiffer
+)
+
+var mismatchKindStrings = map[MismatchKind]string{
+	DimensionsDiffer: "dimensions differ",
+	MissingEntry:     "missing entry",
+	ExtraEntry:       "extra entry",
+	ValuesDiffer:     "values differ",
+}
+
+func (kind MismatchKind) String() string {
+	return mismatchKindStrings[kind]
+}
+
+func (m *Mismatch) Error() string {
+	if m.Kind == DimensionsDiffer {
+		return fmt.Sprintf("reference: %v: got %v, want %v", m.Kind, m.Got, m.Want)
+	}
+	return fmt.Sprintf("reference: %v at (%v, %v): got %v, want %v", m.Kind, m.Row, m.Col, m.Got, m.Want)
+}
+
+// Equal reports whether x and y are equal according to ==. It can be passed as
+// the equal parameter of [CompareMatrix] and related functions.
+func Equal[D comparable](x, y D) bool {
+	return x == y
+}
+
+// ApproxEqual returns a function that reports whether x and y are equal within the
+// given relative tolerance. Two NaN values are considered equal. ApproxEqual is useful
+// for comparing results of floating-point operations where the order of evaluation
+// in [GrB] is unspecified.
+func ApproxEqual[D GrB.Float](tol float64) func(x, y D) bool {
+	return func(x, y D) bool {
+		return approxEqual(float64(x), float64(y), tol)
+	}
+}
+
+// ApproxEqualComplex is like [ApproxEqual], for complex numbers.
+func ApproxEqualComplex[D GrB.Complex](tol float64) func(x, y D) bool {
+	return func(x, y D) bool {
+		cx, cy := complex128(x), complex128(y)
+		if cmplx.IsNaN(cx) || cmplx.IsNaN(cy) {
+			return cmplx.IsNaN(cx) && cmplx.IsNaN(cy)
+		}
+		return cmplx.Abs(cx-cy) <= tol*math.Max(1, math.Max(cmplx.Abs(cx), cmplx.Abs(cy)))
+	}
+}
+
+func approxEqual(x, y, tol float64) bool {
+	if math.IsNaN(x) || math.IsNaN(y) {
+		return math.IsNaN(x) && math.IsNaN(y)
+	}
+	if x == y {
+		return true
+	}
+	return math.Abs(x-y) <= tol*math.Max(1, math.Max(math.Abs(x), math.Abs(y)))
+}
+
+// CompareMatrices compares two reference matrices entry by entry, and returns a
+// [*Mismatch] describing the first difference in row-major order, or nil if
+// they are equal.
+func CompareMatrices[D any](got, want Matrix[D], equal func(x, y D) bool) *Mismatch {
+	if got.Nrows != want.Nrows || got.Ncols != want.Ncols {
+		return &Mismatch{
+			Kind: DimensionsDiffer,
+			Got:  [2]int{got.Nrows, got.Ncols},
+			Want: [2]int{want.Nrows, want.Ncols},
+		}
+	}
+	union := got.Dup()
+	for k, x := range want.Entries {
+		union.Entries[k] = x
+	}
+	for _, k := range union.Coords() {
+		x, gotOK := got.Entries[k]
+		y, wantOK := want.Entries[k]
+		switch {
+		case !gotOK:
+			return &Mismatch{Kind: MissingEntry, Row: k.Row, Col: k.Col, Want: y}
+		case !wantOK:
+			return &Mismatch{Kind: ExtraEntry, Row: k.Row, Col: k.Col, Got: x}
+		case !equal(x, y):
+			return &Mismatch{Kind: ValuesDiffer, Row: k.Row, Col: k.Col, Got: x, Want: y}
+		}
+	}
+	return nil
+}
+
+// CompareVectors compares two reference vectors entry by entry, and returns a
+// [*Mismatch] describing the first difference in ascending index order, or nil
+// if they are equal.
+func CompareVectors[D any](got, want Vector[D], equal func(x, y D) bool) *Mismatch {
+	if got.N != want.N {
+		return &Mismatch{Kind: DimensionsDiffer, Got: got.N, Want: want.N}
+	}
+	return CompareMatrices(got.asColumn(), want.asColumn(), equal)
+}
+
+// CompareMatrix compares a [GrB.Matrix] with a reference matrix entry by entry.
+// It returns a [*Mismatch] describing the first difference in row-major order,
+// nil if they are equal, or any error returned while extracting the entries of got.
+func CompareMatrix[D any](got GrB.Matrix[D], want Matrix[D], equal func(x, y D) bool) error {
+	m, err := MatrixFromGrB(got)
+	if err != nil {
+		return err
+	}
+	if mismatch := CompareMatrices(m, want, equal); mismatch != nil {
+		return mismatch
+	}
+	return nil
+}
+
+// CompareVector compares a [GrB.Vector] with a reference vector entry by entry.
+// It returns a [*Mismatch] describing the first difference in ascending index order,
+// nil if they are equal, or any error returned while extracting the entries of got.
+func CompareVector[D any](got GrB.Vector[D], want Vector[D], equal func(x, y D) bool) error {
+	v, err := VectorFromGrB(got)
+	if err != nil {
+		return err
+	}
+	if mismatch := CompareVectors(v, want, equal); mismatch != nil {
+		return mismatch
+	}
+	return nil
+}
+
+// CheckMatrix runs the same operation through [GrB] and the reference implementation,
+// and compares the results with [CompareMatrix].
+//
+// c is the initial content of the output matrix. grbOp is called with a new [GrB.Matrix]
+// holding a copy of c, and refOp is called with another copy of c. Both are expected
+// to perform the operation under test on their argument. The GrB copy is freed before
+// CheckMatrix returns.
+func CheckMatrix[D any](
+	c Matrix[D],
+	grbOp func(c GrB.Matrix[D]) error,
+	refOp func(c Matrix[D]),
+	equal func(x, y D) bool,
+) (err error) {
+	g, err := c.ToGrB()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if ferr := g.Free(); err == nil {
+			err = ferr
+		}
+	}()
+	if err = grbOp(g); err != nil {
+		return
+	}
+	r := c.Dup()
+	refOp(r)
+	return CompareMatrix(g, r, equal)
+}
+
+// CheckVector is like [CheckMatrix], for vectors.
+func CheckVector[D any](
+	w Vector[D],
+	grbOp func(w GrB.Vector[D]) error,
+	refOp func(w Vector[D]),
+	equal func(x, y D) bool,
+) (err error) {
+	g, err := w.ToGrB()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if ferr := g.Free(); err == nil {
+			err = ferr
+		}
+	}()
+	if err = grbOp(g); err != nil {
+		return
+	}
+	r := w.Dup()
+	refOp(r)
+	return CompareVector(g, r, equal)
+}
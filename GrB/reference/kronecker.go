@@ -0,0 +1,57 @@
+package reference
+
+import "github.com/intel/forGraphBLASGo/GrB"
+
+// KroneckerBinaryOp computes the Kronecker product of two matrices:
+// c<mask> = accum(c, kron(a, b)), where
+// kron(a, b)(ia*nrows(b) + ib, ja*ncols(b) + jb) = op(a(ia, ja), b(ib, jb)).
+//
+// The [Descriptor] fields Tran0 and Tran1 transpose a and b, respectively.
+func KroneckerBinaryOp[DC, DA, DB any](
+	c Matrix[DC],
+	mask *Matrix[bool],
+	accum BinaryOp[DC, DC, DC],
+	op BinaryOp[DC, DA, DB],
+	a Matrix[DA],
+	b Matrix[DB],
+	desc *Descriptor,
+) {
+	a = transposeIf(a, desc.tran0())
+	b = transposeIf(b, desc.tran1())
+	if a.Nrows*b.Nrows != c.Nrows || a.Ncols*b.Ncols != c.Ncols {
+		panic(GrB.DimensionMismatch)
+	}
+	t := MatrixNew[DC](c.Nrows, c.Ncols)
+	for ak, x := range a.Entries {
+		for bk, y := range b.Entries {
+			t.Entries[Coord{ak.Row*b.Nrows + bk.Row, ak.Col*b.Ncols + bk.Col}] = op(x, y)
+		}
+	}
+	matrixWriteBack(c, mask, accum, t, desc)
+}
+
+// KroneckerMonoid is like [KroneckerBinaryOp], except that the operator of a [Monoid] is used.
+func KroneckerMonoid[D any](
+	c Matrix[D],
+	mask *Matrix[bool],
+	accum BinaryOp[D, D, D],
+	op Monoid[D],
+	a, b Matrix[D],
+	desc *Descriptor,
+) {
+	KroneckerBinaryOp(c, mask, accum, op.Op, a, b, desc)
+}
+
+// KroneckerSemiring is like [KroneckerBinaryOp], except that the multiplicative operator
+// of a [Semiring] is used.
+func KroneckerSemiring[DC, DA, DB any](
+	c Matrix[DC],
+	mask *Matrix[bool],
+	accum BinaryOp[DC, DC, DC],
+	op Semiring[DC, DA, DB],
+	a Matrix[DA],
+	b Matrix[DB],
+	desc *Descriptor,
+) {
+	KroneckerBinaryOp(c, mask, accum, op.Multiply, a, b, desc)
+}
@@ -0,0 +1,108 @@
+package reference
+
+import "github.com/intel/forGraphBLASGo/GrB"
+
+// MxM multiplies two matrices over a semiring: c<mask> = accum(c, a*b).
+//
+// T(i, j) is the sum over all k, in ascending order of k, of a(i, k) * b(k, j),
+// where both a(i, k) and b(k, j) are present. T(i, j) has no entry if there is no
+// such k.
+//
+// The [Descriptor] fields Tran0 and Tran1 transpose a and b, respectively.
+func MxM[DC, DA, DB any](
+	c Matrix[DC],
+	mask *Matrix[bool],
+	accum BinaryOp[DC, DC, DC],
+	op Semiring[DC, DA, DB],
+	a Matrix[DA],
+	b Matrix[DB],
+	desc *Descriptor,
+) {
+	a = transposeIf(a, desc.tran0())
+	b = transposeIf(b, desc.tran1())
+	if a.Nrows != c.Nrows || b.Ncols != c.Ncols || a.Ncols != b.Nrows {
+		panic(GrB.DimensionMismatch)
+	}
+	t := matrixProduct(op, a, b)
+	matrixWriteBack(c, mask, accum, t, desc)
+}
+
+// matrixProduct computes a*b over a semiring, without a mask.
+func matrixProduct[DC, DA, DB any](op Semiring[DC, DA, DB], a Matrix[DA], b Matrix[DB]) Matrix[DC] {
+	t := MatrixNew[DC](a.Nrows, b.Ncols)
+	brows := make([][]Coord, b.Nrows)
+	for _, k := range b.Coords() {
+		brows[k.Row] = append(brows[k.Row], k)
+	}
+	for _, ak := range a.Coords() {
+		x := a.Entries[ak]
+		for _, bk := range brows[ak.Col] {
+			z := op.Multiply(x, b.Entries[bk])
+			pos := Coord{ak.Row, bk.Col}
+			if old, ok := t.Entries[pos]; ok {
+				t.Entries[pos] = op.Add.Op(old, z)
+			} else {
+				t.Entries[pos] = z
+			}
+		}
+	}
+	return t
+}
+
+// MxV multiplies a matrix by a vector over a semiring: w<mask> = accum(w, a*u).
+//
+// The [Descriptor] field Tran0 transposes a.
+func MxV[Dw, DA, Du any](
+	w Vector[Dw],
+	mask *Vector[bool],
+	accum BinaryOp[Dw, Dw, Dw],
+	op Semiring[Dw, DA, Du],
+	a Matrix[DA],
+	u Vector[Du],
+	desc *Descriptor,
+) {
+	a = transposeIf(a, desc.tran0())
+	if a.Nrows != w.N || a.Ncols != u.N {
+		panic(GrB.DimensionMismatch)
+	}
+	t := matrixProduct(op, a, u.asColumn())
+	vectorWriteBack(w, mask, accum, columnOf(t), desc)
+}
+
+// VxM multiplies a vector by a matrix over a semiring: w<mask> = accum(w, u*a).
+//
+// The [Descriptor] field Tran1 transposes a.
+func VxM[Dw, Du, DA any](
+	w Vector[Dw],
+	mask *Vector[bool],
+	accum BinaryOp[Dw, Dw, Dw],
+	op Semiring[Dw, Du, DA],
+	u Vector[Du],
+	a Matrix[DA],
+	desc *Descriptor,
+) {
+	a = transposeIf(a, desc.tran1())
+	if a.Ncols != w.N || a.Nrows != u.N {
+		panic(GrB.DimensionMismatch)
+	}
+	t := matrixProduct(op, u.asRow(), a)
+	vectorWriteBack(w, mask, accum, rowOf(t), desc)
+}
+
+// columnOf converts an n x 1 matrix to a vector.
+func columnOf[D any](m Matrix[D]) Vector[D] {
+	v := VectorNew[D](m.Nrows)
+	for k, x := range m.Entries {
+		v.Entries[k.Row] = x
+	}
+	return v
+}
+
+// rowOf converts a 1 x n matrix to a vector.
+func rowOf[D any](m Matrix[D]) Vector[D] {
+	v := VectorNew[D](m.Ncols)
+	for k, x := range m.Entries {
+		v.Entries[k.Col] = x
+	}
+	return v
+}
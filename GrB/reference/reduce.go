@@ -0,0 +1,65 @@
+package reference
+
+import "github.com/intel/forGraphBLASGo/GrB"
+
+// MatrixReduceBinaryOp reduces each row of a matrix to a scalar:
+// w<mask> = accum(w, [⊕_j a(:, j)]). The entries of each row are combined
+// in ascending order of their column indices. Rows without entries produce
+// no entry in the result.
+//
+// The [Descriptor] field Tran0 transposes a, so that columns are reduced instead.
+func MatrixReduceBinaryOp[D any](
+	w Vector[D],
+	mask *Vector[bool],
+	accum BinaryOp[D, D, D],
+	op BinaryOp[D, D, D],
+	a Matrix[D],
+	desc *Descriptor,
+) {
+	a = transposeIf(a, desc.tran0())
+	if a.Nrows != w.N {
+		panic(GrB.DimensionMismatch)
+	}
+	t := VectorNew[D](w.N)
+	for _, k := range a.Coords() {
+		x := a.Entries[k]
+		if old, ok := t.Entries[k.Row]; ok {
+			t.Entries[k.Row] = op(old, x)
+		} else {
+			t.Entries[k.Row] = x
+		}
+	}
+	vectorWriteBack(w, mask, accum, t, desc)
+}
+
+// MatrixReduceMonoid is like [MatrixReduceBinaryOp], except that the operator of a [Monoid] is used.
+func MatrixReduceMonoid[D any](
+	w Vector[D],
+	mask *Vector[bool],
+	accum BinaryOp[D, D, D],
+	op Monoid[D],
+	a Matrix[D],
+	desc *Descriptor,
+) {
+	MatrixReduceBinaryOp(w, mask, accum, op.Op, a, desc)
+}
+
+// VectorReduce reduces all entries of a vector to a scalar, in ascending order
+// of their indices. The result is the identity of the monoid if u has no entries.
+func VectorReduce[D any](op Monoid[D], u Vector[D]) D {
+	result := op.Identity
+	for _, i := range u.Indices() {
+		result = op.Op(result, u.Entries[i])
+	}
+	return result
+}
+
+// MatrixReduce reduces all entries of a matrix to a scalar, in row-major order.
+// The result is the identity of the monoid if a has no entries.
+func MatrixReduce[D any](op Monoid[D], a Matrix[D]) D {
+	result := op.Identity
+	for _, k := range a.Coords() {
+		result = op.Op(result, a.Entries[k])
+	}
+	return result
+}
@@ -0,0 +1,399 @@
+// Package reference is a straightforward, pure-Go implementation of the core
+// GraphBLAS semantics on Go maps. It is intended as an oracle for differential
+// testing of the [GrB] package and of algorithms built on top of it: every
+// operation is implemented in the most obvious way possible, with no attempt at
+// efficiency, so that its results can be trusted when they disagree with those
+// of SuiteSparse:GraphBLAS.
+//
+// The functions in this package mirror the signatures of their counterparts in
+// [GrB] as closely as possible, except that operators are plain Go functions,
+// masks are given as *Matrix[bool] or *Vector[bool] holding the mask values,
+// and descriptors are represented by the [Descriptor] struct. All operations
+// follow the mask/accum/replace rules of the GraphBLAS C API specification.
+//
+// Entry-by-entry comparisons between [GrB] objects and reference objects are
+// provided by [CompareMatrix] and [CompareVector], and [CheckMatrix] and
+// [CheckVector] run the same operation through both implementations.
+//
+// The reference package is a forGraphBLASGo extension.
+package reference
+
+import "sort"
+
+// Coord identifies an entry of a [Matrix] by its row and column index.
+type Coord struct {
+	Row, Col int
+}
+
+// less orders coordinates in row-major order.
+func (c Coord) less(d Coord) bool {
+	if c.Row != d.Row {
+		return c.Row < d.Row
+	}
+	return c.Col < d.Col
+}
+
+// Matrix is a sparse matrix whose entries are stored in a Go map.
+// Positions that are not present in Entries have no entry.
+//
+// Operations that take a Matrix as an output parameter modify its Entries map
+// in place, so like [GrB.Matrix], a Matrix behaves like a reference type.
+type Matrix[D any] struct {
+	Nrows, Ncols int
+	Entries      map[Coord]D
+}
+
+// MatrixNew creates a new matrix with the specified dimensions and no entries.
+func MatrixNew[D any](nrows, ncols int) Matrix[D] {
+	return Matrix[D]{Nrows: nrows, Ncols: ncols, Entries: make(map[Coord]D)}
+}
+
+// MatrixFromTuples creates a new matrix with the specified dimensions from
+// a list of tuples. Duplicate positions are resolved by taking the last value.
+func MatrixFromTuples[D any](nrows, ncols int, rowIndices, colIndices []int, values []D) Matrix[D] {
+	m := MatrixNew[D](nrows, ncols)
+	for k := range values {
+		m.Entries[Coord{rowIndices[k], colIndices[k]}] = values[k]
+	}
+	return m
+}
+
+// Size returns the dimensions of the matrix.
+func (m Matrix[D]) Size() (nrows, ncols int) {
+	return m.Nrows, m.Ncols
+}
+
+// Nvals returns the number of entries in the matrix.
+func (m Matrix[D]) Nvals() int {
+	return len(m.Entries)
+}
+
+// SetElement sets a single entry of the matrix.
+func (m Matrix[D]) SetElement(val D, rowIndex, colIndex int) {
+	m.Entries[Coord{rowIndex, colIndex}] = val
+}
+
+// RemoveElement removes a single entry from the matrix, if present.
+func (m Matrix[D]) RemoveElement(rowIndex, colIndex int) {
+	delete(m.Entries, Coord{rowIndex, colIndex})
+}
+
+// ExtractElement returns a single entry of the matrix, and whether it is present.
+func (m Matrix[D]) ExtractElement(rowIndex, colIndex int) (val D, ok bool) {
+	val, ok = m.Entries[Coord{rowIndex, colIndex}]
+	return
+}
+
+// Clear removes all entries from the matrix.
+func (m Matrix[D]) Clear() {
+	clear(m.Entries)
+}
+
+// Dup returns a deep copy of the matrix.
+func (m Matrix[D]) Dup() Matrix[D] {
+	d := MatrixNew[D](m.Nrows, m.Ncols)
+	for k, v := range m.Entries {
+		d.Entries[k] = v
+	}
+	return d
+}
+
+// Coords returns the positions of all entries of the matrix in row-major order.
+func (m Matrix[D]) Coords() []Coord {
+	coords := make([]Coord, 0, len(m.Entries))
+	for k := range m.Entries {
+		coords = append(coords, k)
+	}
+	sort.Slice(coords, func(i, j int) bool {
+		return coords[i].less(coords[j])
+	})
+	return coords
+}
+
+// ExtractTuples returns all entries of the matrix in row-major order.
+func (m Matrix[D]) ExtractTuples() (rowIndices, colIndices []int, values []D) {
+	coords := m.Coords()
+	rowIndices = make([]int, len(coords))
+	colIndices = make([]int, len(coords))
+	values = make([]D, len(coords))
+	for k, c := range coords {
+		rowIndices[k] = c.Row
+		colIndices[k] = c.Col
+		values[k] = m.Entries[c]
+	}
+	return
+}
+
+// Vector is a sparse vector whose entries are stored in a Go map.
+// Positions that are not present in Entries have no entry.
+//
+// Operations that take a Vector as an output parameter modify its Entries map
+// in place, so like [GrB.Vector], a Vector behaves like a reference type.
+type Vector[D any] struct {
+	N       int
+	Entries map[int]D
+}
+
+// VectorNew creates a new vector with the specified size and no entries.
+func VectorNew[D any](size int) Vector[D] {
+	return Vector[D]{N: size, Entries: make(map[int]D)}
+}
+
+// VectorFromTuples creates a new vector with the specified size from a list
+// of tuples. Duplicate positions are resolved by taking the last value.
+func VectorFromTuples[D any](size int, indices []int, values []D) Vector[D] {
+	v := VectorNew[D](size)
+	for k := range values {
+		v.Entries[indices[k]] = values[k]
+	}
+	return v
+}
+
+// Size returns the size of the vector.
+func (v Vector[D]) Size() int {
+	return v.N
+}
+
+// Nvals returns the number of entries in the vector.
+func (v Vector[D]) Nvals() int {
+	return len(v.Entries)
+}
+
+// SetElement sets a single entry of the vector.
+func (v Vector[D]) SetElement(val D, index int) {
+	v.Entries[index] = val
+}
+
+// RemoveElement removes a single entry from the vector, if present.
+func (v Vector[D]) RemoveElement(index int) {
+	delete(v.Entries, index)
+}
+
+// ExtractElement returns a single entry of the vector, and whether it is present.
+func (v Vector[D]) ExtractElement(index int) (val D, ok bool) {
+	val, ok = v.Entries[index]
+	return
+}
+
+// Clear removes all entries from the vector.
+func (v Vector[D]) Clear() {
+	clear(v.Entries)
+}
+
+// Dup returns a deep copy of the vector.
+func (v Vector[D]) Dup() Vector[D] {
+	d := VectorNew[D](v.N)
+	for k, x := range v.Entries {
+		d.Entries[k] = x
+	}
+	return d
+}
+
+// Indices returns the positions of all entries of the vector in ascending order.
+func (v Vector[D]) Indices() []int {
+	indices := make([]int, 0, len(v.Entries))
+	for k := range v.Entries {
+		indices = append(indices, k)
+	}
+	sort.Ints(indices)
+	return indices
+}
+
+// ExtractTuples returns all entries of the vector in ascending order of their indices.
+func (v Vector[D]) ExtractTuples() (indices []int, values []D) {
+	indices = v.Indices()
+	values = make([]D, len(indices))
+	for k, i := range indices {
+		values[k] = v.Entries[i]
+	}
+	return
+}
+
+// asColumn views the vector as an n x 1 matrix. The result does not share
+// storage with the vector.
+func (v Vector[D]) asColumn() Matrix[D] {
+	m := MatrixNew[D](v.N, 1)
+	for i, x := range v.Entries {
+		m.Entries[Coord{i, 0}] = x
+	}
+	return m
+}
+
+// asRow views the vector as a 1 x n matrix. The result does not share
+// storage with the vector.
+func (v Vector[D]) asRow() Matrix[D] {
+	m := MatrixNew[D](1, v.N)
+	for j, x := range v.Entries {
+		m.Entries[Coord{0, j}] = x
+	}
+	return m
+}
+
+// UnaryOp is a function of one argument.
+type UnaryOp[Dout, Din any] func(x Din) Dout
+
+// BinaryOp is a function of two arguments.
+type BinaryOp[Dout, Din1, Din2 any] func(x Din1, y Din2) Dout
+
+// IndexUnaryOp is a function of one argument, the position of the argument
+// in its container, and an additional scalar value. For vectors, j is always 0.
+type IndexUnaryOp[Dout, Din1, Din2 any] func(x Din1, i, j int, y Din2) Dout
+
+// Monoid is an associative and commutative binary operator together with its
+// identity value.
+type Monoid[D any] struct {
+	Op       BinaryOp[D, D, D]
+	Identity D
+}
+
+// Semiring is an additive monoid together with a multiplicative binary operator.
+type Semiring[Dout, Din1, Din2 any] struct {
+	Add      Monoid[Dout]
+	Multiply BinaryOp[Dout, Din1, Din2]
+}
+
+// Descriptor modifies the behavior of an operation. A nil *Descriptor
+// corresponds to the default behavior.
+//
+// The fields correspond to the descriptor settings of the GraphBLAS C API:
+// Replace is [GrB.Replace] for [GrB.Outp], Comp and Structure are [GrB.Comp]
+// and [GrB.Structure] for [GrB.Mask], and Tran0 and Tran1 are [GrB.Tran] for
+// [GrB.Inp0] and [GrB.Inp1], respectively.
+type Descriptor struct {
+	Replace, Comp, Structure, Tran0, Tran1 bool
+}
+
+// Predefined descriptors, corresponding to the predefined descriptors in [GrB].
+var (
+	DescT1    = &Descriptor{Tran1: true}
+	DescT0    = &Descriptor{Tran0: true}
+	DescT0T1  = &Descriptor{Tran0: true, Tran1: true}
+	DescC     = &Descriptor{Comp: true}
+	DescS     = &Descriptor{Structure: true}
+	DescSC    = &Descriptor{Structure: true, Comp: true}
+	DescR     = &Descriptor{Replace: true}
+	DescRC    = &Descriptor{Replace: true, Comp: true}
+	DescRS    = &Descriptor{Replace: true, Structure: true}
+	DescRSC   = &Descriptor{Replace: true, Structure: true, Comp: true}
+	DescRT0   = &Descriptor{Replace: true, Tran0: true}
+	DescRT1   = &Descriptor{Replace: true, Tran1: true}
+	DescRCT0  = &Descriptor{Replace: true, Comp: true, Tran0: true}
+	DescRSCT0 = &Descriptor{Replace: true, Structure: true, Comp: true, Tran0: true}
+)
+
+func (desc *Descriptor) replace() bool   { return desc != nil && desc.Replace }
+func (desc *Descriptor) comp() bool      { return desc != nil && desc.Comp }
+func (desc *Descriptor) structure() bool { return desc != nil && desc.Structure }
+func (desc *Descriptor) tran0() bool     { return desc != nil && desc.Tran0 }
+func (desc *Descriptor) tran1() bool     { return desc != nil && desc.Tran1 }
+
+// maskFunc returns a function that reports whether the mask allows
+// writing to a given position, taking the Comp and Structure settings
+// of the descriptor into account. A nil mask allows all positions,
+// unless it is complemented.
+func maskFunc[K comparable](entries map[K]bool, present bool, desc *Descriptor) func(K) bool {
+	comp := desc.comp()
+	if !present {
+		return func(K) bool { return !comp }
+	}
+	structure := desc.structure()
+	return func(k K) bool {
+		val, ok := entries[k]
+		allowed := ok && (structure || val)
+		return allowed != comp
+	}
+}
+
+func matrixMask(mask *Matrix[bool], desc *Descriptor) func(Coord) bool {
+	if mask == nil {
+		return maskFunc[Coord](nil, false, desc)
+	}
+	return maskFunc(mask.Entries, true, desc)
+}
+
+func vectorMask(mask *Vector[bool], desc *Descriptor) func(int) bool {
+	if mask == nil {
+		return maskFunc[int](nil, false, desc)
+	}
+	return maskFunc(mask.Entries, true, desc)
+}
+
+// accumulate computes z = accum(c, t): the union of c and t, where
+// positions present in both are combined with accum. If accum is nil,
+// z is t.
+func accumulate[K comparable, D any](c, t map[K]D, accum BinaryOp[D, D, D]) map[K]D {
+	if accum == nil {
+		return t
+	}
+	z := make(map[K]D, len(c)+len(t))
+	for k, v := range c {
+		z[k] = v
+	}
+	for k, v := range t {
+		if old, ok := z[k]; ok {
+			z[k] = accum(old, v)
+		} else {
+			z[k] = v
+		}
+	}
+	return z
+}
+
+// writeBack implements the final step of every GraphBLAS operation,
+// c<mask> = accum(c, t), restricted to the positions for which inScope
+// returns true. Positions outside of the scope are never modified.
+//
+// Within the scope: where the mask allows writing, c receives the entry
+// of z = accum(c, t), or loses its entry if z has none; where the mask
+// does not allow writing, c keeps its entry, unless replace is set, in
+// which case the entry is deleted.
+func writeBack[K comparable, D any](
+	c map[K]D,
+	allowed func(K) bool,
+	inScope func(K) bool,
+	accum BinaryOp[D, D, D],
+	t map[K]D,
+	replace bool,
+) {
+	z := accumulate(c, t, accum)
+	for k := range c {
+		if inScope != nil && !inScope(k) {
+			continue
+		}
+		if allowed(k) {
+			if _, ok := z[k]; !ok {
+				delete(c, k)
+			}
+		} else if replace {
+			delete(c, k)
+		}
+	}
+	for k, v := range z {
+		if inScope != nil && !inScope(k) {
+			continue
+		}
+		if allowed(k) {
+			c[k] = v
+		}
+	}
+}
+
+func matrixWriteBack[D any](c Matrix[D], mask *Matrix[bool], accum BinaryOp[D, D, D], t Matrix[D], desc *Descriptor) {
+	writeBack(c.Entries, matrixMask(mask, desc), nil, accum, t.Entries, desc.replace())
+}
+
+func vectorWriteBack[D any](w Vector[D], mask *Vector[bool], accum BinaryOp[D, D, D], t Vector[D], desc *Descriptor) {
+	writeBack(w.Entries, vectorMask(mask, desc), nil, accum, t.Entries, desc.replace())
+}
+
+// transposeIf returns the transpose of a if tran is set, and a itself otherwise.
+func transposeIf[D any](a Matrix[D], tran bool) Matrix[D] {
+	if !tran {
+		return a
+	}
+	t := MatrixNew[D](a.Ncols, a.Nrows)
+	for k, v := range a.Entries {
+		t.Entries[Coord{k.Col, k.Row}] = v
+	}
+	return t
+}
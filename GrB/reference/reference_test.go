@@ -0,0 +1,571 @@
+package reference_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/intel/forGraphBLASGo/GrB"
+	"github.com/intel/forGraphBLASGo/GrB/reference"
+)
+
+func TestMain(m *testing.M) {
+	if err := GrB.Init(GrB.NonBlocking); err != nil {
+		panic(err)
+	}
+	defer func() {
+		if err := GrB.Finalize(); err != nil {
+			panic(err)
+		}
+	}()
+	m.Run()
+}
+
+type descPair struct {
+	name string
+	ref  *reference.Descriptor
+	grb  *GrB.Descriptor
+}
+
+var descs = []descPair{
+	{"default", nil, nil},
+	{"T0", reference.DescT0, GrB.DescT0},
+	{"T1", reference.DescT1, GrB.DescT1},
+	{"T0T1", reference.DescT0T1, GrB.DescT0T1},
+	{"C", reference.DescC, GrB.DescC},
+	{"S", reference.DescS, GrB.DescS},
+	{"SC", reference.DescSC, GrB.DescSC},
+	{"R", reference.DescR, GrB.DescR},
+	{"RC", reference.DescRC, GrB.DescRC},
+	{"RS", reference.DescRS, GrB.DescRS},
+	{"RSC", reference.DescRSC, GrB.DescRSC},
+	{"RT0", reference.DescRT0, GrB.DescRT0},
+}
+
+var (
+	refPlus  = func(x, y int64) int64 { return x + y }
+	refTimes = func(x, y int64) int64 { return x * y }
+	refMin   = func(x, y int64) int64 { return min(x, y) }
+
+	refPlusMonoid       = reference.Monoid[int64]{Op: refPlus}
+	refPlusTimes        = reference.Semiring[int64, int64, int64]{Add: refPlusMonoid, Multiply: refTimes}
+	refMinPlusSemiring  = reference.Semiring[int64, int64, int64]{Add: reference.Monoid[int64]{Op: refMin, Identity: GrB.Maximum[int64]()}, Multiply: refPlus}
+	grbPlusAccum        = GrB.Plus[int64]()
+	grbPlusTimes        = GrB.PlusTimesSemiring[int64]()
+	grbMinPlusSemiring  = GrB.MinPlusSemiring[int64]()
+	refSemirings        = []reference.Semiring[int64, int64, int64]{refPlusTimes, refMinPlusSemiring}
+	grbSemirings        = []GrB.Semiring[int64, int64, int64]{grbPlusTimes, grbMinPlusSemiring}
+	semiringNames       = []string{"plus_times", "min_plus"}
+	refAccums           = []reference.BinaryOp[int64, int64, int64]{nil, refPlus}
+	grbAccums           = []*GrB.BinaryOp[int64, int64, int64]{nil, &grbPlusAccum}
+	accumNames          = []string{"noaccum", "plus"}
+	testDensities       = []float64{0, 0.3, 1}
+	testRandomSeed      = int64(42)
+	testMatrixDimension = 5
+)
+
+func randomMatrix[D any](rng *rand.Rand, nrows, ncols int, density float64, val func() D) reference.Matrix[D] {
+	m := reference.MatrixNew[D](nrows, ncols)
+	for i := 0; i < nrows; i++ {
+		for j := 0; j < ncols; j++ {
+			if rng.Float64() < density {
+				m.SetElement(val(), i, j)
+			}
+		}
+	}
+	return m
+}
+
+func randomVector[D any](rng *rand.Rand, size int, density float64, val func() D) reference.Vector[D] {
+	v := reference.VectorNew[D](size)
+	for i := 0; i < size; i++ {
+		if rng.Float64() < density {
+			v.SetElement(val(), i)
+		}
+	}
+	return v
+}
+
+func intValue(rng *rand.Rand) func() int64 {
+	return func() int64 { return rng.Int63n(19) - 9 }
+}
+
+func boolValue(rng *rand.Rand) func() bool {
+	return func() bool { return rng.Intn(3) > 0 }
+}
+
+func toGrB[D any](t *testing.T, m reference.Matrix[D]) GrB.Matrix[D] {
+	a, err := m.ToGrB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = a.Free()
+	})
+	return a
+}
+
+func vectorToGrB[D any](t *testing.T, v reference.Vector[D]) GrB.Vector[D] {
+	u, err := v.ToGrB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = u.Free()
+	})
+	return u
+}
+
+// matrixMasks returns the masks to test with: no mask, and a random mask.
+func matrixMasks(t *testing.T, rng *rand.Rand, nrows, ncols int) ([]*reference.Matrix[bool], []*GrB.Matrix[bool]) {
+	m := randomMatrix(rng, nrows, ncols, 0.5, boolValue(rng))
+	g := toGrB(t, m)
+	return []*reference.Matrix[bool]{nil, &m}, []*GrB.Matrix[bool]{nil, &g}
+}
+
+func vectorMasks(t *testing.T, rng *rand.Rand, size int) ([]*reference.Vector[bool], []*GrB.Vector[bool]) {
+	m := randomVector(rng, size, 0.5, boolValue(rng))
+	g := vectorToGrB(t, m)
+	return []*reference.Vector[bool]{nil, &m}, []*GrB.Vector[bool]{nil, &g}
+}
+
+// forEachMatrixSetting calls f for every combination of input density, mask,
+// accumulator and descriptor, with a square output matrix of size n x n.
+func forEachMatrixSetting(
+	t *testing.T,
+	f func(t *testing.T, rng *rand.Rand, density float64, c reference.Matrix[int64],
+		refMask *reference.Matrix[bool], grbMask *GrB.Matrix[bool],
+		refAccum reference.BinaryOp[int64, int64, int64], grbAccum *GrB.BinaryOp[int64, int64, int64],
+		desc descPair),
+) {
+	rng := rand.New(rand.NewSource(testRandomSeed))
+	n := testMatrixDimension
+	refMasks, grbMasks := matrixMasks(t, rng, n, n)
+	for _, density := range testDensities {
+		for mi := range refMasks {
+			for ai := range refAccums {
+				for _, desc := range descs {
+					name := fmt.Sprintf("density=%v/mask=%v/accum=%v/desc=%v", density, mi, accumNames[ai], desc.name)
+					t.Run(name, func(t *testing.T) {
+						c := randomMatrix(rng, n, n, 0.3, intValue(rng))
+						f(t, rng, density, c, refMasks[mi], grbMasks[mi], refAccums[ai], grbAccums[ai], desc)
+					})
+				}
+			}
+		}
+	}
+}
+
+func forEachVectorSetting(
+	t *testing.T,
+	f func(t *testing.T, rng *rand.Rand, density float64, w reference.Vector[int64],
+		refMask *reference.Vector[bool], grbMask *GrB.Vector[bool],
+		refAccum reference.BinaryOp[int64, int64, int64], grbAccum *GrB.BinaryOp[int64, int64, int64],
+		desc descPair),
+) {
+	rng := rand.New(rand.NewSource(testRandomSeed))
+	n := testMatrixDimension
+	refMasks, grbMasks := vectorMasks(t, rng, n)
+	for _, density := range testDensities {
+		for mi := range refMasks {
+			for ai := range refAccums {
+				for _, desc := range descs {
+					name := fmt.Sprintf("density=%v/mask=%v/accum=%v/desc=%v", density, mi, accumNames[ai], desc.name)
+					t.Run(name, func(t *testing.T) {
+						w := randomVector(rng, n, 0.3, intValue(rng))
+						f(t, rng, density, w, refMasks[mi], grbMasks[mi], refAccums[ai], grbAccums[ai], desc)
+					})
+				}
+			}
+		}
+	}
+}
+
+func TestMxM(t *testing.T) {
+	forEachMatrixSetting(t, func(t *testing.T, rng *rand.Rand, density float64, c reference.Matrix[int64],
+		refMask *reference.Matrix[bool], grbMask *GrB.Matrix[bool],
+		refAccum reference.BinaryOp[int64, int64, int64], grbAccum *GrB.BinaryOp[int64, int64, int64],
+		desc descPair) {
+		n := c.Nrows
+		a := randomMatrix(rng, n, n, density, intValue(rng))
+		b := randomMatrix(rng, n, n, density, intValue(rng))
+		ga, gb := toGrB(t, a), toGrB(t, b)
+		for s := range refSemirings {
+			if err := reference.CheckMatrix(c, func(gc GrB.Matrix[int64]) error {
+				return GrB.MxM(gc, grbMask, grbAccum, grbSemirings[s], ga, gb, desc.grb)
+			}, func(rc reference.Matrix[int64]) {
+				reference.MxM(rc, refMask, refAccum, refSemirings[s], a, b, desc.ref)
+			}, reference.Equal[int64]); err != nil {
+				t.Errorf("%v: %v", semiringNames[s], err)
+			}
+		}
+	})
+}
+
+func TestMxVVxM(t *testing.T) {
+	forEachVectorSetting(t, func(t *testing.T, rng *rand.Rand, density float64, w reference.Vector[int64],
+		refMask *reference.Vector[bool], grbMask *GrB.Vector[bool],
+		refAccum reference.BinaryOp[int64, int64, int64], grbAccum *GrB.BinaryOp[int64, int64, int64],
+		desc descPair) {
+		n := w.N
+		a := randomMatrix(rng, n, n, density, intValue(rng))
+		u := randomVector(rng, n, density, intValue(rng))
+		ga, gu := toGrB(t, a), vectorToGrB(t, u)
+		for s := range refSemirings {
+			if err := reference.CheckVector(w, func(gw GrB.Vector[int64]) error {
+				return GrB.MxV(gw, grbMask, grbAccum, grbSemirings[s], ga, gu, desc.grb)
+			}, func(rw reference.Vector[int64]) {
+				reference.MxV(rw, refMask, refAccum, refSemirings[s], a, u, desc.ref)
+			}, reference.Equal[int64]); err != nil {
+				t.Errorf("MxV %v: %v", semiringNames[s], err)
+			}
+			if err := reference.CheckVector(w, func(gw GrB.Vector[int64]) error {
+				return GrB.VxM(gw, grbMask, grbAccum, grbSemirings[s], gu, ga, desc.grb)
+			}, func(rw reference.Vector[int64]) {
+				reference.VxM(rw, refMask, refAccum, refSemirings[s], u, a, desc.ref)
+			}, reference.Equal[int64]); err != nil {
+				t.Errorf("VxM %v: %v", semiringNames[s], err)
+			}
+		}
+	})
+}
+
+func TestMatrixEWise(t *testing.T) {
+	grbMinus := GrB.Minus[int64]()
+	refMinus := func(x, y int64) int64 { return x - y }
+	forEachMatrixSetting(t, func(t *testing.T, rng *rand.Rand, density float64, c reference.Matrix[int64],
+		refMask *reference.Matrix[bool], grbMask *GrB.Matrix[bool],
+		refAccum reference.BinaryOp[int64, int64, int64], grbAccum *GrB.BinaryOp[int64, int64, int64],
+		desc descPair) {
+		n := c.Nrows
+		a := randomMatrix(rng, n, n, density, intValue(rng))
+		b := randomMatrix(rng, n, n, density, intValue(rng))
+		ga, gb := toGrB(t, a), toGrB(t, b)
+		if err := reference.CheckMatrix(c, func(gc GrB.Matrix[int64]) error {
+			return GrB.MatrixEWiseAddBinaryOp(gc, grbMask, grbAccum, grbMinus, ga, gb, desc.grb)
+		}, func(rc reference.Matrix[int64]) {
+			reference.MatrixEWiseAddBinaryOp(rc, refMask, refAccum, refMinus, a, b, desc.ref)
+		}, reference.Equal[int64]); err != nil {
+			t.Errorf("eWiseAdd: %v", err)
+		}
+		if err := reference.CheckMatrix(c, func(gc GrB.Matrix[int64]) error {
+			return GrB.MatrixEWiseMultBinaryOp(gc, grbMask, grbAccum, grbMinus, ga, gb, desc.grb)
+		}, func(rc reference.Matrix[int64]) {
+			reference.MatrixEWiseMultBinaryOp(rc, refMask, refAccum, refMinus, a, b, desc.ref)
+		}, reference.Equal[int64]); err != nil {
+			t.Errorf("eWiseMult: %v", err)
+		}
+		alpha, beta := int64(100), int64(-100)
+		if err := reference.CheckMatrix(c, func(gc GrB.Matrix[int64]) (err error) {
+			galpha, err := GrB.ScalarNew[int64]()
+			if err != nil {
+				return
+			}
+			defer func() {
+				_ = galpha.Free()
+			}()
+			gbeta, err := GrB.ScalarNew[int64]()
+			if err != nil {
+				return
+			}
+			defer func() {
+				_ = gbeta.Free()
+			}()
+			if err = galpha.SetElement(alpha); err != nil {
+				return
+			}
+			if err = gbeta.SetElement(beta); err != nil {
+				return
+			}
+			return GrB.MatrixEWiseUnion(gc, grbMask, grbAccum, grbMinus, ga, galpha, gb, gbeta, desc.grb)
+		}, func(rc reference.Matrix[int64]) {
+			reference.MatrixEWiseUnion(rc, refMask, refAccum, refMinus, a, alpha, b, beta, desc.ref)
+		}, reference.Equal[int64]); err != nil {
+			t.Errorf("eWiseUnion: %v", err)
+		}
+	})
+}
+
+func TestVectorEWise(t *testing.T) {
+	grbMinus := GrB.Minus[int64]()
+	refMinus := func(x, y int64) int64 { return x - y }
+	forEachVectorSetting(t, func(t *testing.T, rng *rand.Rand, density float64, w reference.Vector[int64],
+		refMask *reference.Vector[bool], grbMask *GrB.Vector[bool],
+		refAccum reference.BinaryOp[int64, int64, int64], grbAccum *GrB.BinaryOp[int64, int64, int64],
+		desc descPair) {
+		u := randomVector(rng, w.N, density, intValue(rng))
+		v := randomVector(rng, w.N, density, intValue(rng))
+		gu, gv := vectorToGrB(t, u), vectorToGrB(t, v)
+		if err := reference.CheckVector(w, func(gw GrB.Vector[int64]) error {
+			return GrB.VectorEWiseAddBinaryOp(gw, grbMask, grbAccum, grbMinus, gu, gv, desc.grb)
+		}, func(rw reference.Vector[int64]) {
+			reference.VectorEWiseAddBinaryOp(rw, refMask, refAccum, refMinus, u, v, desc.ref)
+		}, reference.Equal[int64]); err != nil {
+			t.Errorf("eWiseAdd: %v", err)
+		}
+		if err := reference.CheckVector(w, func(gw GrB.Vector[int64]) error {
+			return GrB.VectorEWiseMultBinaryOp(gw, grbMask, grbAccum, grbMinus, gu, gv, desc.grb)
+		}, func(rw reference.Vector[int64]) {
+			reference.VectorEWiseMultBinaryOp(rw, refMask, refAccum, refMinus, u, v, desc.ref)
+		}, reference.Equal[int64]); err != nil {
+			t.Errorf("eWiseMult: %v", err)
+		}
+	})
+}
+
+func TestApplySelect(t *testing.T) {
+	grbAinv := GrB.Ainv[int64]()
+	grbTimes := GrB.Times[int64]()
+	grbRowIndex := GrB.RowIndex[int64, int64]()
+	grbTril := GrB.Tril[int64]()
+	grbValuegt := GrB.Valuegt[int64]()
+	forEachMatrixSetting(t, func(t *testing.T, rng *rand.Rand, density float64, c reference.Matrix[int64],
+		refMask *reference.Matrix[bool], grbMask *GrB.Matrix[bool],
+		refAccum reference.BinaryOp[int64, int64, int64], grbAccum *GrB.BinaryOp[int64, int64, int64],
+		desc descPair) {
+		a := randomMatrix(rng, c.Nrows, c.Ncols, density, intValue(rng))
+		ga := toGrB(t, a)
+		checks := []struct {
+			name string
+			grb  func(gc GrB.Matrix[int64]) error
+			ref  func(rc reference.Matrix[int64])
+		}{
+			{"apply", func(gc GrB.Matrix[int64]) error {
+				return GrB.MatrixApply(gc, grbMask, grbAccum, grbAinv, ga, desc.grb)
+			}, func(rc reference.Matrix[int64]) {
+				reference.MatrixApply(rc, refMask, refAccum, func(x int64) int64 { return -x }, a, desc.ref)
+			}},
+			{"apply2nd", func(gc GrB.Matrix[int64]) error {
+				return GrB.MatrixApplyBinaryOp2nd(gc, grbMask, grbAccum, grbTimes, ga, 3, desc.grb)
+			}, func(rc reference.Matrix[int64]) {
+				reference.MatrixApplyBinaryOp2nd(rc, refMask, refAccum, refTimes, a, 3, desc.ref)
+			}},
+			{"applyIndexOp", func(gc GrB.Matrix[int64]) error {
+				return GrB.MatrixApplyIndexOp(gc, grbMask, grbAccum, grbRowIndex, ga, 10, desc.grb)
+			}, func(rc reference.Matrix[int64]) {
+				reference.MatrixApplyIndexOp(rc, refMask, refAccum, func(_ int64, i, _ int, s int64) int64 {
+					return int64(i) + s
+				}, a, 10, desc.ref)
+			}},
+			{"selectTril", func(gc GrB.Matrix[int64]) error {
+				return GrB.MatrixSelect(gc, grbMask, grbAccum, grbTril, ga, -1, desc.grb)
+			}, func(rc reference.Matrix[int64]) {
+				reference.MatrixSelect(rc, refMask, refAccum, func(_ int64, i, j int, s int64) bool {
+					return int64(j) <= int64(i)+s
+				}, a, -1, desc.ref)
+			}},
+			{"selectValuegt", func(gc GrB.Matrix[int64]) error {
+				return GrB.MatrixSelect(gc, grbMask, grbAccum, grbValuegt, ga, 0, desc.grb)
+			}, func(rc reference.Matrix[int64]) {
+				reference.MatrixSelect(rc, refMask, refAccum, func(x int64, _, _ int, s int64) bool {
+					return x > s
+				}, a, 0, desc.ref)
+			}},
+		}
+		for _, check := range checks {
+			if err := reference.CheckMatrix(c, check.grb, check.ref, reference.Equal[int64]); err != nil {
+				t.Errorf("%v: %v", check.name, err)
+			}
+		}
+	})
+}
+
+func TestAssignSubassign(t *testing.T) {
+	rows, cols := []int{4, 1, 3}, []int{0, 2}
+	forEachMatrixSetting(t, func(t *testing.T, rng *rand.Rand, density float64, c reference.Matrix[int64],
+		refMask *reference.Matrix[bool], grbMask *GrB.Matrix[bool],
+		refAccum reference.BinaryOp[int64, int64, int64], grbAccum *GrB.BinaryOp[int64, int64, int64],
+		desc descPair) {
+		a := randomMatrix(rng, len(rows), len(cols), density, intValue(rng))
+		at := randomMatrix(rng, len(cols), len(rows), density, intValue(rng))
+		ga, gat := toGrB(t, a), toGrB(t, at)
+		input, ginput := a, ga
+		if desc.ref != nil && desc.ref.Tran0 {
+			input, ginput = at, gat
+		}
+		if err := reference.CheckMatrix(c, func(gc GrB.Matrix[int64]) error {
+			return GrB.MatrixAssign(gc, grbMask, grbAccum, ginput, rows, cols, desc.grb)
+		}, func(rc reference.Matrix[int64]) {
+			reference.MatrixAssign(rc, refMask, refAccum, input, rows, cols, desc.ref)
+		}, reference.Equal[int64]); err != nil {
+			t.Errorf("assign: %v", err)
+		}
+		if err := reference.CheckMatrix(c, func(gc GrB.Matrix[int64]) error {
+			return GrB.MatrixAssignConstant(gc, grbMask, grbAccum, 7, rows, cols, desc.grb)
+		}, func(rc reference.Matrix[int64]) {
+			reference.MatrixAssignConstant(rc, refMask, refAccum, 7, rows, cols, desc.ref)
+		}, reference.Equal[int64]); err != nil {
+			t.Errorf("assignConstant: %v", err)
+		}
+
+		// subassign masks have the dimensions of the region
+		sm := randomMatrix(rng, len(rows), len(cols), 0.5, boolValue(rng))
+		gsm := toGrB(t, sm)
+		subRefMask, subGrbMask := &sm, &gsm
+		if refMask == nil {
+			subRefMask, subGrbMask = nil, nil
+		}
+		if err := reference.CheckMatrix(c, func(gc GrB.Matrix[int64]) error {
+			return GrB.MatrixSubassign(gc, subGrbMask, grbAccum, ginput, rows, cols, desc.grb)
+		}, func(rc reference.Matrix[int64]) {
+			reference.MatrixSubassign(rc, subRefMask, refAccum, input, rows, cols, desc.ref)
+		}, reference.Equal[int64]); err != nil {
+			t.Errorf("subassign: %v", err)
+		}
+		if err := reference.CheckMatrix(c, func(gc GrB.Matrix[int64]) error {
+			return GrB.MatrixSubassignConstant(gc, subGrbMask, grbAccum, 7, rows, cols, desc.grb)
+		}, func(rc reference.Matrix[int64]) {
+			reference.MatrixSubassignConstant(rc, subRefMask, refAccum, 7, rows, cols, desc.ref)
+		}, reference.Equal[int64]); err != nil {
+			t.Errorf("subassignConstant: %v", err)
+		}
+	})
+}
+
+func TestVectorAssignSubassign(t *testing.T) {
+	indices := []int{3, 0, 4}
+	forEachVectorSetting(t, func(t *testing.T, rng *rand.Rand, density float64, w reference.Vector[int64],
+		refMask *reference.Vector[bool], grbMask *GrB.Vector[bool],
+		refAccum reference.BinaryOp[int64, int64, int64], grbAccum *GrB.BinaryOp[int64, int64, int64],
+		desc descPair) {
+		u := randomVector(rng, len(indices), density, intValue(rng))
+		gu := vectorToGrB(t, u)
+		if err := reference.CheckVector(w, func(gw GrB.Vector[int64]) error {
+			return GrB.VectorAssign(gw, grbMask, grbAccum, gu, indices, desc.grb)
+		}, func(rw reference.Vector[int64]) {
+			reference.VectorAssign(rw, refMask, refAccum, u, indices, desc.ref)
+		}, reference.Equal[int64]); err != nil {
+			t.Errorf("assign: %v", err)
+		}
+		sm := randomVector(rng, len(indices), 0.5, boolValue(rng))
+		gsm := vectorToGrB(t, sm)
+		subRefMask, subGrbMask := &sm, &gsm
+		if refMask == nil {
+			subRefMask, subGrbMask = nil, nil
+		}
+		if err := reference.CheckVector(w, func(gw GrB.Vector[int64]) error {
+			return GrB.VectorSubassign(gw, subGrbMask, grbAccum, gu, indices, desc.grb)
+		}, func(rw reference.Vector[int64]) {
+			reference.VectorSubassign(rw, subRefMask, refAccum, u, indices, desc.ref)
+		}, reference.Equal[int64]); err != nil {
+			t.Errorf("subassign: %v", err)
+		}
+	})
+}
+
+func TestExtract(t *testing.T) {
+	rows, cols := []int{2, 0, 4, 4, 1}, []int{3, 1, 0, 2, 2}
+	forEachMatrixSetting(t, func(t *testing.T, rng *rand.Rand, density float64, c reference.Matrix[int64],
+		refMask *reference.Matrix[bool], grbMask *GrB.Matrix[bool],
+		refAccum reference.BinaryOp[int64, int64, int64], grbAccum *GrB.BinaryOp[int64, int64, int64],
+		desc descPair) {
+		a := randomMatrix(rng, c.Nrows, c.Ncols, density, intValue(rng))
+		ga := toGrB(t, a)
+		if err := reference.CheckMatrix(c, func(gc GrB.Matrix[int64]) error {
+			return GrB.MatrixExtract(gc, grbMask, grbAccum, ga, rows, cols, desc.grb)
+		}, func(rc reference.Matrix[int64]) {
+			reference.MatrixExtract(rc, refMask, refAccum, a, rows, cols, desc.ref)
+		}, reference.Equal[int64]); err != nil {
+			t.Errorf("extract: %v", err)
+		}
+	})
+}
+
+func TestTransposeKronecker(t *testing.T) {
+	forEachMatrixSetting(t, func(t *testing.T, rng *rand.Rand, density float64, c reference.Matrix[int64],
+		refMask *reference.Matrix[bool], grbMask *GrB.Matrix[bool],
+		refAccum reference.BinaryOp[int64, int64, int64], grbAccum *GrB.BinaryOp[int64, int64, int64],
+		desc descPair) {
+		a := randomMatrix(rng, c.Nrows, c.Ncols, density, intValue(rng))
+		ga := toGrB(t, a)
+		if err := reference.CheckMatrix(c, func(gc GrB.Matrix[int64]) error {
+			return GrB.Transpose(gc, grbMask, grbAccum, ga, desc.grb)
+		}, func(rc reference.Matrix[int64]) {
+			reference.Transpose(rc, refMask, refAccum, a, desc.ref)
+		}, reference.Equal[int64]); err != nil {
+			t.Errorf("transpose: %v", err)
+		}
+
+		// a 5 x 5 result from a 5 x 1 (or 1 x 5) and a 1 x 5 (or 5 x 1) matrix
+		k0 := randomMatrix(rng, 5, 1, density, intValue(rng))
+		k1 := randomMatrix(rng, 1, 5, density, intValue(rng))
+		if desc.ref != nil && desc.ref.Tran0 {
+			k0 = randomMatrix(rng, 1, 5, density, intValue(rng))
+		}
+		if desc.ref != nil && desc.ref.Tran1 {
+			k1 = randomMatrix(rng, 5, 1, density, intValue(rng))
+		}
+		gk0, gk1 := toGrB(t, k0), toGrB(t, k1)
+		grbTimes := GrB.Times[int64]()
+		if err := reference.CheckMatrix(c, func(gc GrB.Matrix[int64]) error {
+			return GrB.KroneckerBinaryOp(gc, grbMask, grbAccum, grbTimes, gk0, gk1, desc.grb)
+		}, func(rc reference.Matrix[int64]) {
+			reference.KroneckerBinaryOp(rc, refMask, refAccum, refTimes, k0, k1, desc.ref)
+		}, reference.Equal[int64]); err != nil {
+			t.Errorf("kronecker: %v", err)
+		}
+	})
+}
+
+func TestReduce(t *testing.T) {
+	grbPlusMonoid := GrB.PlusMonoid[int64]()
+	forEachVectorSetting(t, func(t *testing.T, rng *rand.Rand, density float64, w reference.Vector[int64],
+		refMask *reference.Vector[bool], grbMask *GrB.Vector[bool],
+		refAccum reference.BinaryOp[int64, int64, int64], grbAccum *GrB.BinaryOp[int64, int64, int64],
+		desc descPair) {
+		a := randomMatrix(rng, w.N, w.N, density, intValue(rng))
+		ga := toGrB(t, a)
+		if err := reference.CheckVector(w, func(gw GrB.Vector[int64]) error {
+			return GrB.MatrixReduceMonoid(gw, grbMask, grbAccum, grbPlusMonoid, ga, desc.grb)
+		}, func(rw reference.Vector[int64]) {
+			reference.MatrixReduceMonoid(rw, refMask, refAccum, refPlusMonoid, a, desc.ref)
+		}, reference.Equal[int64]); err != nil {
+			t.Errorf("reduce to vector: %v", err)
+		}
+		got, err := GrB.MatrixReduce(grbPlusMonoid, ga, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := reference.MatrixReduce(refPlusMonoid, a); got != want {
+			t.Errorf("reduce to scalar: got %v, want %v", got, want)
+		}
+	})
+}
+
+func TestMismatch(t *testing.T) {
+	want := reference.MatrixFromTuples(3, 3, []int{0, 1, 2}, []int{0, 1, 2}, []float64{1, 2, 3})
+	got := want.Dup()
+	g := toGrB(t, got)
+
+	if err := reference.CompareMatrix(g, want, reference.Equal[float64]); err != nil {
+		t.Errorf("unexpected mismatch: %v", err)
+	}
+
+	if err := g.SetElement(2+1e-12, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.SetElement(5, 2, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := reference.CompareMatrix(g, want, reference.ApproxEqual[float64](1e-9)); err == nil {
+		t.Error("expected mismatch")
+	} else {
+		var mismatch *reference.Mismatch
+		if !errors.As(err, &mismatch) {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if mismatch.Kind != reference.ExtraEntry || mismatch.Row != 2 || mismatch.Col != 0 {
+			t.Errorf("unexpected mismatch %v", mismatch)
+		}
+	}
+
+	if err := g.RemoveElement(2, 0); err != nil {
+		t.Fatal(err)
+	}
+	err := reference.CompareMatrix(g, want, reference.Equal[float64])
+	var mismatch *reference.Mismatch
+	if !errors.As(err, &mismatch) || mismatch.Kind != reference.ValuesDiffer || mismatch.Row != 1 || mismatch.Col != 1 {
+		t.Errorf("unexpected result %v", err)
+	}
+}
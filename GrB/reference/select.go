@@ -0,0 +1,53 @@
+package reference
+
+import "github.com/intel/forGraphBLASGo/GrB"
+
+// VectorSelect keeps the entries of u for which op(u(i), i, 0, val) is true:
+// w<mask> = accum(w, select(u)).
+func VectorSelect[D, T any](
+	w Vector[D],
+	mask *Vector[bool],
+	accum BinaryOp[D, D, D],
+	op IndexUnaryOp[bool, D, T],
+	u Vector[D],
+	val T,
+	desc *Descriptor,
+) {
+	if u.N != w.N {
+		panic(GrB.DimensionMismatch)
+	}
+	t := VectorNew[D](w.N)
+	for i, x := range u.Entries {
+		if op(x, i, 0, val) {
+			t.Entries[i] = x
+		}
+	}
+	vectorWriteBack(w, mask, accum, t, desc)
+}
+
+// MatrixSelect keeps the entries of a for which op(a(i, j), i, j, val) is true:
+// c<mask> = accum(c, select(a)).
+//
+// The [Descriptor] field Tran0 transposes a. In that case, i and j refer to
+// positions in the transposed matrix.
+func MatrixSelect[D, T any](
+	c Matrix[D],
+	mask *Matrix[bool],
+	accum BinaryOp[D, D, D],
+	op IndexUnaryOp[bool, D, T],
+	a Matrix[D],
+	val T,
+	desc *Descriptor,
+) {
+	a = transposeIf(a, desc.tran0())
+	if a.Nrows != c.Nrows || a.Ncols != c.Ncols {
+		panic(GrB.DimensionMismatch)
+	}
+	t := MatrixNew[D](c.Nrows, c.Ncols)
+	for k, x := range a.Entries {
+		if op(x, k.Row, k.Col, val) {
+			t.Entries[k] = x
+		}
+	}
+	matrixWriteBack(c, mask, accum, t, desc)
+}
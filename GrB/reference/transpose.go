@@ -0,0 +1,27 @@
+package reference
+
+import "github.com/intel/forGraphBLASGo/GrB"
+
+// Transpose computes c<mask> = accum(c, aᵀ).
+//
+// The [Descriptor] field Tran0 transposes a beforehand, so that in effect
+// a is copied without being transposed.
+func Transpose[D any](
+	c Matrix[D],
+	mask *Matrix[bool],
+	accum BinaryOp[D, D, D],
+	a Matrix[D],
+	desc *Descriptor,
+) {
+	var t Matrix[D]
+	if desc.tran0() {
+		// a may be the same matrix as c
+		t = a.Dup()
+	} else {
+		t = transposeIf(a, true)
+	}
+	if t.Nrows != c.Nrows || t.Ncols != c.Ncols {
+		panic(GrB.DimensionMismatch)
+	}
+	matrixWriteBack(c, mask, accum, t, desc)
+}
@@ -0,0 +1,773 @@
+package GrB
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// The registry maps names of semirings, monoids and operators to the corresponding GraphBLAS objects,
+// so that they can be selected at runtime, for example from configuration files. Each object is
+// registered under a name and the Go types of its domains, so that the same name can refer to
+// different objects for different domains.
+
+type registryKind int
+
+const (
+	semiringKind registryKind = iota
+	monoidKind
+	binaryOpKind
+	unaryOpKind
+	indexUnaryOpKind
+)
+
+var registryKindStrings = map[registryKind]string{
+	semiringKind:     "semiring",
+	monoidKind:       "monoid",
+	binaryOpKind:     "binary operator",
+	unaryOpKind:      "unary operator",
+	indexUnaryOpKind: "index-unary operator",
+}
+
+// A registryKey identifies a registered object. For the predefined index-unary operators
+// that do not depend on the values of their input, din1 is nil, which matches any domain.
+type registryKey struct {
+	kind             registryKind
+	name             string
+	dout, din1, din2 reflect.Type
+}
+
+var registry struct {
+	once    sync.Once
+	mutex   sync.RWMutex
+	objects map[registryKey]any
+}
+
+// normalizeName makes lookup by name insensitive to case and separators, so that for example
+// "min_plus", "min.plus", "MinPlus" and "MIN-PLUS" all refer to the same semiring. It also
+// accepts the SuiteSparse:GraphBLAS name "pair" as an alias for [Oneb], but only as a whole
+// word of the name, as in "pair", "plus_pair" or "PlusPair", and not in "repair" or "pairwise".
+func normalizeName(name string) string {
+	var normalized strings.Builder
+	for _, field := range strings.FieldsFunc(name, func(r rune) bool {
+		return strings.ContainsRune("_.- ", r)
+	}) {
+		for _, word := range splitCamelCase(field) {
+			word = strings.ToLower(word)
+			if word == "pair" {
+				word = "oneb"
+			}
+			normalized.WriteString(word)
+		}
+	}
+	return normalized.String()
+}
+
+// splitCamelCase splits a name before each upper-case letter that follows a lower-case letter or digit.
+func splitCamelCase(name string) (words []string) {
+	start := 0
+	var previous rune
+	for i, r := range name {
+		if unicode.IsUpper(r) && (unicode.IsLower(previous) || unicode.IsDigit(previous)) {
+			words = append(words, name[start:i])
+			start = i
+		}
+		previous = r
+	}
+	return append(words, name[start:])
+}
+
+func typeOf[D any]() reflect.Type {
+	return reflect.TypeOf((*D)(nil)).Elem()
+}
+
+func registryKeyOf[Dout, Din1, Din2 any](kind registryKind, name string) registryKey {
+	return registryKey{
+		kind: kind,
+		name: normalizeName(name),
+		dout: typeOf[Dout](),
+		din1: typeOf[Din1](),
+		din2: typeOf[Din2](),
+	}
+}
+
+// register adds an object to the registry. Predefined objects are registered first,
+// and the first registration for a key takes precedence.
+func register(key registryKey, object any) bool {
+	if _, ok := registry.objects[key]; ok {
+		return false
+	}
+	registry.objects[key] = object
+	return true
+}
+
+func registerCustom(key registryKey, object any) error {
+	if key.name == "" {
+		return makeError(InvalidValue)
+	}
+	initRegistry()
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	if !register(key, object) {
+		return fmt.Errorf("%v %q already registered for domain %v: %w", registryKindStrings[key.kind], key.name, key.dout, makeError(InvalidValue))
+	}
+	return nil
+}
+
+func lookup(key registryKey, name string) (object any, err error) {
+	initRegistry()
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	if object, ok := registry.objects[key]; ok {
+		return object, nil
+	}
+	if key.kind == indexUnaryOpKind {
+		key.din1 = nil
+		if object, ok := registry.objects[key]; ok {
+			return object, nil
+		}
+	}
+	return nil, fmt.Errorf("%v %q not found for domain %v: %w", registryKindStrings[key.kind], name, key.dout, makeError(InvalidValue))
+}
+
+// SemiringByName returns the semiring registered under the given name for domain D.
+// All predefined semirings whose domains are all D are registered under the snake_case
+// version of their names without the Semiring and Bool suffixes, for example "plus_times",
+// "min_plus", "any_secondi" or "lor_land". Names are case-insensitive, and "_", ".", "-"
+// and spaces are ignored, so that "min.plus" and "MinPlus" refer to the same semiring
+// as "min_plus". The word "pair" can be used instead of "oneb", as in "plus_pair" or
+// "PlusPair". Further semirings can be registered with [RegisterSemiring].
+//
+// GraphBLAS API errors that may be returned:
+//   - [InvalidValue]: No semiring is registered under the given name for domain D.
+//
+// SemiringByName is a forGraphBLASGo extension.
+func SemiringByName[D any](name string) (semiring Semiring[D, D, D], err error) {
+	object, err := lookup(registryKeyOf[D, D, D](semiringKind, name), name)
+	if err != nil {
+		return
+	}
+	return object.(Semiring[D, D, D]), nil
+}
+
+// RegisterSemiring registers a semiring under the given name for domain D, so that it can be
+// looked up with [SemiringByName]. The name is normalized as described for SemiringByName.
+//
+// GraphBLAS API errors that may be returned:
+//   - [InvalidValue]: The name is empty, or a semiring is already registered under the given name for domain D.
+//
+// RegisterSemiring is a forGraphBLASGo extension.
+func RegisterSemiring[D any](name string, semiring Semiring[D, D, D]) error {
+	return registerCustom(registryKeyOf[D, D, D](semiringKind, name), semiring)
+}
+
+// MonoidByName returns the monoid registered under the given name for domain D.
+// All predefined monoids are registered under the snake_case version of their names
+// without the Monoid and Bool suffixes, for example "plus", "max", "bxor" or "lor".
+// Names are normalized as described for [SemiringByName]. Further monoids can
+// be registered with [RegisterMonoid].
+//
+// GraphBLAS API errors that may be returned:
+//   - [InvalidValue]: No monoid is registered under the given name for domain D.
+//
+// MonoidByName is a forGraphBLASGo extension.
+func MonoidByName[D any](name string) (monoid Monoid[D], err error) {
+	object, err := lookup(registryKeyOf[D, D, D](monoidKind, name), name)
+	if err != nil {
+		return
+	}
+	return object.(Monoid[D]), nil
+}
+
+// RegisterMonoid registers a monoid under the given name for domain D, so that it can be
+// looked up with [MonoidByName]. The name is normalized as described for [SemiringByName].
+//
+// GraphBLAS API errors that may be returned:
+//   - [InvalidValue]: The name is empty, or a monoid is already registered under the given name for domain D.
+//
+// RegisterMonoid is a forGraphBLASGo extension.
+func RegisterMonoid[D any](name string, monoid Monoid[D]) error {
+	return registerCustom(registryKeyOf[D, D, D](monoidKind, name), monoid)
+}
+
+// BinaryOpByName returns the binary operator registered under the given name for domain D.
+// All predefined binary operators whose domains are all D are registered under the snake_case
+// version of their names without the Bool suffix, for example "plus", "second", "firsti"
+// or "lor". Names are normalized as described for [SemiringByName]. Further binary
+// operators can be registered with [RegisterBinaryOp].
+//
+// GraphBLAS API errors that may be returned:
+//   - [InvalidValue]: No binary operator is registered under the given name for domain D.
+//
+// BinaryOpByName is a forGraphBLASGo extension.
+func BinaryOpByName[D any](name string) (binaryOp BinaryOp[D, D, D], err error) {
+	object, err := lookup(registryKeyOf[D, D, D](binaryOpKind, name), name)
+	if err != nil {
+		return
+	}
+	return object.(BinaryOp[D, D, D]), nil
+}
+
+// RegisterBinaryOp registers a binary operator under the given name for domain D, so that it can be
+// looked up with [BinaryOpByName]. The name is normalized as described for [SemiringByName].
+//
+// GraphBLAS API errors that may be returned:
+//   - [InvalidValue]: The name is empty, or a binary operator is already registered under the given name for domain D.
+//
+// RegisterBinaryOp is a forGraphBLASGo extension.
+func RegisterBinaryOp[D any](name string, binaryOp BinaryOp[D, D, D]) error {
+	return registerCustom(registryKeyOf[D, D, D](binaryOpKind, name), binaryOp)
+}
+
+// UnaryOpByName returns the unary operator registered under the given name for domain D.
+// All predefined unary operators whose domains are both D are registered under the snake_case
+// version of their names, for example "identity", "ainv", "positioni" or "sqrt".
+// Names are normalized as described for [SemiringByName]. Further unary operators can
+// be registered with [RegisterUnaryOp].
+//
+// GraphBLAS API errors that may be returned:
+//   - [InvalidValue]: No unary operator is registered under the given name for domain D.
+//
+// UnaryOpByName is a forGraphBLASGo extension.
+func UnaryOpByName[D any](name string) (unaryOp UnaryOp[D, D], err error) {
+	object, err := lookup(registryKeyOf[D, D, D](unaryOpKind, name), name)
+	if err != nil {
+		return
+	}
+	return object.(UnaryOp[D, D]), nil
+}
+
+// RegisterUnaryOp registers a unary operator under the given name for domain D, so that it can be
+// looked up with [UnaryOpByName]. The name is normalized as described for [SemiringByName].
+//
+// GraphBLAS API errors that may be returned:
+//   - [InvalidValue]: The name is empty, or a unary operator is already registered under the given name for domain D.
+//
+// RegisterUnaryOp is a forGraphBLASGo extension.
+func RegisterUnaryOp[D any](name string, unaryOp UnaryOp[D, D]) error {
+	return registerCustom(registryKeyOf[D, D, D](unaryOpKind, name), unaryOp)
+}
+
+// IndexUnaryOpByName returns the index-unary operator registered under the given name for the
+// given domains. Unlike for the other operators, the domains of index-unary operators are
+// rarely all the same, so they have to be specified separately. All predefined index-unary
+// operators are registered under their names in lower case, for example "tril", "rowindex"
+// or "valuegt". The operators that do not depend on the values of their input, like [Tril] or
+// [RowIndex], are found for any domain Din1. Names are normalized as described for
+// [SemiringByName]. Further index-unary operators can be registered with [RegisterIndexUnaryOp].
+//
+// GraphBLAS API errors that may be returned:
+//   - [InvalidValue]: No index-unary operator is registered under the given name for the given domains.
+//
+// IndexUnaryOpByName is a forGraphBLASGo extension.
+func IndexUnaryOpByName[Dout, Din1, Din2 any](name string) (indexUnaryOp IndexUnaryOp[Dout, Din1, Din2], err error) {
+	object, err := lookup(registryKeyOf[Dout, Din1, Din2](indexUnaryOpKind, name), name)
+	if err != nil {
+		return
+	}
+	switch op := object.(type) {
+	case IndexUnaryOp[Dout, Din1, Din2]:
+		return op, nil
+	case IndexUnaryOp[Dout, any, Din2]:
+		// predefined operators that do not depend on the values of their input
+		return IndexUnaryOp[Dout, Din1, Din2]{op.grb}, nil
+	}
+	err = fmt.Errorf("%v %q has unexpected type %T: %w", registryKindStrings[indexUnaryOpKind], name, object, makeError(InvalidValue))
+	return
+}
+
+// RegisterIndexUnaryOp registers an index-unary operator under the given name for the given domains,
+// so that it can be looked up with [IndexUnaryOpByName]. The name is normalized as described for
+// [SemiringByName].
+//
+// GraphBLAS API errors that may be returned:
+//   - [InvalidValue]: The name is empty, or an index-unary operator is already registered under
+//     the given name for the given domains.
+//
+// RegisterIndexUnaryOp is a forGraphBLASGo extension.
+func RegisterIndexUnaryOp[Dout, Din1, Din2 any](name string, indexUnaryOp IndexUnaryOp[Dout, Din1, Din2]) error {
+	return registerCustom(registryKeyOf[Dout, Din1, Din2](indexUnaryOpKind, name), indexUnaryOp)
+}
+
+func registerSemiring[D any](name string, semiring Semiring[D, D, D]) {
+	register(registryKeyOf[D, D, D](semiringKind, name), semiring)
+}
+
+func registerMonoid[D any](name string, monoid Monoid[D]) {
+	register(registryKeyOf[D, D, D](monoidKind, name), monoid)
+}
+
+func registerBinaryOp[D any](name string, binaryOp BinaryOp[D, D, D]) {
+	register(registryKeyOf[D, D, D](binaryOpKind, name), binaryOp)
+}
+
+func registerUnaryOp[D any](name string, unaryOp UnaryOp[D, D]) {
+	register(registryKeyOf[D, D, D](unaryOpKind, name), unaryOp)
+}
+
+func registerIndexUnaryOp[Dout, Din1, Din2 any](name string, indexUnaryOp IndexUnaryOp[Dout, Din1, Din2]) {
+	register(registryKeyOf[Dout, Din1, Din2](indexUnaryOpKind, name), indexUnaryOp)
+}
+
+// registerStructuralIndexUnaryOp registers a predefined index-unary operator that
+// does not depend on the values of its input, and therefore matches any domain Din1.
+func registerStructuralIndexUnaryOp[Dout, Din2 any](name string, indexUnaryOp IndexUnaryOp[Dout, any, Din2]) {
+	key := registryKeyOf[Dout, any, Din2](indexUnaryOpKind, name)
+	key.din1 = nil
+	register(key, indexUnaryOp)
+}
+
+func initRegistry() {
+	registry.once.Do(func() {
+		registry.objects = make(map[registryKey]any)
+
+		registerPredefined[bool]()
+		registerNumbers[int]()
+		registerNumbers[int8]()
+		registerNumbers[int16]()
+		registerNumbers[int32]()
+		registerNumbers[int64]()
+		registerNumbers[uint]()
+		registerNumbers[uint8]()
+		registerNumbers[uint16]()
+		registerNumbers[uint32]()
+		registerNumbers[uint64]()
+		registerNumbers[float32]()
+		registerNumbers[float64]()
+		registerComplex[complex64]()
+		registerComplex[complex128]()
+
+		registerIntegers[int]()
+		registerIntegers[int8]()
+		registerIntegers[int16]()
+		registerIntegers[int32]()
+		registerIntegers[int64]()
+		registerIntegers[uint]()
+		registerIntegers[uint8]()
+		registerIntegers[uint16]()
+		registerIntegers[uint32]()
+		registerIntegers[uint64]()
+
+		registerUnsigned[uint]()
+		registerUnsigned[uint8]()
+		registerUnsigned[uint16]()
+		registerUnsigned[uint32]()
+		registerUnsigned[uint64]()
+
+		registerPositional[int]()
+		registerPositional[int32]()
+		registerPositional[int64]()
+
+		registerFloats[float32]()
+		registerFloats[float64]()
+		registerFloatsAndComplex[float32]()
+		registerFloatsAndComplex[float64]()
+		registerFloatsAndComplex[complex64]()
+		registerFloatsAndComplex[complex128]()
+
+		registerBool()
+
+		registerStructuralIndexUnaryOp("tril", Tril[any]())
+		registerStructuralIndexUnaryOp("triu", Triu[any]())
+		registerStructuralIndexUnaryOp("diag", Diag[any]())
+		registerStructuralIndexUnaryOp("offdiag", Offdiag[any]())
+		registerStructuralIndexUnaryOp("colle", Colle[any]())
+		registerStructuralIndexUnaryOp("colgt", Colgt[any]())
+		registerStructuralIndexUnaryOp("rowle", Rowle[any]())
+		registerStructuralIndexUnaryOp("rowgt", Rowgt[any]())
+	})
+}
+
+// registerPredefined registers the objects for constructors with constraint Predefined,
+// and for constructors with constraint Predefined | Complex.
+func registerPredefined[D Predefined]() {
+	registerPredefinedOrComplex[D]()
+
+	registerSemiring("any_lor", AnyLor[D]())
+	registerSemiring("any_land", AnyLand[D]())
+	registerSemiring("any_lxor", AnyLxor[D]())
+
+	registerBinaryOp("min", Min[D]())
+	registerBinaryOp("max", Max[D]())
+	registerBinaryOp("isgt", Isgt[D]())
+	registerBinaryOp("islt", Islt[D]())
+	registerBinaryOp("isge", Isge[D]())
+	registerBinaryOp("isle", Isle[D]())
+	registerBinaryOp("lor", Lor[D]())
+	registerBinaryOp("land", Land[D]())
+	registerBinaryOp("lxor", Lxor[D]())
+
+	registerUnaryOp("lnot", Lnot[D]())
+
+	registerIndexUnaryOp("valuegt", Valuegt[D]())
+	registerIndexUnaryOp("valuege", Valuege[D]())
+	registerIndexUnaryOp("valuelt", Valuelt[D]())
+	registerIndexUnaryOp("valuele", Valuele[D]())
+}
+
+func registerPredefinedOrComplex[D Predefined | Complex]() {
+	registerSemiring("any_first", AnyFirst[D]())
+	registerSemiring("any_second", AnySecond[D]())
+	registerSemiring("any_oneb", AnyOneb[D]())
+
+	registerBinaryOp("first", First[D, D]())
+	registerBinaryOp("second", Second[D, D]())
+	registerBinaryOp("any", Any[D]())
+	registerBinaryOp("oneb", Oneb[D]())
+	registerBinaryOp("plus", Plus[D]())
+	registerBinaryOp("minus", Minus[D]())
+	registerBinaryOp("rminus", Rminus[D]())
+	registerBinaryOp("times", Times[D]())
+	registerBinaryOp("div", Div[D]())
+	registerBinaryOp("rdiv", Rdiv[D]())
+	registerBinaryOp("pow", Pow[D]())
+	registerBinaryOp("iseq", Iseq[D]())
+	registerBinaryOp("isne", Isne[D]())
+
+	registerUnaryOp("one", One[D]())
+	registerUnaryOp("identity", Identity[D]())
+	registerUnaryOp("ainv", Ainv[D]())
+	registerUnaryOp("minv", Minv[D]())
+	registerUnaryOp("abs", Abs[D]())
+
+	registerIndexUnaryOp("valueeq", Valueeq[D]())
+	registerIndexUnaryOp("valuene", Valuene[D]())
+}
+
+// registerNumbers registers the objects for constructors with constraint Number,
+// and for constructors with constraints that include Number.
+func registerNumbers[D Number]() {
+	registerPredefined[D]()
+
+	// GraphBLAS C API semirings take precedence over the equivalent SuiteSparse:GraphBLAS semirings
+	registerSemiring("plus_times", PlusTimesSemiring[D]())
+	registerSemiring("min_plus", MinPlusSemiring[D]())
+	registerSemiring("max_plus", MaxPlusSemiring[D]())
+	registerSemiring("min_times", MinTimesSemiring[D]())
+	registerSemiring("min_max", MinMaxSemiring[D]())
+	registerSemiring("max_min", MaxMinSemiring[D]())
+	registerSemiring("max_times", MaxTimesSemiring[D]())
+	registerSemiring("plus_min", PlusMinSemiring[D]())
+	registerSemiring("min_first", MinFirstSemiring[D]())
+	registerSemiring("min_second", MinSecondSemiring[D]())
+	registerSemiring("max_first", MaxFirstSemiring[D]())
+	registerSemiring("max_second", MaxSecondSemiring[D]())
+	registerNumbersOrComplex[D]()
+
+	registerSemiring("min_oneb", MinOneb[D]())
+	registerSemiring("max_oneb", MaxOneb[D]())
+	registerSemiring("min_min", MinMin[D]())
+	registerSemiring("times_min", TimesMin[D]())
+	registerSemiring("any_min", AnyMin[D]())
+	registerSemiring("max_max", MaxMax[D]())
+	registerSemiring("plus_max", PlusMax[D]())
+	registerSemiring("times_max", TimesMax[D]())
+	registerSemiring("any_max", AnyMax[D]())
+	registerSemiring("min_minus", MinMinus[D]())
+	registerSemiring("max_minus", MaxMinus[D]())
+	registerSemiring("min_div", MinDiv[D]())
+	registerSemiring("max_div", MaxDiv[D]())
+	registerSemiring("min_rdiv", MinRdiv[D]())
+	registerSemiring("max_rdiv", MaxRdiv[D]())
+	registerSemiring("min_rminus", MinRminus[D]())
+	registerSemiring("max_rminus", MaxRminus[D]())
+
+	registerSemiring("min_iseq", MinIseq[D]())
+	registerSemiring("max_iseq", MaxIseq[D]())
+	registerSemiring("plus_iseq", PlusIseq[D]())
+	registerSemiring("times_iseq", TimesIseq[D]())
+	registerSemiring("any_iseq", AnyIseq[D]())
+	registerSemiring("min_isne", MinIsne[D]())
+	registerSemiring("max_isne", MaxIsne[D]())
+	registerSemiring("plus_isne", PlusIsne[D]())
+	registerSemiring("times_isne", TimesIsne[D]())
+	registerSemiring("any_isne", AnyIsne[D]())
+	registerSemiring("min_isgt", MinIsgt[D]())
+	registerSemiring("max_isgt", MaxIsgt[D]())
+	registerSemiring("plus_isgt", PlusIsgt[D]())
+	registerSemiring("times_isgt", TimesIsgt[D]())
+	registerSemiring("any_isgt", AnyIsgt[D]())
+	registerSemiring("min_islt", MinIslt[D]())
+	registerSemiring("max_islt", MaxIslt[D]())
+	registerSemiring("plus_islt", PlusIslt[D]())
+	registerSemiring("times_islt", TimesIslt[D]())
+	registerSemiring("any_islt", AnyIslt[D]())
+	registerSemiring("min_isge", MinIsge[D]())
+	registerSemiring("max_isge", MaxIsge[D]())
+	registerSemiring("plus_isge", PlusIsge[D]())
+	registerSemiring("times_isge", TimesIsge[D]())
+	registerSemiring("any_isge", AnyIsge[D]())
+	registerSemiring("min_isle", MinIsle[D]())
+	registerSemiring("max_isle", MaxIsle[D]())
+	registerSemiring("plus_isle", PlusIsle[D]())
+	registerSemiring("times_isle", TimesIsle[D]())
+	registerSemiring("any_isle", AnyIsle[D]())
+
+	registerSemiring("min_lor", MinLor[D]())
+	registerSemiring("max_lor", MaxLor[D]())
+	registerSemiring("plus_lor", PlusLor[D]())
+	registerSemiring("times_lor", TimesLor[D]())
+	registerSemiring("min_land", MinLand[D]())
+	registerSemiring("max_land", MaxLand[D]())
+	registerSemiring("plus_land", PlusLand[D]())
+	registerSemiring("times_land", TimesLand[D]())
+	registerSemiring("min_lxor", MinLxor[D]())
+	registerSemiring("max_lxor", MaxLxor[D]())
+	registerSemiring("plus_lxor", PlusLxor[D]())
+	registerSemiring("times_lxor", TimesLxor[D]())
+
+	registerMonoid("min", MinMonoid[D]())
+	registerMonoid("max", MaxMonoid[D]())
+}
+
+// registerComplex registers the objects for constructors with constraints that include Complex.
+func registerComplex[D Complex]() {
+	registerPredefinedOrComplex[D]()
+	registerNumbersOrComplex[D]()
+
+	registerUnaryOp("conj", Conj[D]())
+}
+
+func registerNumbersOrComplex[D Number | Complex]() {
+	registerSemiring("plus_first", PlusFirst[D]())
+	registerSemiring("plus_second", PlusSecond[D]())
+	registerSemiring("times_first", TimesFirst[D]())
+	registerSemiring("times_second", TimesSecond[D]())
+	registerSemiring("plus_oneb", PlusOneb[D]())
+	registerSemiring("times_oneb", TimesOneb[D]())
+	registerSemiring("plus_plus", PlusPlus[D]())
+	registerSemiring("times_plus", TimesPlus[D]())
+	registerSemiring("any_plus", AnyPlus[D]())
+	registerSemiring("plus_minus", PlusMinus[D]())
+	registerSemiring("times_minus", TimesMinus[D]())
+	registerSemiring("any_minus", AnyMinus[D]())
+	registerSemiring("plus_times", PlusTimes[D]())
+	registerSemiring("times_times", TimesTimes[D]())
+	registerSemiring("any_times", AnyTimes[D]())
+	registerSemiring("plus_div", PlusDiv[D]())
+	registerSemiring("times_div", TimesDiv[D]())
+	registerSemiring("any_div", AnyDiv[D]())
+	registerSemiring("plus_rdiv", PlusRdiv[D]())
+	registerSemiring("times_rdiv", TimesRdiv[D]())
+	registerSemiring("any_rdiv", AnyRdiv[D]())
+	registerSemiring("plus_rminus", PlusRminus[D]())
+	registerSemiring("times_rminus", TimesRminus[D]())
+	registerSemiring("any_rminus", AnyRminus[D]())
+
+	registerMonoid("plus", PlusMonoid[D]())
+	registerMonoid("times", TimesMonoid[D]())
+	registerMonoid("any", AnyMonoid[D]())
+}
+
+func registerIntegers[D Integer]() {
+	registerBinaryOp("bor", Bor[D]())
+	registerBinaryOp("band", Band[D]())
+	registerBinaryOp("bxor", Bxor[D]())
+	registerBinaryOp("bxnor", Bxnor[D]())
+	registerBinaryOp("bget", Bget[D]())
+	registerBinaryOp("bset", Bset[D]())
+	registerBinaryOp("bclr", Bclr[D]())
+
+	registerUnaryOp("bnot", Bnot[D]())
+}
+
+func registerUnsigned[D Unsigned]() {
+	registerSemiring("bor_bor", BorBor[D]())
+	registerSemiring("bor_band", BorBand[D]())
+	registerSemiring("bor_bxor", BorBxor[D]())
+	registerSemiring("bor_bxnor", BorBxnor[D]())
+	registerSemiring("band_bor", BandBor[D]())
+	registerSemiring("band_band", BandBand[D]())
+	registerSemiring("band_bxor", BandBxor[D]())
+	registerSemiring("band_bxnor", BandBxnor[D]())
+	registerSemiring("bxor_bor", BxorBor[D]())
+	registerSemiring("bxor_band", BxorBand[D]())
+	registerSemiring("bxor_bxor", BxorBxor[D]())
+	registerSemiring("bxor_bxnor", BxorBxnor[D]())
+	registerSemiring("bxnor_bor", BxnorBor[D]())
+	registerSemiring("bxnor_band", BxnorBand[D]())
+	registerSemiring("bxnor_bxor", BxnorBxor[D]())
+	registerSemiring("bxnor_bxnor", BxnorBxnor[D]())
+
+	registerMonoid("bor", BorMonoid[D]())
+	registerMonoid("band", BandMonoid[D]())
+	registerMonoid("bxor", BxorMonoid[D]())
+	registerMonoid("bxnor", BxnorMonoid[D]())
+}
+
+func registerPositional[D int32 | int64 | int]() {
+	registerSemiring("min_firsti", MinFirsti[D]())
+	registerSemiring("max_firsti", MaxFirsti[D]())
+	registerSemiring("any_firsti", AnyFirsti[D]())
+	registerSemiring("plus_firsti", PlusFirsti[D]())
+	registerSemiring("times_firsti", TimesFirsti[D]())
+	registerSemiring("min_firsti1", MinFirsti1[D]())
+	registerSemiring("max_firsti1", MaxFirsti1[D]())
+	registerSemiring("any_firsti1", AnyFirsti1[D]())
+	registerSemiring("plus_firsti1", PlusFirsti1[D]())
+	registerSemiring("times_firsti1", TimesFirsti1[D]())
+	registerSemiring("min_firstj", MinFirstj[D]())
+	registerSemiring("max_firstj", MaxFirstj[D]())
+	registerSemiring("any_firstj", AnyFirstj[D]())
+	registerSemiring("plus_firstj", PlusFirstj[D]())
+	registerSemiring("times_firstj", TimesFirstj[D]())
+	registerSemiring("min_firstj1", MinFirstj1[D]())
+	registerSemiring("max_firstj1", MaxFirstj1[D]())
+	registerSemiring("any_firstj1", AnyFirstj1[D]())
+	registerSemiring("plus_firstj1", PlusFirstj1[D]())
+	registerSemiring("times_firstj1", TimesFirstj1[D]())
+	registerSemiring("min_secondi", MinSecondi[D]())
+	registerSemiring("max_secondi", MaxSecondi[D]())
+	registerSemiring("any_secondi", AnySecondi[D]())
+	registerSemiring("plus_secondi", PlusSecondi[D]())
+	registerSemiring("times_secondi", TimesSecondi[D]())
+	registerSemiring("min_secondi1", MinSecondi1[D]())
+	registerSemiring("max_secondi1", MaxSecondi1[D]())
+	registerSemiring("any_secondi1", AnySecondi1[D]())
+	registerSemiring("plus_secondi1", PlusSecondi1[D]())
+	registerSemiring("times_secondi1", TimesSecondi1[D]())
+	registerSemiring("min_secondj", MinSecondj[D]())
+	registerSemiring("max_secondj", MaxSecondj[D]())
+	registerSemiring("any_secondj", AnySecondj[D]())
+	registerSemiring("plus_secondj", PlusSecondj[D]())
+	registerSemiring("times_secondj", TimesSecondj[D]())
+	registerSemiring("min_secondj1", MinSecondj1[D]())
+	registerSemiring("max_secondj1", MaxSecondj1[D]())
+	registerSemiring("any_secondj1", AnySecondj1[D]())
+	registerSemiring("plus_secondj1", PlusSecondj1[D]())
+	registerSemiring("times_secondj1", TimesSecondj1[D]())
+
+	registerBinaryOp("firsti", Firsti[D, D, D]())
+	registerBinaryOp("firsti1", Firsti1[D, D, D]())
+	registerBinaryOp("firstj", Firstj[D, D, D]())
+	registerBinaryOp("firstj1", Firstj1[D, D, D]())
+	registerBinaryOp("secondi", Secondi[D, D, D]())
+	registerBinaryOp("secondi1", Secondi1[D, D, D]())
+	registerBinaryOp("secondj", Secondj[D, D, D]())
+	registerBinaryOp("secondj1", Secondj1[D, D, D]())
+
+	registerUnaryOp("positioni", Positioni[D, D]())
+	registerUnaryOp("positioni1", Positioni1[D, D]())
+	registerUnaryOp("positionj", Positionj[D, D]())
+	registerUnaryOp("positionj1", Positionj1[D, D]())
+
+	registerStructuralIndexUnaryOp("rowindex", RowIndex[D, any]())
+	registerStructuralIndexUnaryOp("colindex", ColIndex[D, any]())
+	registerStructuralIndexUnaryOp("diagindex", DiagIndex[D, any]())
+}
+
+func registerFloats[D Float]() {
+	registerBinaryOp("atan2", Atan2[D]())
+	registerBinaryOp("hypot", Hypot[D]())
+	registerBinaryOp("fmod", Fmod[D]())
+	registerBinaryOp("remainder", Remainder[D]())
+	registerBinaryOp("ldexp", Ldexp[D]())
+	registerBinaryOp("copysign", Copysign[D]())
+
+	registerUnaryOp("lgamma", Lgamma[D]())
+	registerUnaryOp("tgamma", Tgamma[D]())
+	registerUnaryOp("erf", Erf[D]())
+	registerUnaryOp("erfc", Erfc[D]())
+	registerUnaryOp("cbrt", Cbrt[D]())
+	registerUnaryOp("frexpx", Frexpx[D]())
+	registerUnaryOp("frexpe", Frexpe[D]())
+}
+
+func registerFloatsAndComplex[D Float | Complex]() {
+	registerUnaryOp("sqrt", Sqrt[D]())
+	registerUnaryOp("log", Log[D]())
+	registerUnaryOp("exp", Exp[D]())
+	registerUnaryOp("log10", Log10[D]())
+	registerUnaryOp("log2", Log2[D]())
+	registerUnaryOp("exp2", Exp2[D]())
+	registerUnaryOp("expm1", Expm1[D]())
+	registerUnaryOp("log1p", Log1p[D]())
+	registerUnaryOp("sin", Sin[D]())
+	registerUnaryOp("cos", Cos[D]())
+	registerUnaryOp("tan", Tan[D]())
+	registerUnaryOp("asin", Asin[D]())
+	registerUnaryOp("acos", Acos[D]())
+	registerUnaryOp("atan", Atan[D]())
+	registerUnaryOp("sinh", Sinh[D]())
+	registerUnaryOp("cosh", Cosh[D]())
+	registerUnaryOp("tanh", Tanh[D]())
+	registerUnaryOp("asinh", Asinh[D]())
+	registerUnaryOp("acosh", Acosh[D]())
+	registerUnaryOp("atanh", Atanh[D]())
+	registerUnaryOp("signum", Signum[D]())
+	registerUnaryOp("ceil", Ceil[D]())
+	registerUnaryOp("floor", Floor[D]())
+	registerUnaryOp("round", Round[D]())
+	registerUnaryOp("trunc", Trunc[D]())
+}
+
+func registerBool() {
+	registerSemiring("lor_land", LorLandSemiringBool)
+	registerSemiring("land_lor", LandLorSemiringBool)
+	registerSemiring("lxor_land", LxorLandSemiringBool)
+	registerSemiring("lxnor_lor", LxnorLorSemiringBool)
+
+	registerSemiring("lor_first", LorFirstBool)
+	registerSemiring("land_first", LandFirstBool)
+	registerSemiring("lxor_first", LxorFirstBool)
+	registerSemiring("eq_first", EqFirstBool)
+	registerSemiring("lor_second", LorSecondBool)
+	registerSemiring("land_second", LandSecondBool)
+	registerSemiring("lxor_second", LxorSecondBool)
+	registerSemiring("eq_second", EqSecondBool)
+	registerSemiring("lor_oneb", LorOnebBool)
+	registerSemiring("land_oneb", LandOnebBool)
+	registerSemiring("lxor_oneb", LxorOnebBool)
+	registerSemiring("eq_oneb", EqOnebBool)
+	registerSemiring("lor_lor", LorLorBool)
+	registerSemiring("land_lor", LandLorBool)
+	registerSemiring("lxor_lor", LxorLorBool)
+	registerSemiring("eq_lor", EqLorBool)
+	registerSemiring("lor_land", LorLandBool)
+	registerSemiring("land_land", LandLandBool)
+	registerSemiring("lxor_land", LxorLandBool)
+	registerSemiring("eq_land", EqLandBool)
+	registerSemiring("lor_lxor", LorLxorBool)
+	registerSemiring("land_lxor", LandLxorBool)
+	registerSemiring("lxor_lxor", LxorLxorBool)
+	registerSemiring("eq_lxor", EqLxorBool)
+	registerSemiring("lor_eq", LorEqBool)
+	registerSemiring("land_eq", LandEqBool)
+	registerSemiring("lxor_eq", LxorEqBool)
+	registerSemiring("eq_eq", EqEqBool)
+	registerSemiring("lor_gt", LorGtBool)
+	registerSemiring("land_gt", LandGtBool)
+	registerSemiring("lxor_gt", LxorGtBool)
+	registerSemiring("eq_gt", EqGtBool)
+	registerSemiring("lor_lt", LorLtBool)
+	registerSemiring("land_lt", LandLtBool)
+	registerSemiring("lxor_lt", LxorLtBool)
+	registerSemiring("eq_lt", EqLtBool)
+	registerSemiring("lor_ge", LorGeBool)
+	registerSemiring("land_ge", LandGeBool)
+	registerSemiring("lxor_ge", LxorGeBool)
+	registerSemiring("eq_ge", EqGeBool)
+	registerSemiring("lor_le", LorLeBool)
+	registerSemiring("land_le", LandLeBool)
+	registerSemiring("lxor_le", LxorLeBool)
+	registerSemiring("eq_le", EqLeBool)
+
+	registerMonoid("lor", LorMonoidBool)
+	registerMonoid("land", LandMonoidBool)
+	registerMonoid("lxor", LxorMonoidBool)
+	registerMonoid("lxnor", LxnorMonoidBool)
+	registerMonoid("eq", LxnorMonoidBool)
+
+	registerBinaryOp("lor", LorBool)
+	registerBinaryOp("land", LandBool)
+	registerBinaryOp("lxor", LxorBool)
+	registerBinaryOp("lxnor", LxnorBool)
+	registerBinaryOp("eq", Eq[bool]())
+	registerBinaryOp("ne", Ne[bool]())
+	registerBinaryOp("gt", Gt[bool]())
+	registerBinaryOp("lt", Lt[bool]())
+	registerBinaryOp("ge", Ge[bool]())
+	registerBinaryOp("le", Le[bool]())
+}
@@ -0,0 +1,190 @@
+package GrB
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// WaitContext is like [Matrix.Wait], except that it first checks whether ctx is done,
+// and if so, returns ctx.Err() without waiting. Since the computations of GraphBLAS
+// cannot be interrupted, ctx is checked again after waiting, so that cancellation is
+// reported as soon as possible.
+//
+// GraphBLAS API errors that may be returned:
+//   - [InvalidValue], [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [IndexOutOfBounds], [OutOfMemory], [Panic]
+//
+// WaitContext is a forGraphBLASGo extension.
+func (matrix Matrix[D]) WaitContext(ctx context.Context, mode WaitMode) error {
+	return waitContext(ctx, mode, matrix.Wait)
+}
+
+// WaitContext is like [Vector.Wait], except that it first checks whether ctx is done,
+// and if so, returns ctx.Err() without waiting. Since the computations of GraphBLAS
+// cannot be interrupted, ctx is checked again after waiting, so that cancellation is
+// reported as soon as possible.
+//
+// GraphBLAS API errors that may be returned:
+//   - [InvalidValue], [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [IndexOutOfBounds], [OutOfMemory], [Panic]
+//
+// WaitContext is a forGraphBLASGo extension.
+func (vector Vector[D]) WaitContext(ctx context.Context, mode WaitMode) error {
+	return waitContext(ctx, mode, vector.Wait)
+}
+
+// WaitContext is like [Scalar.Wait], except that it first checks whether ctx is done,
+// and if so, returns ctx.Err() without waiting. Since the computations of GraphBLAS
+// cannot be interrupted, ctx is checked again after waiting, so that cancellation is
+// reported as soon as possible.
+//
+// GraphBLAS API errors that may be returned:
+//   - [InvalidValue], [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [IndexOutOfBounds], [OutOfMemory], [Panic]
+//
+// WaitContext is a forGraphBLASGo extension.
+func (scalar Scalar[D]) WaitContext(ctx context.Context, mode WaitMode) error {
+	return waitContext(ctx, mode, scalar.Wait)
+}
+
+func waitContext(ctx context.Context, mode WaitMode, wait func(WaitMode) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := wait(mode); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// A Sequence runs multi-step GraphBLAS code under the control of a [context.Context].
+// It checks the context between the steps of the sequence, so that long-running
+// iterative computations, like breadth-first searches or PageRank, can be cancelled,
+// or bounded by a deadline or timeout. It also keeps track of temporary objects
+// that need to be freed when the sequence ends, whether it completes or not.
+//
+// A Sequence is not safe for concurrent use by multiple goroutines.
+//
+// Sequence is a forGraphBLASGo extension.
+type Sequence struct {
+	ctx         context.Context
+	cancel      context.CancelFunc
+	temporaries []interface{ Free() error }
+	err         error
+}
+
+// SequenceNew creates a new [Sequence] controlled by ctx. If timeout > 0, the sequence
+// is additionally bounded by the given timeout, starting from the call of SequenceNew.
+//
+// The sequence must be freed with [Sequence.Free] when it is not needed anymore.
+//
+// SequenceNew is a forGraphBLASGo extension.
+func SequenceNew(ctx context.Context, timeout time.Duration) *Sequence {
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	return &Sequence{ctx: ctx, cancel: cancel}
+}
+
+// Context returns the context that controls the sequence, including its timeout, if any.
+// It can be passed to functions like [Matrix.WaitContext].
+//
+// Context is a forGraphBLASGo extension.
+func (s *Sequence) Context() context.Context {
+	return s.ctx
+}
+
+// Err returns the first error encountered by the sequence, or nil if there was none.
+// Once the sequence has encountered an error, no further steps are executed.
+//
+// Err is a forGraphBLASGo extension.
+func (s *Sequence) Err() error {
+	return s.err
+}
+
+// Check returns an error if the sequence has encountered an error before, or if
+// its context is done. In the latter case, the error is the context's error,
+// which is also recorded as the error of the sequence.
+//
+// Check is a forGraphBLASGo extension.
+func (s *Sequence) Check() error {
+	if s.err == nil {
+		s.err = s.ctx.Err()
+	}
+	return s.err
+}
+
+// Do executes the given steps in order, and checks the context of the sequence
+// before each step and after the last one. It stops at the first step that returns
+// an error, or as soon as the context is done, and returns that error, which is also
+// recorded as the error of the sequence. If the sequence has encountered an error
+// before, Do does not execute any steps, and returns that error.
+//
+// Do is a forGraphBLASGo extension.
+func (s *Sequence) Do(steps ...func() error) error {
+	for _, step := range steps {
+		if err := s.Check(); err != nil {
+			return err
+		}
+		if err := step(); err != nil {
+			s.err = err
+			return err
+		}
+	}
+	return s.Check()
+}
+
+// Temporary registers GraphBLAS objects, typically a *[Matrix], *[Vector], or *[Scalar],
+// to be freed by [Sequence.Free]. Objects are freed in the reverse order of registration.
+//
+// Temporary is a forGraphBLASGo extension.
+func (s *Sequence) Temporary(objects ...interface{ Free() error }) {
+	s.temporaries = append(s.temporaries, objects...)
+}
+
+// Free frees all temporaries registered with [Sequence.Temporary], in the reverse order
+// of registration, and releases the resources associated with the context of the sequence.
+// It returns the errors returned by the Free methods of the temporaries, if any.
+//
+// Free is a forGraphBLASGo extension.
+func (s *Sequence) Free() error {
+	var errs []error
+	for i := len(s.temporaries) - 1; i >= 0; i-- {
+		if err := s.temporaries[i].Free(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	s.temporaries = nil
+	s.cancel()
+	return errors.Join(errs...)
+}
+
+// RunSequence creates a new [Sequence] with [SequenceNew], passes it to body, and frees it
+// once body returns or panics. If body returns nil, but the sequence has encountered
+// an error, for example because its context is done, RunSequence returns that error
+// instead. Otherwise, RunSequence returns the error returned by body, or else the
+// error returned by [Sequence.Free].
+//
+// RunSequence is a forGraphBLASGo extension.
+func RunSequence(ctx context.Context, timeout time.Duration, body func(s *Sequence) error) (err error) {
+	s := SequenceNew(ctx, timeout)
+	defer func() {
+		if ferr := s.Free(); err == nil {
+			err = ferr
+		}
+	}()
+	if err = body(s); err == nil {
+		err = s.Err()
+	}
+	return
+}
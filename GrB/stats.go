@@ -0,0 +1,208 @@
+package GrB
+
+// MatrixStats holds statistics about the structure and content of a matrix,
+// as computed by [Matrix.Stats].
+//
+// MatrixStats is a forGraphBLASGo extension.
+type MatrixStats struct {
+	Nrows, Ncols int
+	Nvals        int
+
+	// Density is Nvals / (Nrows * Ncols), or 0 if the matrix has no positions.
+	Density float64
+
+	// The minimum, maximum, and mean number of entries in a row.
+	MinRowDegree, MaxRowDegree int
+	MeanRowDegree              float64
+
+	// The minimum, maximum, and mean number of entries in a column.
+	MinColDegree, MaxColDegree int
+	MeanColDegree              float64
+
+	// The number of rows and columns without any entries.
+	EmptyRows, EmptyCols int
+
+	// The number of entries on the main diagonal.
+	DiagonalEntries int
+
+	// LowerBandwidth is the maximum of i - j, and UpperBandwidth the maximum of j - i,
+	// over all entries A(i, j), or 0 if there are no entries below or above the diagonal.
+	// Bandwidth is the maximum of both.
+	LowerBandwidth, UpperBandwidth, Bandwidth int
+
+	// StructurallySymmetric is true if the matrix is square, and A(j, i) is present
+	// whenever A(i, j) is present.
+	StructurallySymmetric bool
+
+	// NumericallySymmetric is true if the matrix is structurally symmetric, and
+	// A(j, i) == A(i, j) for all entries. It is only computed for the [Predefined]
+	// and [Complex] domains, and is always false for user-defined domains.
+	NumericallySymmetric bool
+
+	Iso         bool
+	Sparsity    Sparsity
+	Layout      Layout
+	MemoryUsage int
+}
+
+// Stats computes statistics about the structure and content of the matrix. All
+// statistics are computed with GraphBLAS operations on the matrix, and the matrix
+// is not modified.
+//
+// GraphBLAS API errors that may be returned:
+//   - [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [InvalidObject], [OutOfMemory], [Panic]
+//
+// Stats is a forGraphBLASGo extension.
+func (matrix Matrix[D]) Stats() (stats MatrixStats, err error) {
+	defer CheckErrors(&err)
+
+	stats.Nrows, stats.Ncols, err = matrix.Size()
+	OK(err)
+	stats.Nvals, err = matrix.Nvals()
+	OK(err)
+	stats.Iso, err = matrix.Iso()
+	OK(err)
+	stats.Sparsity, err = matrix.GetSparsityStatus()
+	OK(err)
+	stats.Layout, err = matrix.GetLayout()
+	OK(err)
+	stats.MemoryUsage, err = matrix.MemoryUsage()
+	OK(err)
+
+	nrows, ncols, nvals := stats.Nrows, stats.Ncols, stats.Nvals
+	if nrows > 0 && ncols > 0 {
+		stats.Density = float64(nvals) / (float64(nrows) * float64(ncols))
+	}
+
+	// the pattern of the matrix, which works for any domain, including user-defined ones
+	pattern, err := MatrixNew[bool](nrows, ncols)
+	OK(err)
+	defer func() {
+		OK(pattern.Free())
+	}()
+	OK(MatrixAssignConstant(pattern, matrix.AsMask(), nil, true, All(nrows), All(ncols), DescS))
+	ipattern := MatrixView[int, bool](pattern)
+
+	stats.EmptyRows, stats.MinRowDegree, stats.MaxRowDegree, stats.MeanRowDegree = degreeStats(ipattern, nrows, nvals, nil)
+	stats.EmptyCols, stats.MinColDegree, stats.MaxColDegree, stats.MeanColDegree = degreeStats(ipattern, ncols, nvals, DescT0)
+
+	diagonal, err := MatrixNew[bool](nrows, ncols)
+	OK(err)
+	defer func() {
+		OK(diagonal.Free())
+	}()
+	OK(MatrixSelect(diagonal, nil, nil, Diag[bool](), pattern, 0, nil))
+	stats.DiagonalEntries, err = diagonal.Nvals()
+	OK(err)
+
+	// offsets(i, j) = j - i
+	offsets, err := MatrixNew[int](nrows, ncols)
+	OK(err)
+	defer func() {
+		OK(offsets.Free())
+	}()
+	OK(MatrixApplyIndexOp(offsets, nil, nil, DiagIndex[int, bool](), pattern, 0, nil))
+	maxOffset, err := MatrixReduce(MaxMonoid[int](), offsets, nil)
+	OK(err)
+	minOffset, err := MatrixReduce(MinMonoid[int](), offsets, nil)
+	OK(err)
+	stats.UpperBandwidth = max(0, maxOffset)
+	stats.LowerBandwidth = max(0, -minOffset)
+	stats.Bandwidth = max(stats.LowerBandwidth, stats.UpperBandwidth)
+
+	if nrows != ncols {
+		return
+	}
+
+	// the entries present in both A and A'
+	both, err := MatrixNew[bool](nrows, ncols)
+	OK(err)
+	defer func() {
+		OK(both.Free())
+	}()
+	OK(MatrixEWiseMultBinaryOp(both, nil, nil, LandBool, pattern, pattern, DescT1))
+	nboth, err := both.Nvals()
+	OK(err)
+	stats.StructurallySymmetric = nboth == nvals
+	if !stats.StructurallySymmetric {
+		return
+	}
+
+	eq, ok := eqOp[D]()
+	if !ok {
+		return
+	}
+	OK(MatrixEWiseMultBinaryOp(both, nil, nil, eq, matrix, matrix, DescT1))
+	stats.NumericallySymmetric, err = MatrixReduce(LandMonoidBool, both, nil)
+	OK(err)
+	return
+}
+
+// degreeStats computes the number of empty rows, and the minimum, maximum, and mean
+// row degree of the given pattern, or the same for the columns if desc is DescT0.
+func degreeStats(pattern Matrix[int], n, nvals int, desc *Descriptor) (empty, minDegree, maxDegree int, meanDegree float64) {
+	degree, err := VectorNew[int](n)
+	OK(err)
+	defer func() {
+		OK(degree.Free())
+	}()
+	OK(MatrixReduceMonoid(degree, nil, nil, PlusMonoid[int](), pattern, desc))
+	nonempty, err := degree.Nvals()
+	OK(err)
+	empty = n - nonempty
+	if nonempty == 0 {
+		return
+	}
+	maxDegree, err = VectorReduce(MaxMonoid[int](), degree, nil)
+	OK(err)
+	if empty == 0 {
+		minDegree, err = VectorReduce(MinMonoid[int](), degree, nil)
+		OK(err)
+	}
+	meanDegree = float64(nvals) / float64(n)
+	return
+}
+
+// eqOp returns the predefined equality operator for D, if D is one of
+// the [Predefined] or [Complex] domains.
+func eqOp[D any]() (eq BinaryOp[bool, D, D], ok bool) {
+	var d D
+	switch any(d).(type) {
+	case bool:
+		eq.grb = Eq[bool]().grb
+	case int:
+		eq.grb = Eq[int]().grb
+	case int8:
+		eq.grb = Eq[int8]().grb
+	case int16:
+		eq.grb = Eq[int16]().grb
+	case int32:
+		eq.grb = Eq[int32]().grb
+	case int64:
+		eq.grb = Eq[int64]().grb
+	case uint:
+		eq.grb = Eq[uint]().grb
+	case uint8:
+		eq.grb = Eq[uint8]().grb
+	case uint16:
+		eq.grb = Eq[uint16]().grb
+	case uint32:
+		eq.grb = Eq[uint32]().grb
+	case uint64:
+		eq.grb = Eq[uint64]().grb
+	case float32:
+		eq.grb = Eq[float32]().grb
+	case float64:
+		eq.grb = Eq[float64]().grb
+	case complex64:
+		eq.grb = Eq[complex64]().grb
+	case complex128:
+		eq.grb = Eq[complex128]().grb
+	default:
+		return eq, false
+	}
+	return eq, true
+}
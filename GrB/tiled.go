@@ -0,0 +1,211 @@
+package GrB
+
+import (
+	"runtime"
+	"sync"
+)
+
+// TiledOptions controls how [TiledMxM] splits a matrix product into block products,
+// and how the block products are scheduled.
+//
+// TiledOptions is a forGraphBLASGo extension.
+type TiledOptions struct {
+	// RowTiles is the number of blocks into which the rows of a and c are split.
+	// If RowTiles <= 0, the number of workers is used.
+	RowTiles int
+
+	// ColTiles is the number of blocks into which the columns of b and c are split.
+	// If ColTiles <= 0, the columns are not split.
+	ColTiles int
+
+	// InnerTiles is the number of blocks into which the columns of a and the rows of b
+	// are split. If InnerTiles <= 0, the inner dimension is not split.
+	InnerTiles int
+
+	// Workers is the maximum number of goroutines that compute block products
+	// concurrently. If Workers <= 0, runtime.GOMAXPROCS(0) / NThreads is used,
+	// but at least 1.
+	Workers int
+
+	// NThreads is the maximum number of threads each worker may use, which is set in
+	// the [Context] of the worker. If NThreads <= 0, each worker uses one thread.
+	NThreads int
+}
+
+// TiledMxM computes the matrix product c = a * b on a semiring, by splitting a and b
+// into blocks with [Matrix.Split], computing the products of the blocks on a bounded pool
+// of goroutines, and concatenating the results with [Matrix.Concat]. The previous content
+// of c is replaced.
+//
+// Each block c(I, J) is computed as the sum of the products a(I, K) * b(K, J) over all
+// blocks K of the inner dimension, using the additive monoid of op. Each worker goroutine
+// is locked to its OS thread and engaged to its own [Context], which limits the number of
+// threads the worker uses to opts.NThreads. This allows an application to bound the number of
+// threads used for each multiplication, while still using all cores for a single large product.
+//
+// The result is the same as the result of [MxM] without a mask, accumulator, and descriptor,
+// except that the additions of a non-associative floating-point monoid may be performed in
+// a different order.
+//
+// GraphBLAS API errors that may be returned:
+//   - [DimensionMismatch], [DomainMismatch], [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [InvalidObject], [OutOfMemory], [Panic]
+//
+// TiledMxM is a forGraphBLASGo extension.
+func TiledMxM[DC, DA, DB any](c Matrix[DC], op Semiring[DC, DA, DB], a Matrix[DA], b Matrix[DB], opts TiledOptions) (err error) {
+	nrows, ninner, err := a.Size()
+	if err != nil {
+		return
+	}
+	binner, ncols, err := b.Size()
+	if err != nil {
+		return
+	}
+	if binner != ninner {
+		return makeError(DimensionMismatch)
+	}
+	if err = checkSize(c, nrows, ncols); err != nil {
+		return
+	}
+	if nrows == 0 || ncols == 0 || ninner == 0 {
+		return c.Clear()
+	}
+
+	nthreads := max(1, opts.NThreads)
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = max(1, runtime.GOMAXPROCS(0)/nthreads)
+	}
+	rowTiles := opts.RowTiles
+	if rowTiles <= 0 {
+		rowTiles = workers
+	}
+	rowSizes := tileSizes(nrows, rowTiles)
+	colSizes := tileSizes(ncols, opts.ColTiles)
+	innerSizes := tileSizes(ninner, opts.InnerTiles)
+	m, n, k := len(rowSizes), len(colSizes), len(innerSizes)
+
+	add, err := op.Add()
+	if err != nil {
+		return
+	}
+	addOp, err := add.Operator()
+	if err != nil {
+		return
+	}
+
+	var aTiles []Matrix[DA]
+	var bTiles []Matrix[DB]
+	cTiles := make([]Matrix[DC], m*n)
+	defer func() {
+		freeTiles(aTiles, &err)
+		freeTiles(bTiles, &err)
+		freeTiles(cTiles, &err)
+	}()
+	if aTiles, err = a.Split(rowSizes, innerSizes, nil); err != nil {
+		return
+	}
+	if bTiles, err = b.Split(innerSizes, colSizes, nil); err != nil {
+		return
+	}
+	// The tiles are read concurrently by several workers,
+	// so they must not have any pending work.
+	for _, tile := range aTiles {
+		if err = tile.Wait(Materialize); err != nil {
+			return
+		}
+	}
+	for _, tile := range bTiles {
+		if err = tile.Wait(Materialize); err != nil {
+			return
+		}
+	}
+
+	// multiply computes the block c(i, j)
+	multiply := func(i, j int) (err error) {
+		defer CheckErrors(&err)
+		tile, err := MatrixNew[DC](rowSizes[i], colSizes[j])
+		OK(err)
+		cTiles[i*n+j] = tile
+		for l := 0; l < k; l++ {
+			var accum *BinaryOp[DC, DC, DC]
+			if l > 0 {
+				accum = &addOp
+			}
+			OK(MxM(tile, nil, accum, op, aTiles[i*k+l], bTiles[l*n+j], nil))
+		}
+		return tile.Wait(Materialize)
+	}
+
+	// worker computes blocks until the jobs are exhausted, or an error occurs
+	worker := func(jobs <-chan int) (err error) {
+		defer CheckErrors(&err)
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		context, err := ContextNew()
+		OK(err)
+		defer func() {
+			OK(context.Free())
+		}()
+		OK(context.SetNThreads(nthreads))
+		OK(context.Engage())
+		defer func() {
+			OK(ContextDisengage(&context))
+		}()
+		for job := range jobs {
+			OK(multiply(job/n, job%n))
+		}
+		return
+	}
+
+	jobs := make(chan int)
+	errs := make([]error, min(workers, m*n))
+	var wg sync.WaitGroup
+	for w := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if errs[w] = worker(jobs); errs[w] != nil {
+				// keep draining, so that the producer is not blocked
+				for range jobs {
+				}
+			}
+		}()
+	}
+	for job := range m * n {
+		jobs <- job
+	}
+	close(jobs)
+	wg.Wait()
+	for _, werr := range errs {
+		if werr != nil {
+			return werr
+		}
+	}
+
+	return c.Concat(cTiles, m, n, nil)
+}
+
+// tileSizes splits n into at most t sizes that differ by at most 1, none of which is 0.
+func tileSizes(n, t int) []int {
+	t = max(1, min(t, n))
+	sizes := make([]int, t)
+	for i := range sizes {
+		sizes[i] = n / t
+		if i < n%t {
+			sizes[i]++
+		}
+	}
+	return sizes
+}
+
+// freeTiles frees all tiles, and records the first error in err if it is nil.
+func freeTiles[D any](tiles []Matrix[D], err *error) {
+	for i := range tiles {
+		if ferr := tiles[i].Free(); *err == nil {
+			*err = ferr
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package GrB
+
+// MatrixTopKPerRow selects the k first entries of each row of a, according to the
+// order defined by cmp. For example, with [Gt], it selects the k largest entries of each row,
+// and with [Lt], the k smallest entries. Ties are broken by index: if two entries of a row
+// compare equal, the one with the smaller column index comes first.
+//
+// Parameters:
+//
+//   - c (OUT): An nrows x k matrix, where c(i, r) holds the value of the r-th selected entry
+//     in row i of a. Rows of a with fewer than k entries yield fewer entries in c.
+//     The previous content of c is replaced. If nil, this output is not produced.
+//
+//   - p (OUT): An nrows x k matrix, where p(i, r) holds the column index in a of the
+//     r-th selected entry in row i. The previous content of p is replaced. If nil, this
+//     output is not produced.
+//
+//   - a (IN): The matrix from which entries are selected.
+//
+//   - k (IN): The number of entries to select in each row.
+//
+//   - cmp (IN): The comparator operation, which must define a strict or non-strict total
+//     order on the values of a.
+//
+// GraphBLAS API errors that may be returned:
+//   - [DimensionMismatch], [DomainMismatch], [InvalidValue], [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [InvalidObject], [OutOfMemory], [Panic]
+//
+// MatrixTopKPerRow is a forGraphBLASGo extension.
+func MatrixTopKPerRow[D any](c *Matrix[D], p *Matrix[int], a Matrix[D], k int, cmp BinaryOp[bool, D, D]) error {
+	return matrixTopK(c, p, a, k, cmp, false)
+}
+
+// MatrixTopKPerCol is like [MatrixTopKPerRow], except that it selects the k first entries of
+// each column of a. c and p are k x ncols matrices, and p holds the row indices in a of the
+// selected entries. Ties are broken by row index.
+//
+// GraphBLAS API errors that may be returned:
+//   - [DimensionMismatch], [DomainMismatch], [InvalidValue], [UninitializedObject]
+//
+// GraphBLAS execution errors that may cause a panic:
+//   - [InvalidObject], [OutOfMemory], [Panic]
+//
+// MatrixTopKPerCol is a forGraphBLASGo extension.
+func MatrixTopKPerCol[D any](c *Matrix[D], p *Matrix[int], a Matrix[D], k int, cmp BinaryOp[bool, D, D]) error {
+	return matrixTopK(c, p, a, k, cmp, true)
+}
+
+func matrixTopK[D any](c *Matrix[D], p *Matrix[int], a Matrix[D], k int, cmp BinaryOp[bool, D, D], byCol bool) (err error) {
+	if k < 0 {
+		return makeError(InvalidValue)
+	}
+	nrows, ncols, err := a.Size()
+	if err != nil {
+		return
+	}
+	// the dimensions of the results
+	rrows, rcols := nrows, k
+	var desc *Descriptor
+	if byCol {
+		rrows, rcols = k, ncols
+		desc = DescT0
+	}
+	if c != nil {
+		if err = checkSize(*c, rrows, rcols); err != nil {
+			return
+		}
+	}
+	if p != nil {
+		if err = checkSize(*p, rrows, rcols); err != nil {
+			return
+		}
+	}
+
+	if c == nil && p == nil {
+		return nil
+	}
+	var sorted *Matrix[D]
+	var perm *Matrix[int]
+	var s Matrix[D]
+	var q Matrix[int]
+	defer func() {
+		if ferr := s.Free(); err == nil {
+			err = ferr
+		}
+		if ferr := q.Free(); err == nil {
+			err = ferr
+		}
+	}()
+	if c != nil {
+		if s, err = MatrixNew[D](nrows, ncols); err != nil {
+			return
+		}
+		sorted = &s
+	}
+	if p != nil {
+		if q, err = MatrixNew[int](nrows, ncols); err != nil {
+			return
+		}
+		perm = &q
+	}
+	// Sort moves the entries of each row (or column) to the leading positions,
+	// so the first k of them are the entries to be selected.
+	if err = a.Sort(sorted, perm, cmp, desc); err != nil {
+		return
+	}
+	if sorted != nil {
+		if err = sorted.Resize(rrows, rcols); err != nil {
+			return
+		}
+		if err = MatrixAssign(*c, nil, nil, *sorted, All(rrows), All(rcols), nil); err != nil {
+			return
+		}
+	}
+	if perm != nil {
+		if err = perm.Resize(rrows, rcols); err != nil {
+			return
+		}
+		if err = MatrixAssign(*p, nil, nil, *perm, All(rrows), All(rcols), nil); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func checkSize[D any](matrix Matrix[D], nrows, ncols int) error {
+	r, c, err := matrix.Size()
+	if err != nil {
+		return err
+	}
+	if r != nrows || c != ncols {
+		return makeError(DimensionMismatch)
+	}
+	return nil
+}
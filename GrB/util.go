@@ -96,6 +96,18 @@ func growslice[T any](sptr *[]T, n int) (newSection []T) {
 	return t[len(s) : len(s)+n]
 }
 
+// buildEmpty handles calls to Build or BuildScalar without any tuples, for which
+// SuiteSparse:GraphBLAS would otherwise report a null pointer for the empty slices.
+func buildEmpty(nvals int, err error) error {
+	if err != nil {
+		return err
+	}
+	if nvals != 0 {
+		return makeError(OutputNotEmpty)
+	}
+	return nil
+}
+
 func gotocbool(b bool) C.int32_t {
 	if b {
 		return 1
@@ -219,6 +219,9 @@ func (vector Vector[D]) Nvals() (nvals int, err error) {
 //     values for the same index are present in the input slices. All three domains
 //     of dup must be D. If dup is nil, then duplicate indices will result in an [InvalidValue] error.
 //
+// If the slices are empty, Build leaves the vector without entries, but still
+// returns [OutputNotEmpty] if the vector already has entries.
+//
 // GraphBLAS API errors that may be returned:
 //   - [DomainMismatch], [InvalidValue], [SliceMismatch], [OutputNotEmpty], [UninitializedObject]
 //
@@ -233,6 +236,9 @@ func (vector Vector[D]) Build(indices []int, values []D, dup *BinaryOp[D, D, D])
 			return makeError(InvalidIndex)
 		}
 	}
+	if len(values) == 0 {
+		return buildEmpty(vector.Nvals())
+	}
 	var cdup C.GrB_BinaryOp
 	if dup == nil {
 		cdup = C.GrB_BinaryOp(C.GrB_NULL)
@@ -363,7 +369,7 @@ func (vector Vector[D]) Build(indices []int, values []D, dup *BinaryOp[D, D, D])
 // BuildScalar is like [Vector.Build], except that the scalar is the value of all the tuples.
 //
 // Unlike [Vector.Build], there is no dup operator to handle duplicate entries. Instead, any
-// duplicates are silently ignored.
+// duplicates are silently ignored. As with [Vector.Build], empty index slices are allowed.
 //
 // BuildScalar is a SuiteSparse:GraphBLAS extension.
 func (vector Vector[D]) BuildScalar(indices []int, scalar Scalar[D]) error {
@@ -372,6 +378,9 @@ func (vector Vector[D]) BuildScalar(indices []int, scalar Scalar[D]) error {
 			return makeError(InvalidIndex)
 		}
 	}
+	if len(indices) == 0 {
+		return buildEmpty(vector.Nvals())
+	}
 	info := Info(C.GxB_Vector_build_Scalar(
 		vector.grb, grbIndices(indices),
 		scalar.grb, C.GrB_Index(len(indices)),
@@ -721,7 +730,7 @@ func (vector Vector[D]) IsStoredElement(index int) (ok bool, err error) {
 //     produces the values of the vector.
 //
 // It is valid to pass pointers to nil slices, and ExtractTuples then produces the
-// corresponding indices or values.
+// corresponding indices or values. If the vector has no entries, the slices are left unchanged.
 //
 // GraphBLAS API errors that may be returned:
 //   - [DomainMismatch], [UninitializedObject]
@@ -733,6 +742,9 @@ func (vector Vector[D]) ExtractTuples(indices *[]int, values *[]D) error {
 	if err != nil {
 		return err
 	}
+	if nvals == 0 {
+		return nil
+	}
 	targetIndices, finalizeTargetIndices := growIndices(indices, nvals)
 	targetValues := growslice(values, nvals)
 	var info Info
@@ -1073,6 +1085,7 @@ func (vector Vector[D]) Valid() bool {
 //   - [Panic]
 func (vector *Vector[D]) Free() error {
 	info := Info(C.GrB_Vector_free(&vector.grb))
+	reapBorrowed()
 	if info == success {
 		return nil
 	}
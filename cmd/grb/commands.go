@@ -0,0 +1,333 @@
+package main
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"github.com/intel/forGraphBLASGo/GrB/algorithm"
+	"io"
+	"math/bits"
+	"slices"
+	"text/tabwriter"
+)
+
+func runConvert(flags *flag.FlagSet, args []string, stdout io.Writer) (err error) {
+	var in inputFlags
+	in.register(flags, "from")
+	to := flags.String("to", "", "output `format`: mtx, edges, or grb (default: determined by the file extension)")
+	outBase := flags.Int("outbase", 0, "index of the first vertex in output edge lists")
+	compression := flags.String("compression", "zstd", "compression `method` for grb output: none, lz4, lz4hc[:level], or zstd[:level]")
+	if err = flags.Parse(args); err != nil {
+		return
+	}
+	if flags.NArg() != 2 {
+		return errUsage
+	}
+	method, err := parseCompression(*compression)
+	if err != nil {
+		return
+	}
+	m, err := in.load(flags.Arg(0))
+	if err != nil {
+		return
+	}
+	defer func() {
+		if ferr := m.Free(); err == nil {
+			err = ferr
+		}
+	}()
+	return save(flags.Arg(1), *to, m, method, *outBase)
+}
+
+func runStats(flags *flag.FlagSet, args []string, stdout io.Writer) (err error) {
+	var in inputFlags
+	in.register(flags, "format")
+	if err = flags.Parse(args); err != nil {
+		return
+	}
+	if flags.NArg() != 1 {
+		return errUsage
+	}
+	m, err := in.load(flags.Arg(0))
+	if err != nil {
+		return
+	}
+	defer func() {
+		if ferr := m.Free(); err == nil {
+			err = ferr
+		}
+	}()
+	return printStats(stdout, m)
+}
+
+func printStats(w io.Writer, m matrix) (err error) {
+	defer GrB.CheckErrors(&err)
+
+	stats, err := m.Stats()
+	GrB.OK(err)
+	histogram, err := degreeHistogram(m)
+	GrB.OK(err)
+
+	tw := tabwriter.NewWriter(w, 0, 8, 1, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "type:\t%v\n", m.typeName())
+	_, _ = fmt.Fprintf(tw, "dimensions:\t%v x %v\n", stats.Nrows, stats.Ncols)
+	_, _ = fmt.Fprintf(tw, "nvals:\t%v\n", stats.Nvals)
+	_, _ = fmt.Fprintf(tw, "density:\t%.6g\n", stats.Density)
+	_, _ = fmt.Fprintf(tw, "sparsity:\t%v\n", stats.Sparsity)
+	_, _ = fmt.Fprintf(tw, "iso:\t%v\n", stats.Iso)
+	_, _ = fmt.Fprintf(tw, "memory:\t%v bytes\n", stats.MemoryUsage)
+	_, _ = fmt.Fprintf(tw, "layout:\t%v\n", stats.Layout)
+	_, _ = fmt.Fprintf(tw, "row degree:\tmin %v, max %v, mean %.6g, %v empty\n",
+		stats.MinRowDegree, stats.MaxRowDegree, stats.MeanRowDegree, stats.EmptyRows)
+	_, _ = fmt.Fprintf(tw, "column degree:\tmin %v, max %v, mean %.6g, %v empty\n",
+		stats.MinColDegree, stats.MaxColDegree, stats.MeanColDegree, stats.EmptyCols)
+	_, _ = fmt.Fprintf(tw, "diagonal:\t%v entries\n", stats.DiagonalEntries)
+	_, _ = fmt.Fprintf(tw, "bandwidth:\t%v (lower %v, upper %v)\n",
+		stats.Bandwidth, stats.LowerBandwidth, stats.UpperBandwidth)
+	_, _ = fmt.Fprintf(tw, "symmetric:\tstructurally %v, numerically %v\n",
+		stats.StructurallySymmetric, stats.NumericallySymmetric)
+	GrB.OK(tw.Flush())
+
+	_, _ = fmt.Fprintln(w, "row degree histogram:")
+	tw = tabwriter.NewWriter(w, 0, 8, 1, ' ', tabwriter.AlignRight)
+	for b, count := range histogram {
+		if count == 0 {
+			continue
+		}
+		lo, hi := bucketBounds(b)
+		if lo == hi {
+			_, _ = fmt.Fprintf(tw, "\t%v:\t%v\t\n", lo, count)
+		} else {
+			_, _ = fmt.Fprintf(tw, "\t%v-%v:\t%v\t\n", lo, hi, count)
+		}
+	}
+	return tw.Flush()
+}
+
+// degreeHistogram counts the rows of m by the number of entries they hold, in buckets of
+// exponentially increasing size: bucket 0 holds the empty rows, and bucket b > 0 holds
+// the rows with a degree in [2^(b-1), 2^b).
+func degreeHistogram(m matrix) (histogram []int, err error) {
+	defer GrB.CheckErrors(&err)
+
+	p, err := m.pattern()
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(p.Free())
+	}()
+	nrows, _, err := p.Size()
+	GrB.OK(err)
+
+	degree, err := GrB.VectorNew[int](nrows)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(degree.Free())
+	}()
+	GrB.OK(GrB.MatrixReduceMonoid(degree, nil, nil, GrB.PlusMonoid[int](), GrB.MatrixView[int, bool](p), nil))
+
+	var indices, degrees []int
+	GrB.OK(degree.ExtractTuples(&indices, &degrees))
+	histogram = make([]int, 1)
+	histogram[0] = nrows - len(degrees)
+	for _, d := range degrees {
+		b := bits.Len(uint(d))
+		for len(histogram) <= b {
+			histogram = append(histogram, 0)
+		}
+		histogram[b]++
+	}
+	return
+}
+
+func bucketBounds(b int) (lo, hi int) {
+	if b == 0 {
+		return 0, 0
+	}
+	return 1 << (b - 1), 1<<b - 1
+}
+
+// graphFlags are the flags common to the commands that run graph algorithms.
+type graphFlags struct {
+	inputFlags
+	undirected bool
+	// vertexBase is the index of the first vertex in the input and output of a command,
+	// which is the base of edge lists, and 0 for all other formats
+	vertexBase int
+}
+
+func (f *graphFlags) register(flags *flag.FlagSet) {
+	f.inputFlags.register(flags, "format")
+	flags.BoolVar(&f.undirected, "undirected", false, "treat the graph as undirected, by adding the reverse of each edge")
+}
+
+// loadGraph reads the matrix in path, and returns its structure as a boolean adjacency matrix.
+func (f *graphFlags) loadGraph(path string) (A GrB.Matrix[bool], err error) {
+	defer func() {
+		if err != nil && A.Valid() {
+			_ = A.Free()
+		}
+	}()
+	defer GrB.CheckErrors(&err)
+
+	format, err := formatOf(path, f.format)
+	GrB.OK(err)
+	if format == formatEdgeList {
+		f.vertexBase = f.base
+	}
+	m, err := f.load(path)
+	GrB.OK(err)
+	defer func() {
+		GrB.OK(m.Free())
+	}()
+	nrows, ncols, err := m.Size()
+	GrB.OK(err)
+	if nrows != ncols {
+		return A, fmt.Errorf("%v: adjacency matrix must be square, got %v x %v", path, nrows, ncols)
+	}
+	A, err = m.pattern()
+	GrB.OK(err)
+	if f.undirected {
+		GrB.OK(GrB.MatrixEWiseAddBinaryOp(A, nil, nil, GrB.LorBool, A, A, GrB.DescT1))
+	}
+	return
+}
+
+func runBFS(flags *flag.FlagSet, args []string, stdout io.Writer) (err error) {
+	var g graphFlags
+	g.register(flags)
+	source := flags.Int("source", 0, "source `vertex` of the search")
+	parents := flags.Bool("parents", false, "print the parent of each reachable vertex instead of its level")
+	if err = flags.Parse(args); err != nil {
+		return
+	}
+	if flags.NArg() != 1 {
+		return errUsage
+	}
+	A, err := g.loadGraph(flags.Arg(0))
+	if err != nil {
+		return
+	}
+	defer func() {
+		if ferr := A.Free(); err == nil {
+			err = ferr
+		}
+	}()
+	n, err := A.Nrows()
+	if err != nil {
+		return
+	}
+	s := *source - g.vertexBase
+	if s < 0 || s >= n {
+		return fmt.Errorf("source vertex %v out of bounds for %v vertices", *source, n)
+	}
+	var result GrB.Vector[int]
+	if *parents {
+		result, err = algorithm.BFSParents(A, s)
+	} else {
+		result, err = algorithm.BFSLevels(A, s)
+	}
+	if err != nil {
+		return
+	}
+	defer func() {
+		if ferr := result.Free(); err == nil {
+			err = ferr
+		}
+	}()
+	var indices, values []int
+	if err = result.ExtractTuples(&indices, &values); err != nil {
+		return
+	}
+	for k, i := range indices {
+		_, _ = fmt.Fprintln(stdout, i+g.vertexBase, values[k]+g.vertexBase*boolToInt(*parents))
+	}
+	return
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func runTC(flags *flag.FlagSet, args []string, stdout io.Writer) (err error) {
+	var g graphFlags
+	g.register(flags)
+	if err = flags.Parse(args); err != nil {
+		return
+	}
+	if flags.NArg() != 1 {
+		return errUsage
+	}
+	// triangle counting is only defined for undirected graphs
+	g.undirected = true
+	A, err := g.loadGraph(flags.Arg(0))
+	if err != nil {
+		return
+	}
+	defer func() {
+		if ferr := A.Free(); err == nil {
+			err = ferr
+		}
+	}()
+	count, err := algorithm.TriangleCount(A)
+	if err != nil {
+		return
+	}
+	_, err = fmt.Fprintln(stdout, count)
+	return
+}
+
+func runPageRank(flags *flag.FlagSet, args []string, stdout io.Writer) (err error) {
+	var g graphFlags
+	g.register(flags)
+	damping := flags.Float64("damping", 0.85, "damping `factor`")
+	tol := flags.Float64("tol", 1e-6, "convergence `tolerance`")
+	maxIter := flags.Int("maxiter", 100, "maximum number of `iterations`")
+	top := flags.Int("top", 0, "print only the `k` vertices with the highest rank (default: all vertices)")
+	if err = flags.Parse(args); err != nil {
+		return
+	}
+	if flags.NArg() != 1 {
+		return errUsage
+	}
+	A, err := g.loadGraph(flags.Arg(0))
+	if err != nil {
+		return
+	}
+	defer func() {
+		if ferr := A.Free(); err == nil {
+			err = ferr
+		}
+	}()
+	rank, iterations, err := algorithm.PageRank(A, *damping, *tol, *maxIter)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if ferr := rank.Free(); err == nil {
+			err = ferr
+		}
+	}()
+	ranks, err := rank.ToDense(0)
+	if err != nil {
+		return
+	}
+	vertices := make([]int, len(ranks))
+	for i := range vertices {
+		vertices[i] = i
+	}
+	if *top > 0 {
+		slices.SortStableFunc(vertices, func(i, j int) int {
+			return cmp.Compare(ranks[j], ranks[i])
+		})
+		vertices = vertices[:min(*top, len(vertices))]
+	}
+	_, _ = fmt.Fprintf(stdout, "# %v iterations\n", iterations)
+	for _, i := range vertices {
+		_, _ = fmt.Fprintf(stdout, "%v %.6g\n", i+g.vertexBase, ranks[i])
+	}
+	return
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// readEdgeList reads a graph given as one edge "i j [weight]" per line. If at least one edge
+// has a weight, the result is a float64 matrix, where edges without weight have weight 1;
+// otherwise, the result is a boolean matrix. If n < 0, the number of vertices is one more than
+// the largest vertex index. Duplicate edges are ignored.
+func readEdgeList(r io.Reader, base, n int) (matrix, error) {
+	s := newScanner(r)
+	comment := func(line string) bool { return line[0] == '#' || line[0] == '%' }
+
+	var rows, cols []int
+	var weights []float64
+	weighted := false
+	maxIndex := -1
+	for {
+		fields, err := s.next(comment)
+		if err == io.ErrUnexpectedEOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if len(fields) == 1 && strings.ContainsRune(fields[0], ',') {
+			fields = strings.Split(fields[0], ",")
+		}
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, s.errorf("expected 2 or 3 fields, got %v", len(fields))
+		}
+		ij, err := parseIndices(s, fields[:2])
+		if err != nil {
+			return nil, err
+		}
+		i, j := ij[0]-base, ij[1]-base
+		if i < 0 || j < 0 {
+			return nil, s.errorf("vertex index smaller than base %v", base)
+		}
+		maxIndex = max(maxIndex, i, j)
+		weight := 1.0
+		if len(fields) == 3 {
+			weighted = true
+			if weight, err = strconv.ParseFloat(fields[2], 64); err != nil {
+				return nil, s.errorf("invalid weight %q", fields[2])
+			}
+		}
+		rows = append(rows, i)
+		cols = append(cols, j)
+		weights = append(weights, weight)
+	}
+	if n < 0 {
+		n = maxIndex + 1
+	} else if maxIndex >= n {
+		return nil, fmt.Errorf("vertex index %v out of bounds for %v vertices", maxIndex+base, n)
+	}
+	if weighted {
+		first := GrB.First[float64, float64]()
+		return build(n, n, rows, cols, weights, &first)
+	}
+	values := make([]bool, len(rows))
+	for k := range values {
+		values[k] = true
+	}
+	first := GrB.First[bool, bool]()
+	return build(n, n, rows, cols, values, &first)
+}
+
+func (m typed[D]) writeEdgeList(w io.Writer, base int) error {
+	rows, cols, values, err := m.tuples()
+	if err != nil {
+		return err
+	}
+	_, pattern := any(values).([]bool)
+	bw := bufio.NewWriter(w)
+	for k, value := range values {
+		if pattern {
+			_, _ = fmt.Fprintf(bw, "%v %v\n", rows[k]+base, cols[k]+base)
+		} else {
+			_, _ = fmt.Fprintf(bw, "%v %v %v\n", rows[k]+base, cols[k]+base, formatValue(value))
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// File formats.
+const (
+	formatMatrixMarket = "mtx"
+	formatEdgeList     = "edges"
+	formatBlob         = "grb"
+)
+
+// formatOf returns the explicitly given format, or else the format determined by the extension of path.
+func formatOf(path, format string) (string, error) {
+	switch format {
+	case formatMatrixMarket, formatEdgeList, formatBlob:
+		return format, nil
+	case "":
+	default:
+		return "", fmt.Errorf("unknown format %q", format)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".mtx", ".mm":
+		return formatMatrixMarket, nil
+	case ".el", ".edges", ".txt", ".tsv", ".csv":
+		return formatEdgeList, nil
+	case ".grb":
+		return formatBlob, nil
+	}
+	return "", fmt.Errorf("cannot determine the format of %v", path)
+}
+
+// inputFlags are the flags common to all commands that read a matrix.
+type inputFlags struct {
+	format string
+	base   int
+	n      int
+}
+
+func (f *inputFlags) register(flags *flag.FlagSet, formatFlag string) {
+	flags.StringVar(&f.format, formatFlag, "", "input `format`: mtx, edges, or grb (default: determined by the file extension)")
+	flags.IntVar(&f.base, "base", 0, "index of the first vertex in edge lists")
+	flags.IntVar(&f.n, "n", -1, "number of vertices in edge lists (default: one more than the largest vertex index)")
+}
+
+// load reads a matrix from a file.
+func (f *inputFlags) load(path string) (matrix, error) {
+	format, err := formatOf(path, f.format)
+	if err != nil {
+		return nil, err
+	}
+	if format == formatBlob {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return readBlob(data)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	var m matrix
+	if format == formatMatrixMarket {
+		m, err = readMatrixMarket(bufio.NewReader(file))
+	} else {
+		m, err = readEdgeList(bufio.NewReader(file), f.base, f.n)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	return m, nil
+}
+
+// save writes a matrix to a file.
+func save(path, format string, m matrix, compression GrB.DescValue, base int) (err error) {
+	if format, err = formatOf(path, format); err != nil {
+		return
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if cerr := file.Close(); err == nil {
+			err = cerr
+		}
+	}()
+	switch format {
+	case formatMatrixMarket:
+		return m.writeMatrixMarket(file)
+	case formatEdgeList:
+		return m.writeEdgeList(file, base)
+	default:
+		return writeBlob(file, m, compression)
+	}
+}
+
+// parseCompression parses a compression method for serialized matrices: "none", "lz4",
+// or "lz4hc" or "zstd", optionally followed by a colon and a compression level.
+func parseCompression(s string) (GrB.DescValue, error) {
+	method, level, hasLevel := strings.Cut(strings.ToLower(s), ":")
+	var base GrB.DescValue
+	maxLevel := 0
+	switch method {
+	case "none":
+		base = GrB.CompressionNone
+	case "lz4":
+		base = GrB.CompressionLZ4
+	case "lz4hc":
+		base, maxLevel = GrB.CompressionLZ4HC, 9
+	case "zstd":
+		base, maxLevel = GrB.CompressionZSTD, 19
+	default:
+		return 0, fmt.Errorf("unknown compression method %q", method)
+	}
+	if !hasLevel {
+		return base, nil
+	}
+	n, err := strconv.Atoi(level)
+	if err != nil || n < 0 || n > maxLevel {
+		return 0, fmt.Errorf("invalid compression level %q for %v", level, method)
+	}
+	return base + GrB.DescValue(n), nil
+}
@@ -0,0 +1,121 @@
+/*
+Grb converts, inspects, and analyzes sparse matrices stored in files, using the GrB package.
+
+Usage:
+
+	grb <command> [flags] <files>
+
+The commands are:
+
+	convert   convert a matrix between Matrix Market, edge list, and serialized formats
+	stats     print information about a matrix
+	bfs       run a breadth-first search on a graph
+	tc        count the triangles of an undirected graph
+	pagerank  compute the PageRank of the vertices of a graph
+
+Use "grb <command> -h" for more information about a command.
+
+The format of a file is determined by its extension, unless it is given explicitly with
+the -format flag (or -from and -to for the convert command):
+
+	mtx       Matrix Market (.mtx, .mm)
+	edges     edge list (.el, .edges, .txt, .tsv, .csv): one edge "i j [value]" per line
+	grb       serialized GraphBLAS matrix (.grb), as produced by [GrB.Matrix.SerializeBlob]
+
+Lines in edge lists starting with '#' or '%' are ignored. Vertex indices in edge lists
+are 0-based by default; use -base 1 for 1-based indices.
+
+For graph algorithms, an entry A(i, j) of the matrix denotes an edge from vertex i to vertex j.
+Only the structure of the matrix is used; values are ignored.
+*/
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"io"
+	"os"
+)
+
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(flags *flag.FlagSet, args []string, stdout io.Writer) error
+}
+
+var commands = []command{
+	{"convert", "convert [flags] <input> <output>", "convert a matrix between Matrix Market, edge list, and serialized formats", runConvert},
+	{"stats", "stats [flags] <input>", "print information about a matrix", runStats},
+	{"bfs", "bfs [flags] <input>", "run a breadth-first search on a graph", runBFS},
+	{"tc", "tc [flags] <input>", "count the triangles of an undirected graph", runTC},
+	{"pagerank", "pagerank [flags] <input>", "compute the PageRank of the vertices of a graph", runPageRank},
+}
+
+var errUsage = errors.New("usage")
+
+func usage(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "usage: grb <command> [flags] <files>")
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "The commands are:")
+	_, _ = fmt.Fprintln(w)
+	for _, cmd := range commands {
+		_, _ = fmt.Fprintf(w, "\t%-9s %s\n", cmd.name, cmd.summary)
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, `Use "grb <command> -h" for more information about a command.`)
+}
+
+// run executes the command given by args, and returns the exit status of the program.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+		flags := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+		flags.SetOutput(stderr)
+		flags.Usage = func() {
+			_, _ = fmt.Fprintf(stderr, "usage: grb %s\n\n", cmd.usage)
+			flags.PrintDefaults()
+		}
+		err := cmd.run(flags, args[1:], stdout)
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.Is(err, errUsage):
+			flags.Usage()
+			return 2
+		default:
+			_, _ = fmt.Fprintf(stderr, "grb %s: %v\n", cmd.name, err)
+			return 1
+		}
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" {
+		usage(stdout)
+		return 0
+	}
+	_, _ = fmt.Fprintf(stderr, "grb: unknown command %q\n", args[0])
+	usage(stderr)
+	return 2
+}
+
+func main() {
+	if err := GrB.Init(GrB.NonBlocking); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "grb:", err)
+		os.Exit(1)
+	}
+	status := run(os.Args[1:], os.Stdout, os.Stderr)
+	if err := GrB.Finalize(); err != nil && status == 0 {
+		_, _ = fmt.Fprintln(os.Stderr, "grb:", err)
+		status = 1
+	}
+	os.Exit(status)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	if err := GrB.Init(GrB.NonBlocking); err != nil {
+		panic(err)
+	}
+	defer func() {
+		if err := GrB.Finalize(); err != nil {
+			panic(err)
+		}
+	}()
+	m.Run()
+}
+
+func grb(args ...string) {
+	if status := run(args, os.Stdout, os.Stdout); status != 0 {
+		panic(status)
+	}
+}
+
+func Example_stats() {
+	var out strings.Builder
+	if status := run([]string{"stats", "testdata/graph.mtx"}, &out, os.Stderr); status != 0 {
+		panic(status)
+	}
+	// the memory usage depends on the version of SuiteSparse:GraphBLAS
+	for _, line := range strings.SplitAfter(out.String(), "\n") {
+		if !strings.HasPrefix(line, "memory:") {
+			fmt.Print(line)
+		}
+	}
+	// Output:
+	// type:          bool
+	// dimensions:    15 x 15
+	// nvals:         36
+	// density:       0.16
+	// sparsity:      bitmap
+	// iso:           true
+	// layout:        by row
+	// row degree:    min 1, max 4, mean 2.4, 0 empty
+	// column degree: min 1, max 4, mean 2.4, 0 empty
+	// diagonal:      0 entries
+	// bandwidth:     8 (lower 8, upper 8)
+	// symmetric:     structurally true, numerically true
+	// row degree histogram:
+	//     1: 4
+	//   2-3: 6
+	//   4-7: 5
+}
+
+func Example_convert() {
+	dir, err := os.MkdirTemp("", "grb")
+	if err != nil {
+		panic(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	blob := filepath.Join(dir, "weighted.grb")
+	mtx := filepath.Join(dir, "weighted.mtx")
+	grb("convert", "-compression", "lz4hc:9", "testdata/weighted.el", blob)
+	grb("convert", blob, mtx)
+	data, err := os.ReadFile(mtx)
+	if err != nil {
+		panic(err)
+	}
+	_, _ = os.Stdout.Write(data)
+	// Output:
+	// %%MatrixMarket matrix coordinate real general
+	// %%GraphBLAS type double
+	// 3 3 4
+	// 1 2 0.5
+	// 1 3 1.5
+	// 2 3 2
+	// 3 1 -1
+}
+
+func Example_algorithms() {
+	grb("tc", "testdata/graph.mtx")
+	grb("bfs", "-source", "14", "testdata/graph.mtx")
+	grb("pagerank", "-tol", "1e-9", "testdata/graph.mtx")
+	// Output:
+	// 2
+	// 0 3
+	// 1 3
+	// 2 3
+	// 3 2
+	// 4 2
+	// 5 4
+	// 6 4
+	// 7 4
+	// 8 4
+	// 9 3
+	// 10 1
+	// 11 1
+	// 12 3
+	// 13 5
+	// 14 0
+	// # 60 iterations
+	// 0 0.099835
+	// 1 0.105688
+	// 2 0.105688
+	// 3 0.105688
+	// 4 0.105688
+	// 5 0.0324587
+	// 6 0.0574697
+	// 7 0.0574697
+	// 8 0.0324587
+	// 9 0.0324587
+	// 10 0.0574697
+	// 11 0.0574697
+	// 12 0.0324587
+	// 13 0.0588492
+	// 14 0.0588492
+}
+
+func Example_base() {
+	grb("bfs", "-base", "1", "-source", "1", "testdata/path.el")
+	grb("bfs", "-base", "1", "-source", "4", "testdata/path.el")
+	grb("bfs", "-base", "1", "-source", "2", "-parents", "testdata/path.el")
+	// Output:
+	// 1 0
+	// 2 1
+	// 3 2
+	// 4 3
+	// 4 0
+	// 2 2
+	// 3 2
+	// 4 3
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"io"
+)
+
+// domain is the set of element types the tool can read and write.
+type domain interface {
+	GrB.Predefined | GrB.Complex
+}
+
+// matrix is a type-erased GraphBLAS matrix, so that the commands can work on matrices
+// of any domain, which is typically only known after a file has been read.
+type matrix interface {
+	Size() (nrows, ncols int, err error)
+	Stats() (GrB.MatrixStats, error)
+	SerializeBlob(desc *GrB.Descriptor) (GrB.SystemSlice[byte], error)
+	Free() error
+
+	// typeName returns the name of the domain of the matrix, as used by SuiteSparse:GraphBLAS.
+	typeName() string
+	// pattern returns a new boolean matrix with the same structure, and all values set to true.
+	pattern() (GrB.Matrix[bool], error)
+	// writeMatrixMarket writes the matrix in Matrix Market format.
+	writeMatrixMarket(w io.Writer) error
+	// writeEdgeList writes one line per entry of the matrix.
+	writeEdgeList(w io.Writer, base int) error
+}
+
+type typed[D domain] struct {
+	GrB.Matrix[D]
+}
+
+func (m typed[D]) typeName() string {
+	var d D
+	return typeNameOf(d)
+}
+
+func (m typed[D]) pattern() (p GrB.Matrix[bool], err error) {
+	defer GrB.CheckErrors(&err)
+	nrows, ncols, err := m.Size()
+	GrB.OK(err)
+	p, err = GrB.MatrixNew[bool](nrows, ncols)
+	GrB.OK(err)
+	GrB.OK(GrB.MatrixAssignConstant(p, m.AsMask(), nil, true, GrB.All(nrows), GrB.All(ncols), GrB.DescS))
+	return
+}
+
+func (m typed[D]) tuples() (rows, cols []int, values []D, err error) {
+	err = m.ExtractTuples(&rows, &cols, &values)
+	return
+}
+
+// typeNames maps the names of the predefined SuiteSparse:GraphBLAS types to the
+// corresponding constructors of typed matrices.
+var typeNames = map[string]func(data []byte) (matrix, error){
+	"bool":           deserialize[bool],
+	"int8_t":         deserialize[int8],
+	"int16_t":        deserialize[int16],
+	"int32_t":        deserialize[int32],
+	"int64_t":        deserialize[int64],
+	"uint8_t":        deserialize[uint8],
+	"uint16_t":       deserialize[uint16],
+	"uint32_t":       deserialize[uint32],
+	"uint64_t":       deserialize[uint64],
+	"float":          deserialize[float32],
+	"double":         deserialize[float64],
+	"float complex":  deserialize[complex64],
+	"double complex": deserialize[complex128],
+}
+
+func typeNameOf(x any) string {
+	switch x.(type) {
+	case bool:
+		return "bool"
+	case int8:
+		return "int8_t"
+	case int16:
+		return "int16_t"
+	case int32:
+		return "int32_t"
+	case int64, int:
+		return "int64_t"
+	case uint8:
+		return "uint8_t"
+	case uint16:
+		return "uint16_t"
+	case uint32:
+		return "uint32_t"
+	case uint64, uint:
+		return "uint64_t"
+	case float32:
+		return "float"
+	case float64:
+		return "double"
+	case complex64:
+		return "float complex"
+	case complex128:
+		return "double complex"
+	}
+	panic(fmt.Sprintf("unsupported domain %T", x))
+}
+
+func deserialize[D domain](data []byte) (matrix, error) {
+	m, err := GrB.MatrixDeserialize[D](data)
+	if err != nil {
+		return nil, err
+	}
+	return &typed[D]{m}, nil
+}
+
+// readBlob reads a matrix serialized with [GrB.Matrix.SerializeBlob].
+func readBlob(data []byte) (matrix, error) {
+	name, err := GrB.DeserializeTypeName(data)
+	if err != nil {
+		return nil, err
+	}
+	if constructor, ok := typeNames[name]; ok {
+		return constructor(data)
+	}
+	return nil, fmt.Errorf("unsupported matrix type %q", name)
+}
+
+// writeBlob serializes a matrix with the given compression.
+func writeBlob(w io.Writer, m matrix, compression GrB.DescValue) (err error) {
+	desc, err := GrB.DescriptorNew()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if ferr := desc.Free(); err == nil {
+			err = ferr
+		}
+	}()
+	if err = desc.Set(GrB.Compression, compression); err != nil {
+		return
+	}
+	blob, err := m.SerializeBlob(&desc)
+	if err != nil {
+		return
+	}
+	defer blob.Free()
+	_, err = w.Write(blob.UnsafeSlice())
+	return
+}
@@ -0,0 +1,329 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/intel/forGraphBLASGo/GrB"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// mmHeader holds the information from the banner and the comments of a Matrix Market file.
+type mmHeader struct {
+	format   string // "coordinate" or "array"
+	field    string // "pattern", "integer", "real", or "complex"
+	symmetry string // "general", "symmetric", "skew-symmetric", or "hermitian"
+	typeName string // the SuiteSparse:GraphBLAS type, from a "%%GraphBLAS type" comment, if any
+}
+
+// scanner reads a text file line by line, keeping track of line numbers for error messages.
+type scanner struct {
+	*bufio.Scanner
+	line int
+}
+
+func newScanner(r io.Reader) *scanner {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	return &scanner{Scanner: s}
+}
+
+func (s *scanner) Scan() bool {
+	if s.Scanner.Scan() {
+		s.line++
+		return true
+	}
+	return false
+}
+
+func (s *scanner) errorf(format string, args ...any) error {
+	return fmt.Errorf("line %v: %v", s.line, fmt.Sprintf(format, args...))
+}
+
+// next returns the fields of the next line that is neither empty nor a comment.
+func (s *scanner) next(comment func(line string) bool) ([]string, error) {
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || comment(line) {
+			continue
+		}
+		return strings.Fields(line), nil
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.ErrUnexpectedEOF
+}
+
+// readMatrixMarket reads a matrix in Matrix Market format. Pattern matrices are read as
+// boolean matrices, integer matrices as int64, real matrices as float64, and complex matrices
+// as complex128, unless the file contains a "%%GraphBLAS type <name>" comment, as written by
+// [typed.writeMatrixMarket] and LAGraph, which determines the domain instead.
+func readMatrixMarket(r io.Reader) (matrix, error) {
+	s := newScanner(r)
+	if !s.Scan() {
+		if err := s.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.ErrUnexpectedEOF
+	}
+	banner := strings.Fields(strings.ToLower(s.Text()))
+	if len(banner) != 5 || banner[0] != "%%matrixmarket" || banner[1] != "matrix" {
+		return nil, s.errorf("invalid Matrix Market banner")
+	}
+	h := mmHeader{format: banner[2], field: banner[3], symmetry: banner[4]}
+	switch h.format {
+	case "coordinate", "array":
+	default:
+		return nil, s.errorf("unsupported Matrix Market format %q", h.format)
+	}
+	switch h.field {
+	case "pattern", "integer", "real", "complex":
+	default:
+		return nil, s.errorf("unsupported Matrix Market field %q", h.field)
+	}
+	switch h.symmetry {
+	case "general", "symmetric", "skew-symmetric", "hermitian":
+	default:
+		return nil, s.errorf("unsupported Matrix Market symmetry %q", h.symmetry)
+	}
+	if h.format == "array" && h.field == "pattern" {
+		return nil, s.errorf("pattern matrices must be in coordinate format")
+	}
+
+	comment := func(line string) bool {
+		if !strings.HasPrefix(line, "%") {
+			return false
+		}
+		if fields := strings.Fields(line); len(fields) == 3 && strings.EqualFold(fields[0], "%%GraphBLAS") && fields[1] == "type" {
+			h.typeName = fields[2]
+		} else if len(fields) == 4 && strings.EqualFold(fields[0], "%%GraphBLAS") && fields[1] == "type" {
+			// "float complex" and "double complex"
+			h.typeName = fields[2] + " " + fields[3]
+		}
+		return true
+	}
+	size, err := s.next(comment)
+	if err != nil {
+		return nil, err
+	}
+
+	typeName := h.typeName
+	if typeName == "" || h.field == "pattern" {
+		switch h.field {
+		case "pattern":
+			typeName = "bool"
+		case "integer":
+			typeName = "int64_t"
+		case "real":
+			typeName = "double"
+		case "complex":
+			typeName = "double complex"
+		}
+	}
+	switch typeName {
+	case "bool":
+		return readMatrixMarketEntries[bool](s, h, size)
+	case "int8_t":
+		return readMatrixMarketEntries[int8](s, h, size)
+	case "int16_t":
+		return readMatrixMarketEntries[int16](s, h, size)
+	case "int32_t":
+		return readMatrixMarketEntries[int32](s, h, size)
+	case "int64_t":
+		return readMatrixMarketEntries[int64](s, h, size)
+	case "uint8_t":
+		return readMatrixMarketEntries[uint8](s, h, size)
+	case "uint16_t":
+		return readMatrixMarketEntries[uint16](s, h, size)
+	case "uint32_t":
+		return readMatrixMarketEntries[uint32](s, h, size)
+	case "uint64_t":
+		return readMatrixMarketEntries[uint64](s, h, size)
+	case "float":
+		return readMatrixMarketEntries[float32](s, h, size)
+	case "double":
+		return readMatrixMarketEntries[float64](s, h, size)
+	case "float complex":
+		return readMatrixMarketEntries[complex64](s, h, size)
+	case "double complex":
+		return readMatrixMarketEntries[complex128](s, h, size)
+	}
+	return nil, fmt.Errorf("unsupported GraphBLAS type %q", typeName)
+}
+
+func parseIndices(s *scanner, fields []string) ([]int, error) {
+	result := make([]int, len(fields))
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return nil, s.errorf("invalid index or size %q", field)
+		}
+		result[i] = n
+	}
+	return result, nil
+}
+
+func readMatrixMarketEntries[D domain](s *scanner, h mmHeader, sizeFields []string) (result matrix, err error) {
+	comment := func(line string) bool { return strings.HasPrefix(line, "%") }
+
+	wantSize := 3
+	if h.format == "array" {
+		wantSize = 2
+	}
+	if len(sizeFields) != wantSize {
+		return nil, s.errorf("invalid size line")
+	}
+	size, err := parseIndices(s, sizeFields)
+	if err != nil {
+		return
+	}
+	nrows, ncols := size[0], size[1]
+	if h.symmetry != "general" && nrows != ncols {
+		return nil, s.errorf("%v matrix must be square", h.symmetry)
+	}
+
+	var rows, cols []int
+	var values []D
+	add := func(i, j int, value D) {
+		rows = append(rows, i)
+		cols = append(cols, j)
+		values = append(values, value)
+		if i == j {
+			return
+		}
+		switch h.symmetry {
+		case "symmetric":
+			rows, cols, values = append(rows, j), append(cols, i), append(values, value)
+		case "skew-symmetric":
+			rows, cols, values = append(rows, j), append(cols, i), append(values, negate(value))
+		case "hermitian":
+			rows, cols, values = append(rows, j), append(cols, i), append(values, conjugate(value))
+		}
+	}
+
+	var one D
+	if h.field == "pattern" {
+		one = any(true).(D)
+	}
+	nvalFields := valueFields[D]()
+	if h.field == "pattern" {
+		nvalFields = 0
+	}
+
+	if h.format == "coordinate" {
+		nvals := size[2]
+		for k := 0; k < nvals; k++ {
+			fields, err := s.next(comment)
+			if err != nil {
+				return nil, err
+			}
+			if len(fields) != 2+nvalFields {
+				return nil, s.errorf("expected %v fields, got %v", 2+nvalFields, len(fields))
+			}
+			ij, err := parseIndices(s, fields[:2])
+			if err != nil {
+				return nil, err
+			}
+			i, j := ij[0]-1, ij[1]-1
+			if i < 0 || i >= nrows || j < 0 || j >= ncols {
+				return nil, s.errorf("index (%v, %v) out of bounds", ij[0], ij[1])
+			}
+			value := one
+			if nvalFields > 0 {
+				if value, err = parseValue[D](fields[2:]); err != nil {
+					return nil, s.errorf("%v", err)
+				}
+			}
+			add(i, j, value)
+		}
+	} else {
+		// array format: column-major, and only the lower triangle for symmetric matrices
+		for j := 0; j < ncols; j++ {
+			first := 0
+			switch h.symmetry {
+			case "symmetric", "hermitian":
+				first = j
+			case "skew-symmetric":
+				first = j + 1
+			}
+			for i := first; i < nrows; i++ {
+				fields, err := s.next(comment)
+				if err != nil {
+					return nil, err
+				}
+				value, err := parseValue[D](fields)
+				if err != nil {
+					return nil, s.errorf("%v", err)
+				}
+				add(i, j, value)
+			}
+		}
+	}
+	if _, err := s.next(comment); err == nil {
+		return nil, s.errorf("unexpected data after the last entry")
+	} else if !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+
+	return build(nrows, ncols, rows, cols, values, nil)
+}
+
+// build creates a new matrix from the given tuples.
+func build[D domain](nrows, ncols int, rows, cols []int, values []D, dup *GrB.BinaryOp[D, D, D]) (matrix, error) {
+	m, err := GrB.MatrixNew[D](nrows, ncols)
+	if err != nil {
+		return nil, err
+	}
+	if err = m.Build(rows, cols, values, dup); err != nil {
+		_ = m.Free()
+		return nil, err
+	}
+	return &typed[D]{m}, nil
+}
+
+func (m typed[D]) writeMatrixMarket(w io.Writer) error {
+	nrows, ncols, err := m.Size()
+	if err != nil {
+		return err
+	}
+	rows, cols, values, err := m.tuples()
+	if err != nil {
+		return err
+	}
+	var field string
+	var d D
+	switch x := any(values).(type) {
+	case []bool:
+		field = "pattern"
+		for _, value := range x {
+			if !value {
+				field = "integer"
+				break
+			}
+		}
+	default:
+		switch any(d).(type) {
+		case float32, float64:
+			field = "real"
+		case complex64, complex128:
+			field = "complex"
+		default:
+			field = "integer"
+		}
+	}
+	bw := bufio.NewWriter(w)
+	_, _ = fmt.Fprintf(bw, "%%%%MatrixMarket matrix coordinate %v general\n", field)
+	_, _ = fmt.Fprintf(bw, "%%%%GraphBLAS type %v\n", m.typeName())
+	_, _ = fmt.Fprintf(bw, "%v %v %v\n", nrows, ncols, len(values))
+	for k, value := range values {
+		if field == "pattern" {
+			_, _ = fmt.Fprintf(bw, "%v %v\n", rows[k]+1, cols[k]+1)
+		} else {
+			_, _ = fmt.Fprintf(bw, "%v %v %v\n", rows[k]+1, cols[k]+1, formatValue(value))
+		}
+	}
+	return bw.Flush()
+}
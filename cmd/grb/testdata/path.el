@@ -0,0 +1,4 @@
+# a directed path 1 -> 2 -> 3 -> 4, with 1-based vertex indices
+1 2
+2 3
+3 4
@@ -0,0 +1,5 @@
+# a small directed graph with weights
+0 1 0.5
+0 2 1.5
+1 2 2
+2 0 -1
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// parseValue parses the textual representation of a value of domain D. Complex values
+// are represented by two fields, the real and the imaginary part; all other values by
+// a single field.
+func parseValue[D domain](fields []string) (value D, err error) {
+	if want := valueFields[D](); len(fields) != want {
+		err = fmt.Errorf("expected %v value field(s), got %v", want, len(fields))
+		return
+	}
+	switch x := any(&value).(type) {
+	case *bool:
+		var v int64
+		if v, err = strconv.ParseInt(fields[0], 10, 64); err == nil {
+			*x = v != 0
+		}
+	case *int8:
+		var v int64
+		if v, err = strconv.ParseInt(fields[0], 10, 8); err == nil {
+			*x = int8(v)
+		}
+	case *int16:
+		var v int64
+		if v, err = strconv.ParseInt(fields[0], 10, 16); err == nil {
+			*x = int16(v)
+		}
+	case *int32:
+		var v int64
+		if v, err = strconv.ParseInt(fields[0], 10, 32); err == nil {
+			*x = int32(v)
+		}
+	case *int64:
+		*x, err = strconv.ParseInt(fields[0], 10, 64)
+	case *uint8:
+		var v uint64
+		if v, err = strconv.ParseUint(fields[0], 10, 8); err == nil {
+			*x = uint8(v)
+		}
+	case *uint16:
+		var v uint64
+		if v, err = strconv.ParseUint(fields[0], 10, 16); err == nil {
+			*x = uint16(v)
+		}
+	case *uint32:
+		var v uint64
+		if v, err = strconv.ParseUint(fields[0], 10, 32); err == nil {
+			*x = uint32(v)
+		}
+	case *uint64:
+		*x, err = strconv.ParseUint(fields[0], 10, 64)
+	case *float32:
+		var v float64
+		if v, err = strconv.ParseFloat(fields[0], 32); err == nil {
+			*x = float32(v)
+		}
+	case *float64:
+		*x, err = strconv.ParseFloat(fields[0], 64)
+	case *complex64:
+		var re, im float64
+		if re, err = strconv.ParseFloat(fields[0], 32); err != nil {
+			return
+		}
+		if im, err = strconv.ParseFloat(fields[1], 32); err == nil {
+			*x = complex(float32(re), float32(im))
+		}
+	case *complex128:
+		var re, im float64
+		if re, err = strconv.ParseFloat(fields[0], 64); err != nil {
+			return
+		}
+		if im, err = strconv.ParseFloat(fields[1], 64); err == nil {
+			*x = complex(re, im)
+		}
+	default:
+		panic(fmt.Sprintf("unsupported domain %T", value))
+	}
+	return
+}
+
+// formatValue returns the textual representation of a value, as accepted by [parseValue].
+func formatValue[D domain](value D) string {
+	switch x := any(value).(type) {
+	case bool:
+		if x {
+			return "1"
+		}
+		return "0"
+	case float32:
+		return strconv.FormatFloat(float64(x), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(x, 'g', -1, 64)
+	case complex64:
+		return strconv.FormatFloat(float64(real(x)), 'g', -1, 32) + " " + strconv.FormatFloat(float64(imag(x)), 'g', -1, 32)
+	case complex128:
+		return strconv.FormatFloat(real(x), 'g', -1, 64) + " " + strconv.FormatFloat(imag(x), 'g', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// valueFields returns the number of fields used to represent a value of domain D.
+func valueFields[D domain]() int {
+	var d D
+	switch any(d).(type) {
+	case complex64, complex128:
+		return 2
+	}
+	return 1
+}
+
+// negate returns -value, for skew-symmetric Matrix Market files.
+func negate[D domain](value D) D {
+	switch x := any(&value).(type) {
+	case *bool:
+		return value
+	case *int8:
+		*x = -*x
+	case *int16:
+		*x = -*x
+	case *int32:
+		*x = -*x
+	case *int64:
+		*x = -*x
+	case *uint8:
+		*x = -*x
+	case *uint16:
+		*x = -*x
+	case *uint32:
+		*x = -*x
+	case *uint64:
+		*x = -*x
+	case *float32:
+		*x = -*x
+	case *float64:
+		*x = -*x
+	case *complex64:
+		*x = -*x
+	case *complex128:
+		*x = -*x
+	}
+	return value
+}
+
+// conjugate returns the complex conjugate of value, for hermitian Matrix Market files.
+func conjugate[D domain](value D) D {
+	switch x := any(&value).(type) {
+	case *complex64:
+		*x = complex(real(*x), -imag(*x))
+	case *complex128:
+		*x = complex(real(*x), -imag(*x))
+	}
+	return value
+}